@@ -8,12 +8,26 @@ import (
 func main() {
 	configPath := flag.String("config", "./config/development.yml", "path to the config file")
 	migrateFlag := flag.Bool("migrate", false, "perform a schema migration")
+	lintFlag := flag.Bool("lint", false, "check the game data for problems without serving traffic")
+	exportArea := flag.String("export-area", "", "export the named area to a bundle file")
+	importArea := flag.String("import-area", "", "import an area from the bundle file at this path")
+	bundlePath := flag.String("bundle", "", "path to write the exported bundle to (defaults to the data directory)")
+	areaName := flag.String("area-name", "", "name to use for the imported area (defaults to the name in the bundle)")
 
 	flag.Parse()
 
 	if *migrateFlag {
 		armeria.Init(*configPath, false)
 		armeria.Migrate()
+	} else if *lintFlag {
+		armeria.InitOffline(*configPath)
+		armeria.LintGameData()
+	} else if len(*exportArea) > 0 {
+		armeria.InitOffline(*configPath)
+		armeria.ExportAreaBundle(*exportArea, *bundlePath)
+	} else if len(*importArea) > 0 {
+		armeria.InitOffline(*configPath)
+		armeria.ImportAreaBundle(*importArea, *areaName)
 	} else {
 		armeria.Init(*configPath, true)
 	}
@@ -0,0 +1,209 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MaxCharactersPerAccount is how many characters a player can create on their account.
+const MaxCharactersPerAccount int = 5
+
+// Account is what a player logs in with. An account owns one or more characters, and its permissions and bans
+// apply to all of them.
+type Account struct {
+	sync.RWMutex
+	UUID              string    `json:"uuid"`
+	UnsafeUsername    string    `json:"username"`
+	UnsafePassword    string    `json:"password"`
+	UnsafeCharacters  []string  `json:"characters"`
+	UnsafePermissions string    `json:"permissions"`
+	UnsafeBanned      bool      `json:"banned"`
+	UnsafeBanReason   string    `json:"banReason"`
+	UnsafeBannedUntil time.Time `json:"bannedUntil"`
+	UnsafeCreated     time.Time `json:"created"`
+}
+
+// ID returns the uuid of the Account.
+func (a *Account) ID() string {
+	return a.UUID
+}
+
+// Username returns the name the Account logs in with.
+func (a *Account) Username() string {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafeUsername
+}
+
+// CheckPassword returns true if the password matches the Account's password.
+func (a *Account) CheckPassword(pw string) bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return bcrypt.CompareHashAndPassword([]byte(a.UnsafePassword), []byte(pw)) == nil
+}
+
+// SetPassword encrypts and sets the Account's password.
+func (a *Account) SetPassword(pw string) {
+	a.Lock()
+	defer a.Unlock()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(pw), bcrypt.MinCost)
+	if err != nil {
+		Armeria.log.Fatal("error generating password hash",
+			zap.Error(err),
+		)
+	}
+
+	a.UnsafePassword = string(hash)
+}
+
+// Characters returns the Characters owned by the Account.
+func (a *Account) Characters() []*Character {
+	a.RLock()
+	defer a.RUnlock()
+
+	var chars []*Character
+	for _, id := range a.UnsafeCharacters {
+		if c := Armeria.characterManager.CharacterById(id); c != nil {
+			chars = append(chars, c)
+		}
+	}
+
+	return chars
+}
+
+// CharacterByName returns one of the Characters owned by the Account, by name.
+func (a *Account) CharacterByName(name string) *Character {
+	for _, c := range a.Characters() {
+		if strings.ToLower(c.Name()) == strings.ToLower(name) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Owns returns true if the Account owns the Character.
+func (a *Account) Owns(c *Character) bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	return misc.Contains(a.UnsafeCharacters, c.ID())
+}
+
+// AddCharacter gives the Account ownership of a Character.
+func (a *Account) AddCharacter(c *Character) {
+	a.Lock()
+	a.UnsafeCharacters = append(a.UnsafeCharacters, c.ID())
+	a.Unlock()
+
+	Armeria.accountManager.indexCharacter(c.ID(), a)
+}
+
+// RemoveCharacter removes a Character from the Account.
+func (a *Account) RemoveCharacter(c *Character) {
+	a.Lock()
+	for i, id := range a.UnsafeCharacters {
+		if id == c.ID() {
+			a.UnsafeCharacters = append(a.UnsafeCharacters[:i], a.UnsafeCharacters[i+1:]...)
+			break
+		}
+	}
+	a.Unlock()
+
+	Armeria.accountManager.unindexCharacter(c.ID(), a)
+}
+
+// Permissions returns the space-separated permissions granted to every Character on the Account.
+func (a *Account) Permissions() string {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafePermissions
+}
+
+// SetPermissions sets the space-separated permissions granted to every Character on the Account.
+func (a *Account) SetPermissions(perms string) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.UnsafePermissions = strings.Join(strings.Fields(perms), " ")
+}
+
+// HasPermission returns true if the Account has been granted a particular permission.
+func (a *Account) HasPermission(p string) bool {
+	return misc.Contains(strings.Fields(a.Permissions()), p)
+}
+
+// Banned returns true if the Account is banned. A ban with an expiry is lifted once it has passed.
+func (a *Account) Banned() bool {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.UnsafeBanned && !a.UnsafeBannedUntil.IsZero() && time.Now().After(a.UnsafeBannedUntil) {
+		a.UnsafeBanned = false
+		a.UnsafeBanReason = ""
+		a.UnsafeBannedUntil = time.Time{}
+	}
+
+	return a.UnsafeBanned
+}
+
+// BanReason returns why the Account was banned.
+func (a *Account) BanReason() string {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafeBanReason
+}
+
+// BannedUntil returns when the Account's ban is lifted, or the zero time if the ban is permanent.
+func (a *Account) BannedUntil() time.Time {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafeBannedUntil
+}
+
+// Ban bans the Account. A zero duration bans the Account permanently.
+func (a *Account) Ban(reason string, d time.Duration) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.UnsafeBanned = true
+	a.UnsafeBanReason = reason
+	a.UnsafeBannedUntil = time.Time{}
+	if d > 0 {
+		a.UnsafeBannedUntil = time.Now().Add(d)
+	}
+}
+
+// Unban lifts the Account's ban.
+func (a *Account) Unban() {
+	a.Lock()
+	defer a.Unlock()
+
+	a.UnsafeBanned = false
+	a.UnsafeBanReason = ""
+	a.UnsafeBannedUntil = time.Time{}
+}
+
+// Created returns when the Account was created.
+func (a *Account) Created() time.Time {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafeCreated
+}
+
+// Account returns the Account that owns the Character, or nil if it isn't owned by one.
+func (c *Character) Account() *Account {
+	return Armeria.accountManager.AccountByCharacter(c)
+}
@@ -0,0 +1,196 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.uber.org/zap"
+)
+
+// AccountManager keeps track of the accounts players log in with, and which account owns each character.
+type AccountManager struct {
+	sync.RWMutex
+	dataFile       string
+	characters     map[string]*Account
+	UnsafeAccounts []*Account `json:"accounts"`
+}
+
+// NewAccountManager creates a new AccountManager.
+func NewAccountManager() *AccountManager {
+	m := &AccountManager{
+		dataFile:   fmt.Sprintf("%s/accounts.json", Armeria.dataPath),
+		characters: make(map[string]*Account),
+	}
+
+	m.LoadAccounts()
+
+	return m
+}
+
+// LoadAccounts loads the accounts from disk into memory.
+func (m *AccountManager) LoadAccounts() {
+	m.Lock()
+	defer m.Unlock()
+
+	accountsFile, err := os.Open(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer accountsFile.Close()
+
+	jsonParser := json.NewDecoder(accountsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	for _, a := range m.UnsafeAccounts {
+		for _, id := range a.UnsafeCharacters {
+			m.characters[id] = a
+		}
+	}
+
+	Armeria.log.Info("accounts loaded",
+		zap.Int("count", len(m.UnsafeAccounts)),
+	)
+}
+
+// SaveAccounts writes the in-memory accounts to disk.
+func (m *AccountManager) SaveAccounts() {
+	m.RLock()
+	defer m.RUnlock()
+
+	accountsFile, err := os.Create(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to create data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer accountsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := accountsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = accountsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Accounts returns all of the in-memory Accounts.
+func (m *AccountManager) Accounts() []*Account {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeAccounts
+}
+
+// AccountByUsername returns the matching Account, by username.
+func (m *AccountManager) AccountByUsername(username string) *Account {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, a := range m.UnsafeAccounts {
+		if strings.ToLower(a.Username()) == strings.ToLower(username) {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// AccountByCharacter returns the Account that owns a Character.
+func (m *AccountManager) AccountByCharacter(c *Character) *Account {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.characters[c.ID()]
+}
+
+// indexCharacter records that an Account owns a Character.
+func (m *AccountManager) indexCharacter(characterID string, a *Account) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.characters[characterID] = a
+}
+
+// unindexCharacter forgets that an Account owns a Character.
+func (m *AccountManager) unindexCharacter(characterID string, a *Account) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.characters[characterID] == a {
+		delete(m.characters, characterID)
+	}
+}
+
+// CreateAccount creates a new Account, adds it to memory and returns the Account.
+func (m *AccountManager) CreateAccount(username, password string) *Account {
+	a := &Account{
+		UUID:             uuid.New().String(),
+		UnsafeUsername:   username,
+		UnsafeCharacters: []string{},
+		UnsafeCreated:    time.Now(),
+	}
+
+	a.SetPassword(password)
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeAccounts = append(m.UnsafeAccounts, a)
+
+	Armeria.log.Info("account created",
+		zap.String("username", username),
+	)
+
+	return a
+}
+
+// RemoveAccount removes an Account from memory.
+func (m *AccountManager) RemoveAccount(a *Account) {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, aa := range m.UnsafeAccounts {
+		if aa == a {
+			m.UnsafeAccounts = append(m.UnsafeAccounts[:i], m.UnsafeAccounts[i+1:]...)
+			break
+		}
+	}
+
+	for id, owner := range m.characters {
+		if owner == a {
+			delete(m.characters, id)
+		}
+	}
+}
@@ -0,0 +1,628 @@
+package armeria
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"go.uber.org/zap"
+)
+
+// AreaBundle is a self-contained, portable copy of an Area along with every item, mob, ledger, script and
+// picture that the Area references. Bundles can be exported from one game world and imported into another.
+type AreaBundle struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Area          *Area             `json:"area"`
+	Items         []*Item           `json:"items"`
+	Mobs          []*Mob            `json:"mobs"`
+	Ledgers       []*Ledger         `json:"ledgers"`
+	Scripts       map[string]string `json:"scripts"`
+	Pictures      map[string]string `json:"pictures"`
+}
+
+// areaExporter keeps track of the definitions that have already been copied into an AreaBundle.
+type areaExporter struct {
+	bundle  *AreaBundle
+	items   map[string]*Item
+	mobs    map[string]*Mob
+	ledgers map[string]*Ledger
+}
+
+var (
+	// ErrBundleSchemaMismatch is an error for when a bundle was exported using a different schema version.
+	ErrBundleSchemaMismatch = errors.New("bundle was exported using a different schema version")
+	// ErrBundleConflicts is an error for when a bundle conflicts with the existing game data.
+	ErrBundleConflicts = errors.New("bundle conflicts with existing game data")
+	// ErrBundleInvalidName is an error for when an item or mob within a bundle has a name that can't be used.
+	ErrBundleInvalidName = errors.New("bundle contains an item or mob with an invalid name")
+
+	// shopLedgerRegex matches the ledger names used by shop() calls within a mob script.
+	shopLedgerRegex = regexp.MustCompile(`shop\(\s*["']([^"']+)["']`)
+)
+
+// AreaBundleFile returns the default path of the bundle file for an area.
+func AreaBundleFile(name string) string {
+	return fmt.Sprintf(
+		"%s/bundles/area-%s.json",
+		Armeria.dataPath,
+		strings.ToLower(strings.ReplaceAll(name, " ", "-")),
+	)
+}
+
+// copyAttributes returns a copy of an attribute map.
+func copyAttributes(attrs map[string]string) map[string]string {
+	c := make(map[string]string)
+	for k, v := range attrs {
+		c[k] = v
+	}
+	return c
+}
+
+// attributesEqual returns true if both attribute maps have the same values. A missing attribute is treated the
+// same as an empty one.
+func attributesEqual(a, b map[string]string) bool {
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	for k, v := range b {
+		if a[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ledgersEqual returns true if both ledgers contain the same entries and prices.
+func ledgersEqual(a, b *Ledger) bool {
+	if len(a.Entries()) != len(b.Entries()) {
+		return false
+	}
+
+	for _, entry := range a.Entries() {
+		other := b.Contains(entry.ItemName)
+		if other == nil || other.BuyPrice != entry.BuyPrice || other.SellPrice != entry.SellPrice ||
+			other.Stock != entry.Stock || other.RestockMinutes != entry.RestockMinutes ||
+			other.Elasticity != entry.Elasticity {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ExportArea builds an AreaBundle from an Area. Characters within the area are not exported.
+func ExportArea(a *Area) (*AreaBundle, error) {
+	raw, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	area := &Area{}
+	if err := json.Unmarshal(raw, area); err != nil {
+		return nil, err
+	}
+
+	e := &areaExporter{
+		bundle: &AreaBundle{
+			SchemaVersion: SchemaVersion,
+			Area:          area,
+			Items:         []*Item{},
+			Mobs:          []*Mob{},
+			Ledgers:       []*Ledger{},
+			Scripts:       make(map[string]string),
+			Pictures:      make(map[string]string),
+		},
+		items:   make(map[string]*Item),
+		mobs:    make(map[string]*Mob),
+		ledgers: make(map[string]*Ledger),
+	}
+
+	for _, r := range area.UnsafeRooms {
+		if r.UnsafeHere == nil {
+			continue
+		}
+
+		objects := make([]*ObjectContainerDefinition, 0)
+		for _, ocd := range r.UnsafeHere.UnsafeObjects {
+			o, rt := Armeria.registry.Get(ocd.UUID)
+			switch rt {
+			case RegistryTypeItemInstance:
+				e.addItemInstance(o.(*ItemInstance))
+				objects = append(objects, ocd)
+			case RegistryTypeMobInstance:
+				e.addMobInstance(o.(*MobInstance))
+				objects = append(objects, ocd)
+			}
+		}
+		r.UnsafeHere.UnsafeObjects = objects
+	}
+
+	return e.bundle, nil
+}
+
+// addPicture copies an object picture into the bundle.
+func (e *areaExporter) addPicture(key string) {
+	if len(key) == 0 || len(e.bundle.Pictures[key]) > 0 {
+		return
+	}
+
+	b, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", Armeria.objectImagesPath, key))
+	if err != nil {
+		Armeria.log.Error("error reading object picture for area bundle",
+			zap.String("picture", key),
+			zap.Error(err),
+		)
+		return
+	}
+
+	e.bundle.Pictures[key] = base64.StdEncoding.EncodeToString(b)
+}
+
+// addItem copies an Item definition (without instances) into the bundle.
+func (e *areaExporter) addItem(i *Item) *Item {
+	key := strings.ToLower(i.Name())
+	if bi, found := e.items[key]; found {
+		return bi
+	}
+
+	i.RLock()
+	bi := &Item{
+		UnsafeName:       i.UnsafeName,
+		UnsafeAttributes: copyAttributes(i.UnsafeAttributes),
+		UnsafeInstances:  []*ItemInstance{},
+	}
+	i.RUnlock()
+
+	e.items[key] = bi
+	e.bundle.Items = append(e.bundle.Items, bi)
+
+	e.addPicture(i.Attribute(AttributePicture))
+
+	if i.Attribute(AttributeType) == ItemTypeMobSpawner {
+		if m := Armeria.mobManager.MobByName(i.Attribute(AttributeSpawnMob)); m != nil {
+			e.addMob(m)
+		}
+	}
+
+	return bi
+}
+
+// addItemInstance copies an ItemInstance, the items within it and its parent Item into the bundle.
+func (e *areaExporter) addItemInstance(ii *ItemInstance) {
+	bi := e.addItem(ii.Parent)
+
+	var contents *ObjectContainer
+	if ii.HasContents() {
+		contents = NewObjectContainer(ii.Contents().MaxSize())
+		for _, ci := range ii.Contents().Items() {
+			e.addItemInstance(ci)
+			contents.UnsafeObjects = append(contents.UnsafeObjects, &ObjectContainerDefinition{
+				UUID: ci.ID(),
+				Slot: ii.Contents().Slot(ci.ID()),
+			})
+		}
+	}
+
+	ii.RLock()
+	bi.UnsafeInstances = append(bi.UnsafeInstances, &ItemInstance{
+		UUID:             ii.UUID,
+		UnsafeAttributes: copyAttributes(ii.UnsafeAttributes),
+		UnsafeQuantity:   ii.UnsafeQuantity,
+		UnsafeContents:   contents,
+	})
+	ii.RUnlock()
+}
+
+// addMob copies a Mob definition (without instances), its script and anything the script references into
+// the bundle.
+func (e *areaExporter) addMob(m *Mob) *Mob {
+	key := strings.ToLower(m.Name())
+	if bm, found := e.mobs[key]; found {
+		return bm
+	}
+
+	m.RLock()
+	bm := &Mob{
+		UnsafeName:       m.UnsafeName,
+		UnsafeAttributes: copyAttributes(m.UnsafeAttributes),
+		UnsafeInstances:  []*MobInstance{},
+	}
+	m.RUnlock()
+
+	e.mobs[key] = bm
+	e.bundle.Mobs = append(e.bundle.Mobs, bm)
+	e.bundle.Scripts[bm.UnsafeName] = m.Script()
+
+	e.addPicture(m.Attribute(AttributePicture))
+
+	if crumb := Armeria.itemManager.ItemByName(m.Attribute(AttributeFollowCrumb)); crumb != nil {
+		e.addItem(crumb)
+	}
+
+	for _, match := range shopLedgerRegex.FindAllStringSubmatch(m.Script(), -1) {
+		if l := Armeria.ledgerManager.LedgerByName(match[1]); l != nil {
+			e.addLedger(l)
+		}
+	}
+
+	return bm
+}
+
+// addMobInstance copies a MobInstance, its inventory and its parent Mob into the bundle.
+func (e *areaExporter) addMobInstance(mi *MobInstance) {
+	bm := e.addMob(mi.Parent)
+
+	inv := NewObjectContainer(mi.Inventory().MaxSize())
+	for _, ii := range mi.Inventory().Items() {
+		e.addItemInstance(ii)
+		inv.UnsafeObjects = append(inv.UnsafeObjects, &ObjectContainerDefinition{
+			UUID: ii.ID(),
+			Slot: mi.Inventory().Slot(ii.ID()),
+		})
+	}
+
+	mi.RLock()
+	bm.UnsafeInstances = append(bm.UnsafeInstances, &MobInstance{
+		UUID:                 mi.UUID,
+		UnsafeAttributes:     copyAttributes(mi.UnsafeAttributes),
+		UnsafeInventory:      inv,
+		UnsafeMobSpawnerUUID: mi.UnsafeMobSpawnerUUID,
+	})
+	mi.RUnlock()
+}
+
+// addLedger copies a Ledger, and the items on it, into the bundle.
+func (e *areaExporter) addLedger(l *Ledger) {
+	key := strings.ToLower(l.Name())
+	if _, found := e.ledgers[key]; found {
+		return
+	}
+
+	bl := &Ledger{UnsafeName: l.Name()}
+	for _, entry := range l.Entries() {
+		copied := *entry
+		bl.UnsafeEntries = append(bl.UnsafeEntries, &copied)
+	}
+
+	e.ledgers[key] = bl
+	e.bundle.Ledgers = append(e.bundle.Ledgers, bl)
+
+	for _, entry := range bl.UnsafeEntries {
+		if i := Armeria.itemManager.ItemByName(entry.ItemName); i != nil {
+			e.addItem(i)
+		}
+	}
+}
+
+// Conflicts returns a description of every conflict between the bundle and the existing game data. Items, mobs
+// and ledgers that already exist with identical definitions are not conflicts; they are re-used on import.
+func (b *AreaBundle) Conflicts(areaName string) []string {
+	conflicts := make([]string, 0)
+
+	if Armeria.worldManager.AreaByName(areaName) != nil {
+		conflicts = append(conflicts, fmt.Sprintf("an area named '%s' already exists", areaName))
+	}
+
+	for _, bi := range b.Items {
+		i := Armeria.itemManager.ItemByName(bi.UnsafeName)
+		if i == nil {
+			continue
+		}
+		i.RLock()
+		equal := attributesEqual(i.UnsafeAttributes, bi.UnsafeAttributes)
+		i.RUnlock()
+		if !equal {
+			conflicts = append(conflicts, fmt.Sprintf("item '%s' already exists with different attributes", bi.UnsafeName))
+		}
+	}
+
+	for _, bm := range b.Mobs {
+		m := Armeria.mobManager.MobByName(bm.UnsafeName)
+		if m == nil {
+			continue
+		}
+		m.RLock()
+		equal := attributesEqual(m.UnsafeAttributes, bm.UnsafeAttributes)
+		m.RUnlock()
+		if !equal {
+			conflicts = append(conflicts, fmt.Sprintf("mob '%s' already exists with different attributes", bm.UnsafeName))
+		} else if strings.TrimSpace(m.Script()) != strings.TrimSpace(b.Scripts[bm.UnsafeName]) {
+			conflicts = append(conflicts, fmt.Sprintf("mob '%s' already exists with a different script", bm.UnsafeName))
+		}
+	}
+
+	for _, bl := range b.Ledgers {
+		l := Armeria.ledgerManager.LedgerByName(bl.UnsafeName)
+		if l != nil && !ledgersEqual(l, bl) {
+			conflicts = append(conflicts, fmt.Sprintf("ledger '%s' already exists with different entries", bl.UnsafeName))
+		}
+	}
+
+	return conflicts
+}
+
+// validBundleName returns true if an item or mob name from a bundle is safe to use, since mob names become part
+// of the path to their script file.
+func validBundleName(name string) bool {
+	return len(strings.TrimSpace(name)) > 0 && !strings.ContainsAny(name, `/\`) && !strings.Contains(name, "..")
+}
+
+// remapContainer rewrites the uuids within an ObjectContainer, dropping anything that was not remapped.
+func remapContainer(oc *ObjectContainer, remap map[string]string) {
+	objects := make([]*ObjectContainerDefinition, 0)
+	for _, ocd := range oc.UnsafeObjects {
+		if newUUID, found := remap[ocd.UUID]; found {
+			ocd.UUID = newUUID
+			objects = append(objects, ocd)
+		}
+	}
+	oc.UnsafeObjects = objects
+}
+
+// writeBundleFiles writes the pictures and scripts from a bundle to disk. If any file cannot be written, the files
+// that were already written are removed again.
+func writeBundleFiles(files map[string][]byte) error {
+	var written []string
+	for path, data := range files {
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			for _, w := range written {
+				_ = os.Remove(w)
+			}
+			return err
+		}
+		written = append(written, path)
+	}
+
+	return nil
+}
+
+// ImportArea adds the contents of a bundle to the game world as a new area named areaName (or the bundle's area
+// name, if empty). Every uuid within the bundle is remapped so that the same bundle can be imported more than once.
+// Nothing is changed unless the whole bundle can be imported.
+func ImportArea(b *AreaBundle, areaName string) (*Area, error) {
+	if b.SchemaVersion != SchemaVersion {
+		return nil, ErrBundleSchemaMismatch
+	}
+
+	oldAreaName := b.Area.UnsafeName
+	if len(areaName) == 0 {
+		areaName = oldAreaName
+	}
+
+	for _, bi := range b.Items {
+		if !validBundleName(bi.UnsafeName) {
+			return nil, ErrBundleInvalidName
+		}
+	}
+	for _, bm := range b.Mobs {
+		if !validBundleName(bm.UnsafeName) {
+			return nil, ErrBundleInvalidName
+		}
+	}
+
+	if len(b.Conflicts(areaName)) > 0 {
+		return nil, ErrBundleConflicts
+	}
+
+	// Generate new uuids for everything up-front so references can be rewritten.
+	remap := make(map[string]string)
+	for _, bi := range b.Items {
+		for _, ii := range bi.UnsafeInstances {
+			remap[ii.UUID] = uuid.New().String()
+		}
+	}
+	for _, bm := range b.Mobs {
+		for _, mi := range bm.UnsafeInstances {
+			remap[mi.UUID] = uuid.New().String()
+		}
+	}
+
+	// Prepare the files to write before anything is changed, so that a bad bundle leaves the game untouched.
+	files := make(map[string][]byte)
+	for key, data := range b.Pictures {
+		pictureFile := fmt.Sprintf("%s/%s", Armeria.objectImagesPath, filepath.Base(key))
+		if _, err := os.Stat(pictureFile); err == nil {
+			continue
+		}
+		dec, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, err
+		}
+		files[pictureFile] = dec
+	}
+	for _, bm := range b.Mobs {
+		if Armeria.mobManager.MobByName(bm.UnsafeName) == nil {
+			m := &Mob{UnsafeName: bm.UnsafeName}
+			files[m.ScriptFile()] = []byte(b.Scripts[bm.UnsafeName])
+		}
+	}
+
+	// The files are the only part of the import that can fail, so they are written before the game world changes.
+	if err := writeBundleFiles(files); err != nil {
+		return nil, err
+	}
+
+	for _, bl := range b.Ledgers {
+		if Armeria.ledgerManager.LedgerByName(bl.UnsafeName) == nil {
+			Armeria.ledgerManager.AddLedger(bl)
+		}
+	}
+
+	for _, bi := range b.Items {
+		i := Armeria.itemManager.ItemByName(bi.UnsafeName)
+		if i == nil {
+			i = Armeria.itemManager.CreateItem(bi.UnsafeName)
+			i.UnsafeAttributes = copyAttributes(bi.UnsafeAttributes)
+			i.Init()
+			Armeria.itemManager.AddItem(i)
+		}
+
+		for _, bii := range bi.UnsafeInstances {
+			if bii.UnsafeContents != nil {
+				remapContainer(bii.UnsafeContents, remap)
+			}
+
+			i.AddInstance(&ItemInstance{
+				UUID:             remap[bii.UUID],
+				UnsafeAttributes: copyAttributes(bii.UnsafeAttributes),
+				UnsafeQuantity:   bii.UnsafeQuantity,
+				UnsafeContents:   bii.UnsafeContents,
+			})
+		}
+	}
+
+	for _, bm := range b.Mobs {
+		m := Armeria.mobManager.MobByName(bm.UnsafeName)
+		if m == nil {
+			m = &Mob{
+				UnsafeName:       bm.UnsafeName,
+				UnsafeAttributes: copyAttributes(bm.UnsafeAttributes),
+			}
+			m.Init()
+			Armeria.mobManager.AddMob(m)
+		}
+
+		for _, bmi := range bm.UnsafeInstances {
+			inv := bmi.UnsafeInventory
+			if inv == nil {
+				inv = NewObjectContainer(0)
+			}
+			remapContainer(inv, remap)
+
+			m.AddInstance(&MobInstance{
+				UUID:                 remap[bmi.UUID],
+				UnsafeAttributes:     copyAttributes(bmi.UnsafeAttributes),
+				UnsafeInventory:      inv,
+				UnsafeMobSpawnerUUID: remap[bmi.UnsafeMobSpawnerUUID],
+			})
+		}
+	}
+
+	a := b.Area
+	a.UUID = uuid.New().String()
+	a.UnsafeName = areaName
+	if a.UnsafeAttributes == nil {
+		a.UnsafeAttributes = make(map[string]string)
+	}
+	a.Init()
+
+	for _, r := range a.UnsafeRooms {
+		r.UUID = uuid.New().String()
+		if r.UnsafeHere != nil {
+			remapContainer(r.UnsafeHere, remap)
+		}
+		// Exits that explicitly reference the area by name need to follow the rename.
+		for _, dir := range []string{AttributeNorth, AttributeSouth, AttributeEast, AttributeWest, AttributeUp, AttributeDown} {
+			exit := r.UnsafeAttributes[dir]
+			if areaName != oldAreaName && strings.HasPrefix(strings.ToLower(exit), strings.ToLower(oldAreaName)+",") {
+				r.UnsafeAttributes[dir] = areaName + exit[len(oldAreaName):]
+			}
+		}
+		r.Init(a)
+	}
+
+	Armeria.worldManager.AddArea(a)
+
+	Armeria.log.Info("area imported",
+		zap.String("area", areaName),
+		zap.Int("rooms", len(a.UnsafeRooms)),
+	)
+
+	return a, nil
+}
+
+// WriteAreaBundle writes the bundle to disk. The JSON is indented so that bundles can be reviewed easily.
+func WriteAreaBundle(b *AreaBundle, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, raw, 0644)
+}
+
+// ReadAreaBundle reads a bundle from disk.
+func ReadAreaBundle(path string) (*AreaBundle, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &AreaBundle{}
+	if err := json.Unmarshal(raw, b); err != nil {
+		return nil, err
+	}
+
+	if b.Area == nil {
+		return nil, errors.New("bundle does not contain an area")
+	}
+
+	return b, nil
+}
+
+// ExportAreaBundle exports an area to a bundle file from the command-line. If path is empty, the bundle is
+// written to the default location within the data directory.
+func ExportAreaBundle(name, path string) {
+	a := Armeria.worldManager.AreaByName(name)
+	if a == nil {
+		Armeria.log.Fatal("area not found", zap.String("area", name))
+	}
+
+	if len(path) == 0 {
+		path = AreaBundleFile(a.Name())
+	}
+
+	b, err := ExportArea(a)
+	if err != nil {
+		Armeria.log.Fatal("error exporting area", zap.String("area", name), zap.Error(err))
+	}
+
+	if err := WriteAreaBundle(b, path); err != nil {
+		Armeria.log.Fatal("error writing area bundle", zap.String("file", path), zap.Error(err))
+	}
+
+	Armeria.log.Info("area exported",
+		zap.String("area", a.Name()),
+		zap.String("file", path),
+	)
+}
+
+// ImportAreaBundle imports an area from a bundle file from the command-line and writes the game data to disk.
+// The game server should not be running while this happens.
+func ImportAreaBundle(path, name string) {
+	b, err := ReadAreaBundle(path)
+	if err != nil {
+		Armeria.log.Fatal("error reading area bundle", zap.String("file", path), zap.Error(err))
+	}
+
+	if len(name) == 0 {
+		name = b.Area.UnsafeName
+	}
+
+	if conflicts := b.Conflicts(name); len(conflicts) > 0 {
+		Armeria.log.Fatal("area bundle conflicts with existing game data",
+			zap.String("file", path),
+			zap.Strings("conflicts", conflicts),
+		)
+	}
+
+	if _, err := ImportArea(b, name); err != nil {
+		Armeria.log.Fatal("error importing area bundle", zap.String("file", path), zap.Error(err))
+	}
+
+	Armeria.Save()
+}
@@ -4,6 +4,7 @@ import (
 	"armeria/internal/pkg/misc"
 	"encoding/json"
 	"log"
+	"strings"
 	"sync"
 
 	"go.uber.org/zap"
@@ -74,6 +75,41 @@ func (a *Area) RoomAt(c *Coords) *Room {
 	return nil
 }
 
+// RoomsWithin returns the Rooms within the box formed by two Coords (inclusive).
+func (a *Area) RoomsWithin(from *Coords, to *Coords) []*Room {
+	a.RLock()
+	defer a.RUnlock()
+
+	minX, maxX := misc.MinMax(from.X(), to.X())
+	minY, maxY := misc.MinMax(from.Y(), to.Y())
+	minZ, maxZ := misc.MinMax(from.Z(), to.Z())
+
+	var rooms []*Room
+	for _, r := range a.UnsafeRooms {
+		rc := r.Coords
+		if rc.X() >= minX && rc.X() <= maxX && rc.Y() >= minY && rc.Y() <= maxY && rc.Z() >= minZ && rc.Z() <= maxZ {
+			rooms = append(rooms, r)
+		}
+	}
+
+	return rooms
+}
+
+// RoomsWithAttribute returns the Rooms that have an attribute matching the value (case-insensitive).
+func (a *Area) RoomsWithAttribute(attr string, val string) []*Room {
+	a.RLock()
+	defer a.RUnlock()
+
+	var rooms []*Room
+	for _, r := range a.UnsafeRooms {
+		if strings.ToLower(r.Attribute(attr)) == strings.ToLower(val) {
+			rooms = append(rooms, r)
+		}
+	}
+
+	return rooms
+}
+
 // MinimapJSON returns the JSON used for minimap rendering on the client.
 func (a *Area) MinimapJSON() string {
 	a.RLock()
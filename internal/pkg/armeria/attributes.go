@@ -7,10 +7,13 @@ import (
 )
 
 const (
+	AttributeCapacity    string = "capacity"
 	AttributeChannels    string = "channels"
 	AttributeColor       string = "color"
+	AttributeCondition   string = "condition"
 	AttributeDescription string = "description"
 	AttributeDown        string = "down"
+	AttributeDurability  string = "durability"
 	AttributeEast        string = "east"
 	AttributeEquipSlot   string = "equipSlot"
 	AttributeFollowCrumb string = "followCrumb"
@@ -29,15 +32,18 @@ const (
 	AttributeSpawnMob    string = "spawnMob"
 	AttributeSpawnSFX    string = "spawnSFX"
 	AttributeSouth       string = "south"
+	AttributeStackSize   string = "stackSize"
 	AttributeTitle       string = "title"
 	AttributeType        string = "type"
 	AttributeUp          string = "up"
 	AttributeVisible     string = "visible"
 	AttributeWest        string = "west"
+	AttributeWorkstation string = "workstation"
 
 	TempAttributeEditorOpen string = "editorOpen"
 	TempAttributeGhost      string = "ghost"
 	TempAttributeReplyTo    string = "replyTo"
+	TempAttributeFollowing  string = "following"
 )
 
 // AttributeCasing returns the correct casing for a given object type and attribute.
@@ -102,6 +108,10 @@ func AttributeList(ot ObjectType) []string {
 			AttributeSpawnMob,
 			AttributeSpawnLimit,
 			AttributeMoney,
+			AttributeWorkstation,
+			AttributeStackSize,
+			AttributeCapacity,
+			AttributeDurability,
 		}
 	case ObjectTypeItemInstance:
 		return []string{
@@ -110,6 +120,7 @@ func AttributeList(ot ObjectType) []string {
 			AttributeHoldable,
 			AttributeVisible,
 			AttributeSpawnLimit,
+			AttributeCondition,
 		}
 	case ObjectTypeMob:
 		return []string{
@@ -144,7 +155,7 @@ func AttributeEditorType(ot ObjectType, attr string) string {
 	case AttributeGender:
 		switch ot {
 		case ObjectTypeCharacter:
-			return "enum:male|female"
+			return "enum:male|female|nonbinary"
 		case ObjectTypeMob:
 			return "enum:male|female|thing"
 		}
@@ -155,7 +166,7 @@ func AttributeEditorType(ot ObjectType, attr string) string {
 		case ObjectTypeItem:
 			return "enum:" + strings.Join(ItemTypes(), "|")
 		case ObjectTypeRoom:
-			return "enum:generic|track|bank|armor|sword|home|wand"
+			return "enum:generic|track|bank|armor|sword|home|wand|jail"
 		default:
 			return "editable"
 		}
@@ -179,6 +190,14 @@ func AttributeGroup(attr string) string {
 		return "Mob Spawning"
 	case AttributeMoney:
 		return "Bank Cards"
+	case AttributeWorkstation:
+		return "Crafting"
+	case AttributeStackSize:
+		return "Stacking"
+	case AttributeCapacity:
+		return "Containers"
+	case AttributeDurability, AttributeCondition:
+		return "Durability"
 	}
 
 	return "General"
@@ -218,6 +237,12 @@ func AttributeDefault(ot ObjectType, attr string) string {
 		return "true"
 	case AttributeSpawnLimit:
 		return "0"
+	case AttributeStackSize:
+		return "1"
+	case AttributeCapacity:
+		return "10"
+	case AttributeDurability:
+		return "0"
 	case AttributeFollowSpeed:
 		return "12"
 	}
@@ -247,7 +272,7 @@ func AttributeValidate(ot ObjectType, attr, val string) validate.ValidationResul
 	case ObjectTypeCharacter:
 		switch attr {
 		case AttributeGender:
-			validatorString = "in:male,female"
+			validatorString = "in:male,female,nonbinary"
 			break
 		case AttributeMoney:
 			validatorString = "num|min:0"
@@ -270,14 +295,29 @@ func AttributeValidate(ot ObjectType, attr, val string) validate.ValidationResul
 		case AttributeSpawnLimit:
 			validatorString = "num|min:0|max:100"
 			break
+		case AttributeStackSize:
+			validatorString = "num|min:1|max:1000"
+			break
+		case AttributeCapacity:
+			validatorString = "num|min:1|max:100"
+			break
+		case AttributeDurability, AttributeCondition:
+			validatorString = "num|min:0|max:10000"
+			break
 		case AttributeEquipSlot:
 			validatorString = "in:" + strings.Join(ValidEquipmentSlotsAsString(), ",")
 			break
 		}
+	case ObjectTypeItemInstance:
+		switch attr {
+		case AttributeCondition:
+			validatorString = "num|min:0|max:10000"
+			break
+		}
 	case ObjectTypeRoom:
 		switch attr {
 		case AttributeType:
-			validatorString = "in:generic,track,bank,armor,sword,home,wand"
+			validatorString = "in:generic,track,bank,armor,sword,home,wand,jail"
 			break
 		}
 	}
@@ -0,0 +1,188 @@
+package armeria
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// MinAuctionDuration is the shortest amount of time an item can be listed for.
+	MinAuctionDuration = 1 * time.Hour
+	// MaxAuctionDuration is the longest amount of time an item can be listed for.
+	MaxAuctionDuration = 7 * 24 * time.Hour
+	// DefaultAuctionDuration is how long an item is listed for when a duration isn't specified.
+	DefaultAuctionDuration = 24 * time.Hour
+	// MinBidIncrease is how much higher, as a fraction of the current bid, the next bid must be.
+	MinBidIncrease float64 = 0.05
+	// AuctionHouseName is the name used when the auction house sends mail.
+	AuctionHouseName string = "Auction House"
+)
+
+// Auction is an item listed for sale at the auction house. The item, and the money from the highest bid, are held
+// in escrow until the auction ends.
+type Auction struct {
+	sync.RWMutex
+	ID                  int              `json:"id"`
+	UnsafeSellerUUID    string           `json:"sellerUuid"`
+	UnsafeEscrow        *ObjectContainer `json:"escrow"`
+	UnsafeStartingPrice Money            `json:"startingPrice"`
+	UnsafeBuyoutPrice   Money            `json:"buyoutPrice"`
+	UnsafeBid           Money            `json:"bid"`
+	UnsafeBidderUUID    string           `json:"bidderUuid"`
+	UnsafeExpires       time.Time        `json:"expires"`
+}
+
+// Init is called when the Auction is created or loaded from disk.
+func (a *Auction) Init() {
+	if a.UnsafeEscrow == nil {
+		a.UnsafeEscrow = NewObjectContainer(1)
+	}
+	a.UnsafeEscrow.AttachParent(a, ContainerParentTypeEscrow)
+	a.UnsafeEscrow.Sync()
+}
+
+// Escrow returns the container holding the item up for auction.
+func (a *Auction) Escrow() *ObjectContainer {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafeEscrow
+}
+
+// Item returns the item instance up for auction.
+func (a *Auction) Item() *ItemInstance {
+	items := a.Escrow().Items()
+	if len(items) == 0 {
+		return nil
+	}
+
+	return items[0]
+}
+
+// ItemName returns the formatted name of the item up for auction.
+func (a *Auction) ItemName() string {
+	if ii := a.Item(); ii != nil {
+		return ii.FormattedName()
+	}
+
+	return "nothing"
+}
+
+// Seller returns the Character that listed the item.
+func (a *Auction) Seller() *Character {
+	a.RLock()
+	defer a.RUnlock()
+
+	return Armeria.characterManager.CharacterById(a.UnsafeSellerUUID)
+}
+
+// Bidder returns the Character with the highest bid, or nil if there are no bids.
+func (a *Auction) Bidder() *Character {
+	a.RLock()
+	defer a.RUnlock()
+
+	if len(a.UnsafeBidderUUID) == 0 {
+		return nil
+	}
+
+	return Armeria.characterManager.CharacterById(a.UnsafeBidderUUID)
+}
+
+// SetBidder sets the Character with the highest bid.
+func (a *Auction) SetBidder(c *Character) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.UnsafeBidderUUID = c.ID()
+}
+
+// StartingPrice returns the lowest amount that can be bid on the item.
+func (a *Auction) StartingPrice() Money {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafeStartingPrice
+}
+
+// BuyoutPrice returns the price the item can be bought for immediately, or 0 if it cannot be bought out.
+func (a *Auction) BuyoutPrice() Money {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafeBuyoutPrice
+}
+
+// Bid returns the highest bid, or 0 if there are no bids.
+func (a *Auction) Bid() Money {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafeBid
+}
+
+// MinimumBid returns the lowest amount the next bid can be.
+func (a *Auction) MinimumBid() Money {
+	bid := a.Bid()
+	if bid == 0 {
+		return a.StartingPrice()
+	}
+
+	increase := Money(float64(bid) * MinBidIncrease)
+	if increase < 1 {
+		increase = 1
+	}
+
+	return bid + increase
+}
+
+// Expires returns the time the auction ends.
+func (a *Auction) Expires() time.Time {
+	a.RLock()
+	defer a.RUnlock()
+
+	return a.UnsafeExpires
+}
+
+// Expired returns true if the auction has ended.
+func (a *Auction) Expired() bool {
+	return !time.Now().Before(a.Expires())
+}
+
+// TimeLeft returns a short description of how long is left before the auction ends (ie: 2d 3h).
+func (a *Auction) TimeLeft() string {
+	left := time.Until(a.Expires())
+	if left <= 0 {
+		return "ended"
+	}
+
+	days := int(left.Hours()) / 24
+	hours := int(left.Hours()) % 24
+	minutes := int(left.Minutes()) % 60
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	} else if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+
+	return fmt.Sprintf("%dm", minutes+1)
+}
+
+// Money returns the amount of money held in escrow for the highest bid.
+func (a *Auction) Money() Money {
+	return a.Bid()
+}
+
+// setMoney sets the amount of money held in escrow. This must only be called by the EconomyManager.
+func (a *Auction) setMoney(m Money) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.UnsafeBid = m
+}
+
+// MoneyHolderName returns the name of the auction as it appears in transaction histories.
+func (a *Auction) MoneyHolderName() string {
+	return fmt.Sprintf("auction #%d", a.ID)
+}
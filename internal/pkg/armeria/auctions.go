@@ -0,0 +1,387 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type AuctionManager struct {
+	sync.RWMutex
+	dataFile       string
+	UnsafeNextID   int        `json:"nextId"`
+	UnsafeAuctions []*Auction `json:"auctions"`
+}
+
+var (
+	// ErrAuctionEnded is an error for when an auction is no longer running.
+	ErrAuctionEnded = errors.New("auction has ended")
+	// ErrAuctionOwnItem is an error for when a character bids on their own auction.
+	ErrAuctionOwnItem = errors.New("cannot bid on your own auction")
+	// ErrAuctionBidTooLow is an error for when a bid is lower than the minimum bid.
+	ErrAuctionBidTooLow = errors.New("bid is too low")
+	// ErrAuctionNoBuyout is an error for when an auction without a buyout price is bought out.
+	ErrAuctionNoBuyout = errors.New("auction has no buyout price")
+	// ErrAuctionHasBids is an error for when an auction that has bids is cancelled.
+	ErrAuctionHasBids = errors.New("auction already has bids")
+	// ErrAuctionNotSeller is an error for when someone other than the seller cancels an auction.
+	ErrAuctionNotSeller = errors.New("not the seller of the auction")
+	// ErrAuctionInvalidPrice is an error for when an auction is listed with an invalid starting or buyout price.
+	ErrAuctionInvalidPrice = errors.New("invalid starting or buyout price")
+	// ErrAuctionInvalidDuration is an error for when an auction is listed for too short or too long.
+	ErrAuctionInvalidDuration = errors.New("invalid auction duration")
+)
+
+// NewAuctionManager creates a new AuctionManager.
+func NewAuctionManager() *AuctionManager {
+	m := &AuctionManager{
+		dataFile: fmt.Sprintf("%s/auctions.json", Armeria.dataPath),
+	}
+
+	m.LoadAuctions()
+
+	return m
+}
+
+// LoadAuctions loads the auctions from disk into memory.
+func (m *AuctionManager) LoadAuctions() {
+	m.Lock()
+	defer m.Unlock()
+
+	auctionsFile, err := os.Open(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer auctionsFile.Close()
+
+	jsonParser := json.NewDecoder(auctionsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	for _, a := range m.UnsafeAuctions {
+		a.Init()
+	}
+
+	Armeria.log.Info("auctions loaded",
+		zap.Int("count", len(m.UnsafeAuctions)),
+	)
+}
+
+// SaveAuctions writes the in-memory auctions to disk.
+func (m *AuctionManager) SaveAuctions() {
+	m.RLock()
+	defer m.RUnlock()
+
+	auctionsFile, err := os.Create(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to create data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer auctionsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := auctionsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = auctionsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Auctions returns all of the running Auctions, oldest first.
+func (m *AuctionManager) Auctions() []*Auction {
+	m.RLock()
+	defer m.RUnlock()
+
+	return append([]*Auction{}, m.UnsafeAuctions...)
+}
+
+// AuctionByID returns the running Auction with a specific id.
+func (m *AuctionManager) AuctionByID(id int) *Auction {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, a := range m.UnsafeAuctions {
+		if a.ID == id {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// Escrow returns the total money held in escrow for the highest bids.
+func (m *AuctionManager) Escrow() Money {
+	var total Money
+	for _, a := range m.Auctions() {
+		total += a.Money()
+	}
+
+	return total
+}
+
+// List moves an item instance from a Character's inventory into escrow and lists it for auction.
+func (m *AuctionManager) List(seller *Character, ii *ItemInstance, start Money, buyout Money, d time.Duration) (*Auction, error) {
+	if start <= 0 || buyout < 0 || (buyout > 0 && buyout < start) {
+		return nil, ErrAuctionInvalidPrice
+	} else if d < MinAuctionDuration || d > MaxAuctionDuration {
+		return nil, ErrAuctionInvalidDuration
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeNextID++
+	a := &Auction{
+		ID:                  m.UnsafeNextID,
+		UnsafeSellerUUID:    seller.ID(),
+		UnsafeStartingPrice: start,
+		UnsafeBuyoutPrice:   buyout,
+		UnsafeExpires:       time.Now().Add(d),
+	}
+	a.Init()
+
+	seller.Inventory().Remove(ii.ID())
+	if err := a.Escrow().Add(ii.ID()); err != nil {
+		_ = seller.Inventory().Add(ii.ID())
+		return nil, err
+	}
+
+	m.UnsafeAuctions = append(m.UnsafeAuctions, a)
+
+	return a, nil
+}
+
+// Bid places a bid on an Auction, moving the money into escrow and returning the previous highest bid to its
+// bidder. A bid at or above the buyout price buys the item out.
+func (m *AuctionManager) Bid(a *Auction, c *Character, amount Money) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if !m.running(a) {
+		return ErrAuctionEnded
+	} else if a.Seller() == c {
+		return ErrAuctionOwnItem
+	}
+
+	if buyout := a.BuyoutPrice(); buyout > 0 && amount >= buyout {
+		return m.buyout(a, c)
+	}
+
+	if amount < a.MinimumBid() {
+		return ErrAuctionBidTooLow
+	}
+
+	return m.placeBid(a, c, amount)
+}
+
+// Buyout buys an Auction's item immediately at the buyout price.
+func (m *AuctionManager) Buyout(a *Auction, c *Character) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if !m.running(a) {
+		return ErrAuctionEnded
+	} else if a.Seller() == c {
+		return ErrAuctionOwnItem
+	}
+
+	return m.buyout(a, c)
+}
+
+// Cancel ends an Auction that has no bids and returns the item to the seller.
+func (m *AuctionManager) Cancel(a *Auction, c *Character) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if !m.running(a) {
+		return ErrAuctionEnded
+	} else if a.Seller() != c {
+		return ErrAuctionNotSeller
+	} else if a.Bidder() != nil {
+		return ErrAuctionHasBids
+	}
+
+	if ii := a.Item(); ii != nil {
+		DeliverItem(c, ii, a.Escrow(), AuctionHouseName, fmt.Sprintf("Auction #%d was cancelled", a.ID))
+	}
+
+	m.remove(a)
+
+	return nil
+}
+
+// ExpireAuctions settles every Auction that has ended.
+func (m *AuctionManager) ExpireAuctions() {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, a := range append([]*Auction{}, m.UnsafeAuctions...) {
+		if a.Expired() {
+			m.settle(a)
+		}
+	}
+}
+
+// running returns true if the Auction is listed and has not ended. The caller must hold the lock.
+func (m *AuctionManager) running(a *Auction) bool {
+	for _, ra := range m.UnsafeAuctions {
+		if ra == a {
+			return !a.Expired()
+		}
+	}
+
+	return false
+}
+
+// remove removes an Auction from the auction house. The caller must hold the lock.
+func (m *AuctionManager) remove(a *Auction) {
+	for i, ra := range m.UnsafeAuctions {
+		if ra == a {
+			m.UnsafeAuctions = append(m.UnsafeAuctions[:i], m.UnsafeAuctions[i+1:]...)
+			return
+		}
+	}
+}
+
+// placeBid moves a bid into escrow and refunds the previous bid. The caller must hold the lock.
+func (m *AuctionManager) placeBid(a *Auction, c *Character, amount Money) error {
+	prevBidder := a.Bidder()
+	prevBid := a.Bid()
+
+	tx := NewMoneyTransaction().Move(c, a, amount, MoneyReasonAuctionBid)
+	if prevBidder != nil {
+		tx.Move(a, prevBidder, prevBid, MoneyReasonAuctionRefund)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	a.SetBidder(c)
+
+	if prevBidder != nil && prevBidder != c && prevBidder.Online() {
+		prevBidder.Player().client.ShowText(
+			fmt.Sprintf(
+				"You have been outbid on auction #%d (%s). Your bid of %s has been returned to you.",
+				a.ID,
+				a.ItemName(),
+				prevBidder.Colorize(prevBid.String(), ColorMoney),
+			),
+		)
+		prevBidder.Player().client.SyncMoney()
+	}
+
+	return nil
+}
+
+// buyout places a bid for the buyout price and settles the Auction. The caller must hold the lock.
+func (m *AuctionManager) buyout(a *Auction, c *Character) error {
+	buyout := a.BuyoutPrice()
+	if buyout == 0 {
+		return ErrAuctionNoBuyout
+	}
+
+	if err := m.placeBid(a, c, buyout); err != nil {
+		return err
+	}
+
+	m.settle(a)
+
+	return nil
+}
+
+// settle ends an Auction. The item is delivered to the highest bidder and the money in escrow is paid to the
+// seller or, if there were no bids, the item is returned to the seller. The caller must hold the lock.
+func (m *AuctionManager) settle(a *Auction) {
+	ii := a.Item()
+	name := a.ItemName()
+	seller := a.Seller()
+	bidder := a.Bidder()
+	bid := a.Bid()
+
+	if bidder != nil {
+		if ii != nil {
+			DeliverItem(bidder, ii, a.Escrow(), AuctionHouseName, fmt.Sprintf("You won auction #%d", a.ID))
+		}
+
+		var payee MoneyHolder = MoneyWorld
+		if seller != nil {
+			payee = seller
+		}
+		if err := TransferMoney(a, payee, bid, MoneyReasonAuctionSale); err != nil {
+			Armeria.log.Error("failed to pay out auction",
+				zap.Int("auction", a.ID),
+				zap.Error(err),
+			)
+		}
+
+		if bidder.Online() {
+			bidder.Player().client.ShowColorizedText(
+				fmt.Sprintf("You won auction #%d and bought %s for %s.", a.ID, name, bid.String()),
+				ColorSuccess,
+			)
+		}
+		if seller != nil && seller.Online() {
+			seller.Player().client.ShowColorizedText(
+				fmt.Sprintf("Your auction #%d of %s sold to %s for %s.", a.ID, name, bidder.FormattedName(), bid.String()),
+				ColorSuccess,
+			)
+			seller.Player().client.SyncMoney()
+		}
+	} else if ii != nil {
+		if seller != nil {
+			DeliverItem(seller, ii, a.Escrow(), AuctionHouseName, fmt.Sprintf("Auction #%d ended without any bids", a.ID))
+			if seller.Online() {
+				seller.Player().client.ShowText(
+					fmt.Sprintf("Your auction #%d of %s ended without any bids, and the item was returned to you.", a.ID, name),
+				)
+			}
+		} else {
+			a.Escrow().Remove(ii.ID())
+			ii.Delete()
+		}
+	}
+
+	m.remove(a)
+
+	Armeria.log.Info("auction ended",
+		zap.Int("auction", a.ID),
+		zap.Int64("bid", int64(bid)),
+	)
+}
+
+// AuctionExpiry settles the auctions that have ended.
+func AuctionExpiry() {
+	Armeria.auctionManager.ExpireAuctions()
+}
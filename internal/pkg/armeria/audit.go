@@ -0,0 +1,241 @@
+package armeria
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Audit entry types.
+const (
+	AuditTypeCommand string = "command"
+	AuditTypeMoney   string = "money"
+)
+
+const (
+	// MaxAuditLogSize is the size, in bytes, at which the audit log is rotated.
+	MaxAuditLogSize int64 = 5 * 1024 * 1024
+	// MaxAuditLogFiles is the number of rotated audit logs that are kept on disk.
+	MaxAuditLogFiles int = 10
+)
+
+// AuditEntry is a single privileged action recorded within the audit log.
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"`
+	Character string    `json:"character"`
+	Command   string    `json:"command"`
+	Arguments []string  `json:"arguments,omitempty"`
+	Details   string    `json:"details,omitempty"`
+}
+
+// AuditLog is an append-only record of privileged commands and money changes that is persisted to disk. Each
+// entry is written as a single line of JSON, and the log is rotated once it reaches MaxAuditLogSize.
+type AuditLog struct {
+	sync.Mutex
+	dir  string
+	file *os.File
+	size int64
+}
+
+// NewAuditLog opens the audit log for writing, creating it if it does not exist.
+func NewAuditLog() *AuditLog {
+	l := &AuditLog{
+		dir: fmt.Sprintf("%s/audit", Armeria.dataPath),
+	}
+
+	if err := os.MkdirAll(l.dir, 0755); err != nil {
+		Armeria.log.Fatal("error creating audit log directory",
+			zap.String("dir", l.dir),
+			zap.Error(err),
+		)
+	}
+
+	l.open()
+
+	return l
+}
+
+// currentFile returns the path of the audit log that is currently being written to.
+func (l *AuditLog) currentFile() string {
+	return fmt.Sprintf("%s/audit.log", l.dir)
+}
+
+// open opens the current audit log file for appending.
+func (l *AuditLog) open() {
+	f, err := os.OpenFile(l.currentFile(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		Armeria.log.Fatal("error opening audit log",
+			zap.String("file", l.currentFile()),
+			zap.Error(err),
+		)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		Armeria.log.Fatal("error reading audit log",
+			zap.String("file", l.currentFile()),
+			zap.Error(err),
+		)
+	}
+
+	l.file = f
+	l.size = info.Size()
+}
+
+// rotatedFiles returns the rotated audit logs, oldest first.
+func (l *AuditLog) rotatedFiles() []string {
+	files, _ := filepath.Glob(fmt.Sprintf("%s/audit-*.log", l.dir))
+	sort.Strings(files)
+	return files
+}
+
+// rotate moves the current audit log aside, starts a new one and removes the oldest rotated logs.
+func (l *AuditLog) rotate() {
+	_ = l.file.Close()
+
+	rotated := fmt.Sprintf("%s/audit-%s.log", l.dir, time.Now().Format("20060102-150405.000000"))
+	if err := os.Rename(l.currentFile(), rotated); err != nil {
+		Armeria.log.Error("error rotating audit log",
+			zap.String("file", l.currentFile()),
+			zap.Error(err),
+		)
+	}
+
+	files := l.rotatedFiles()
+	for len(files) > MaxAuditLogFiles {
+		_ = os.Remove(files[0])
+		files = files[1:]
+	}
+
+	l.open()
+}
+
+// Record appends an entry to the audit log.
+func (l *AuditLog) Record(e *AuditEntry) {
+	if l == nil {
+		return
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		Armeria.log.Error("error marshalling audit entry", zap.Error(err))
+		return
+	}
+	b = append(b, '\n')
+
+	if l.size+int64(len(b)) > MaxAuditLogSize {
+		l.rotate()
+	}
+
+	n, err := l.file.Write(b)
+	l.size += int64(n)
+	if err != nil {
+		Armeria.log.Error("error writing to audit log", zap.Error(err))
+		return
+	}
+
+	_ = l.file.Sync()
+}
+
+// RecordCommand records a command that was executed by a player.
+func (l *AuditLog) RecordCommand(ctx *CommandContext) {
+	c := "Anonymous"
+	if ctx.Character != nil {
+		c = ctx.Character.Name()
+	}
+
+	l.Record(&AuditEntry{
+		Type:      AuditTypeCommand,
+		Character: c,
+		Command:   ctx.Command.FullName(),
+		Arguments: ctx.Command.LoggableArgs(ctx),
+	})
+}
+
+// RecordMoney records a movement of money between two holders.
+func (l *AuditLog) RecordMoney(m *MoneyMovement) {
+	character := m.From.MoneyHolderName()
+	if c, ok := m.To.(*Character); ok {
+		character = c.Name()
+	}
+	if c, ok := m.From.(*Character); ok {
+		character = c.Name()
+	}
+
+	l.Record(&AuditEntry{
+		Type:      AuditTypeMoney,
+		Character: character,
+		Command:   AuditTypeMoney + ":" + m.Reason,
+		Details: fmt.Sprintf(
+			"%s from %s to %s",
+			m.Amount.Decimal(),
+			m.From.MoneyHolderName(),
+			m.To.MoneyHolderName(),
+		),
+	})
+}
+
+// Query returns the most recent audit entries, newest first, matching the filters. An empty character or command
+// matches everything.
+func (l *AuditLog) Query(character, command string, since time.Time, limit int) []*AuditEntry {
+	// The files are scanned without holding the lock, so that a long query doesn't hold up entries being recorded.
+	l.Lock()
+	files := append(l.rotatedFiles(), l.currentFile())
+	l.Unlock()
+
+	var matches []*AuditEntry
+
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			e := &AuditEntry{}
+			if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+				continue
+			}
+
+			if len(character) > 0 && strings.ToLower(e.Character) != strings.ToLower(character) {
+				continue
+			} else if len(command) > 0 && !strings.HasPrefix(strings.ToLower(e.Command), strings.ToLower(command)) {
+				continue
+			} else if e.Time.Before(since) {
+				continue
+			}
+
+			matches = append(matches, e)
+		}
+
+		_ = f.Close()
+	}
+
+	if len(matches) > limit {
+		matches = matches[len(matches)-limit:]
+	}
+
+	for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+		matches[i], matches[j] = matches[j], matches[i]
+	}
+
+	return matches
+}
@@ -0,0 +1,117 @@
+package armeria
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MaxBankTransactions is how many transactions are kept in the history of each bank account.
+const MaxBankTransactions int = 100
+
+// BankTransaction is a single movement of money into or out of a BankAccount.
+type BankTransaction struct {
+	Time         time.Time `json:"time"`
+	Type         string    `json:"type"`
+	Amount       Money     `json:"amount"`
+	Balance      Money     `json:"balance"`
+	Counterparty string    `json:"counterparty"`
+}
+
+// BankAccount is an account held at the bank. Each account is tied to a bank card item instance, and the account
+// can be accessed by whichever character has the card equipped.
+type BankAccount struct {
+	sync.RWMutex
+	UUID               string             `json:"uuid"`
+	UnsafeCardUUID     string             `json:"cardUuid"`
+	UnsafeOwnerUUID    string             `json:"ownerUuid"`
+	UnsafeBalance      Money              `json:"balance"`
+	UnsafeTransactions []*BankTransaction `json:"transactions"`
+}
+
+// ID returns the uuid (account number) of the BankAccount.
+func (ba *BankAccount) ID() string {
+	return ba.UUID
+}
+
+// CardUUID returns the uuid of the bank card item instance tied to the account.
+func (ba *BankAccount) CardUUID() string {
+	ba.RLock()
+	defer ba.RUnlock()
+
+	return ba.UnsafeCardUUID
+}
+
+// Owner returns the Character that opened the account.
+func (ba *BankAccount) Owner() *Character {
+	ba.RLock()
+	defer ba.RUnlock()
+
+	return Armeria.characterManager.CharacterById(ba.UnsafeOwnerUUID)
+}
+
+// Money returns the amount of money in the account.
+func (ba *BankAccount) Money() Money {
+	ba.RLock()
+	defer ba.RUnlock()
+
+	return ba.UnsafeBalance
+}
+
+// setMoney sets the amount of money in the account. This must only be called by the EconomyManager.
+func (ba *BankAccount) setMoney(m Money) {
+	ba.Lock()
+	defer ba.Unlock()
+
+	ba.UnsafeBalance = m
+}
+
+// MoneyHolderName returns the name of the account as it appears in transaction histories.
+func (ba *BankAccount) MoneyHolderName() string {
+	name := "unknown"
+	if o := ba.Owner(); o != nil {
+		name = o.Name()
+	}
+
+	return fmt.Sprintf("%s's bank account", name)
+}
+
+// Transactions returns the transaction history of the account, oldest first.
+func (ba *BankAccount) Transactions() []*BankTransaction {
+	ba.RLock()
+	defer ba.RUnlock()
+
+	return ba.UnsafeTransactions
+}
+
+// recordMoney appends a money movement to the account's transaction history.
+func (ba *BankAccount) recordMoney(m *MoneyMovement, balance Money) {
+	tx := &BankTransaction{
+		Time:    time.Now(),
+		Type:    m.Reason,
+		Amount:  m.Amount,
+		Balance: balance,
+	}
+
+	if m.From == MoneyHolder(ba) {
+		tx.Amount = -m.Amount
+		tx.Counterparty = m.To.MoneyHolderName()
+	} else {
+		tx.Counterparty = m.From.MoneyHolderName()
+	}
+
+	ba.Lock()
+	defer ba.Unlock()
+
+	ba.UnsafeTransactions = appendTransaction(ba.UnsafeTransactions, tx)
+}
+
+// appendTransaction adds a transaction to a history, dropping the oldest transactions past MaxBankTransactions.
+func appendTransaction(history []*BankTransaction, tx *BankTransaction) []*BankTransaction {
+	history = append(history, tx)
+	if len(history) > MaxBankTransactions {
+		history = history[len(history)-MaxBankTransactions:]
+	}
+
+	return history
+}
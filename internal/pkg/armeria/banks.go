@@ -0,0 +1,201 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"go.uber.org/zap"
+)
+
+// BankManager keeps track of the bank accounts, which are tied to bank cards.
+type BankManager struct {
+	sync.RWMutex
+	dataFile       string
+	UnsafeAccounts []*BankAccount `json:"accounts"`
+}
+
+var (
+	// ErrInsufficientFunds is an error for when a bank account does not have enough money.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrSameAccount is an error for when money is transferred to the account it came from.
+	ErrSameAccount = errors.New("cannot transfer money to the same account")
+)
+
+// NewBankManager creates a new BankManager.
+func NewBankManager() *BankManager {
+	m := &BankManager{
+		dataFile: fmt.Sprintf("%s/bank-accounts.json", Armeria.dataPath),
+	}
+
+	m.LoadAccounts()
+
+	return m
+}
+
+// LoadAccounts loads the bank accounts from disk into memory.
+func (m *BankManager) LoadAccounts() {
+	m.Lock()
+	defer m.Unlock()
+
+	accountsFile, err := os.Open(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer accountsFile.Close()
+
+	jsonParser := json.NewDecoder(accountsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("bank accounts loaded",
+		zap.Int("count", len(m.UnsafeAccounts)),
+	)
+}
+
+// SaveAccounts writes the in-memory bank accounts to disk.
+func (m *BankManager) SaveAccounts() {
+	m.RLock()
+	defer m.RUnlock()
+
+	accountsFile, err := os.Create(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to create data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer accountsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := accountsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = accountsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Accounts returns all of the in-memory BankAccounts.
+func (m *BankManager) Accounts() []*BankAccount {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeAccounts
+}
+
+// AccountByCard returns the BankAccount tied to a bank card item instance.
+func (m *BankManager) AccountByCard(cardUUID string) *BankAccount {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.accountByCard(cardUUID)
+}
+
+// accountByCard returns the BankAccount tied to a bank card item instance. The caller must hold the lock.
+func (m *BankManager) accountByCard(cardUUID string) *BankAccount {
+	for _, ba := range m.UnsafeAccounts {
+		if ba.CardUUID() == cardUUID {
+			return ba
+		}
+	}
+
+	return nil
+}
+
+// AccountsByOwner returns the BankAccounts opened by a Character.
+func (m *BankManager) AccountsByOwner(c *Character) []*BankAccount {
+	m.RLock()
+	defer m.RUnlock()
+
+	var accounts []*BankAccount
+	for _, ba := range m.UnsafeAccounts {
+		ba.RLock()
+		owner := ba.UnsafeOwnerUUID
+		ba.RUnlock()
+		if owner == c.ID() {
+			accounts = append(accounts, ba)
+		}
+	}
+
+	return accounts
+}
+
+// AccountForCharacter returns the BankAccount that money sent to a Character is deposited into. This is the
+// account tied to their equipped bank card, otherwise the first account they opened.
+func (m *BankManager) AccountForCharacter(c *Character) *BankAccount {
+	if card := c.BankCard(); card != nil {
+		if ba := m.AccountByCard(card.ID()); ba != nil {
+			return ba
+		}
+	}
+
+	if accounts := m.AccountsByOwner(c); len(accounts) > 0 {
+		return accounts[0]
+	}
+
+	return nil
+}
+
+// AccountForCard returns the BankAccount tied to a bank card item instance, opening a new account owned by the
+// Character if the card does not have one yet.
+func (m *BankManager) AccountForCard(card *ItemInstance, c *Character) *BankAccount {
+	m.Lock()
+	defer m.Unlock()
+
+	if ba := m.accountByCard(card.ID()); ba != nil {
+		return ba
+	}
+
+	ba := &BankAccount{
+		UUID:               uuid.New().String(),
+		UnsafeCardUUID:     card.ID(),
+		UnsafeOwnerUUID:    c.ID(),
+		UnsafeTransactions: make([]*BankTransaction, 0),
+	}
+
+	m.UnsafeAccounts = append(m.UnsafeAccounts, ba)
+
+	Armeria.log.Info("bank account opened",
+		zap.String("account", ba.UUID),
+		zap.String("character", c.Name()),
+	)
+
+	return ba
+}
+
+// Transfer moves money between two accounts.
+func (m *BankManager) Transfer(from *BankAccount, to *BankAccount, amount Money) error {
+	if from == to {
+		return ErrSameAccount
+	}
+
+	return TransferMoney(from, to, amount, MoneyReasonBankTransfer)
+}
@@ -1,17 +1,36 @@
 package armeria
 
 import (
+	"armeria/internal/pkg/misc"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 )
 
-// Channel describes a particular talking channel.
+// Channel describes a particular talking channel. The built-in channels are defined in NewChannels, and players
+// can create their own, which have an owner and are saved to disk.
 type Channel struct {
-	Name              string
-	Description       string
-	SlashCommand      string
-	Color             int
-	RequirePermission string
+	sync.RWMutex
+	Name              string    `json:"name"`
+	Description       string    `json:"-"`
+	SlashCommand      string    `json:"-"`
+	Color             int       `json:"-"`
+	RequirePermission string    `json:"-"`
+	UnsafeOwner       string    `json:"owner"`
+	UnsafeModerators  []string  `json:"moderators"`
+	UnsafeBanned      []string  `json:"banned"`
+	UnsafeInvited     []string  `json:"invited"`
+	UnsafeInviteOnly  bool      `json:"inviteOnly"`
+	UnsafePassword    string    `json:"password"`
+	UnsafeTopic       string    `json:"topic"`
+	Created           time.Time `json:"created"`
+	guild             *Guild
+	party             *Party
 }
 
 // Channels constants.
@@ -47,7 +66,16 @@ func NewChannels() map[string]*Channel {
 	}
 }
 
-// ChannelByName returns the matching Channel.
+var (
+	// ErrChannelBanned is an error for when a character has been banned from a channel.
+	ErrChannelBanned = errors.New("you have been banned from that channel")
+	// ErrChannelInviteOnly is an error for when a character hasn't been invited to an invite-only channel.
+	ErrChannelInviteOnly = errors.New("that channel is invite-only")
+	// ErrChannelPassword is an error for when a character doesn't provide the right password for a channel.
+	ErrChannelPassword = errors.New("that channel requires the correct password")
+)
+
+// ChannelByName returns the matching Channel, built-in or created by a player.
 func ChannelByName(name string) *Channel {
 	for _, c := range Armeria.channels {
 		if strings.ToLower(c.Name) == strings.ToLower(name) {
@@ -55,7 +83,17 @@ func ChannelByName(name string) *Channel {
 		}
 	}
 
-	return nil
+	return Armeria.channelManager.ChannelByName(name)
+}
+
+// AllChannels returns the built-in channels, followed by the channels created by players.
+func AllChannels() []*Channel {
+	var channels []*Channel
+	for _, key := range []string{ChannelGeneral, ChannelCore, ChannelBuilders} {
+		channels = append(channels, Armeria.channels[key])
+	}
+
+	return append(channels, Armeria.channelManager.Channels()...)
 }
 
 // HasPermission returns a bool indicating whether the unsafeCharacter can participate in the channel.
@@ -63,7 +101,227 @@ func (c *Channel) HasPermission(char *Character) bool {
 	if len(c.RequirePermission) > 0 {
 		return char.HasPermission(c.RequirePermission)
 	}
-	return true
+	return !c.Banned(char)
+}
+
+// PlayerCreated returns true if the Channel was created by a player, rather than being built-in.
+func (c *Channel) PlayerCreated() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return len(c.UnsafeOwner) > 0
+}
+
+// Owner returns the Character that created the Channel, or nil for a built-in channel.
+func (c *Channel) Owner() *Character {
+	c.RLock()
+	defer c.RUnlock()
+
+	return Armeria.characterManager.CharacterById(c.UnsafeOwner)
+}
+
+// IsOwner returns true if the Character created the Channel.
+func (c *Channel) IsOwner(char *Character) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeOwner == char.ID()
+}
+
+// IsModerator returns true if the Character can manage the Channel. The owner is always a moderator.
+func (c *Channel) IsModerator(char *Character) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeOwner == char.ID() || misc.Contains(c.UnsafeModerators, char.ID())
+}
+
+// Moderators returns the Characters, other than the owner, who can manage the Channel.
+func (c *Channel) Moderators() []*Character {
+	c.RLock()
+	defer c.RUnlock()
+
+	return charactersByID(c.UnsafeModerators)
+}
+
+// SetModerator adds or removes a Character from the Channel's moderators.
+func (c *Channel) SetModerator(char *Character, moderator bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeModerators = setMember(c.UnsafeModerators, char.ID(), moderator)
+}
+
+// Banned returns true if the Character has been banned from the Channel.
+func (c *Channel) Banned(char *Character) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return misc.Contains(c.UnsafeBanned, char.ID())
+}
+
+// SetBanned bans a Character from the Channel, or lifts their ban. A banned Character also loses any
+// moderator status or invitation.
+func (c *Channel) SetBanned(char *Character, banned bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeBanned = setMember(c.UnsafeBanned, char.ID(), banned)
+	if banned {
+		c.UnsafeModerators = setMember(c.UnsafeModerators, char.ID(), false)
+		c.UnsafeInvited = setMember(c.UnsafeInvited, char.ID(), false)
+	}
+}
+
+// Invite allows a Character to join the Channel when it is invite-only.
+func (c *Channel) Invite(char *Character) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeInvited = setMember(c.UnsafeInvited, char.ID(), true)
+}
+
+// Invited returns true if the Character has been invited to the Channel.
+func (c *Channel) Invited(char *Character) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return misc.Contains(c.UnsafeInvited, char.ID())
+}
+
+// InviteOnly returns true if Characters must be invited to join the Channel.
+func (c *Channel) InviteOnly() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeInviteOnly
+}
+
+// SetInviteOnly sets whether Characters must be invited to join the Channel.
+func (c *Channel) SetInviteOnly(inviteOnly bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeInviteOnly = inviteOnly
+}
+
+// HasPassword returns true if Characters must provide a password to join the Channel.
+func (c *Channel) HasPassword() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return len(c.UnsafePassword) > 0
+}
+
+// SetPassword encrypts and sets the password needed to join the Channel. An empty password removes it.
+func (c *Channel) SetPassword(pw string) {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(pw) == 0 {
+		c.UnsafePassword = ""
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(pw), bcrypt.MinCost)
+	if err != nil {
+		Armeria.log.Fatal("error generating password hash",
+			zap.Error(err),
+		)
+	}
+
+	c.UnsafePassword = string(hash)
+}
+
+// Topic returns what the Channel is for, as set by its moderators.
+func (c *Channel) Topic() string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeTopic
+}
+
+// SetTopic sets what the Channel is for.
+func (c *Channel) SetTopic(topic string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeTopic = topic
+}
+
+// CanJoin returns an error if the Character isn't allowed to join the Channel with the password they provided.
+// Moderators, and Characters that have been invited, can always join.
+func (c *Channel) CanJoin(char *Character, pw string) error {
+	if c.Banned(char) {
+		return ErrChannelBanned
+	} else if c.IsModerator(char) || c.Invited(char) {
+		return nil
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.UnsafeInviteOnly {
+		return ErrChannelInviteOnly
+	} else if len(c.UnsafePassword) > 0 && bcrypt.CompareHashAndPassword([]byte(c.UnsafePassword), []byte(pw)) != nil {
+		return ErrChannelPassword
+	}
+
+	return nil
+}
+
+// Listening returns true if the Character receives the messages sent to the Channel. Every member of a guild or
+// party receives the messages sent to its channel.
+func (c *Channel) Listening(char *Character) bool {
+	if c.guild != nil {
+		return c.guild.IsMember(char)
+	} else if c.party != nil {
+		return c.party.IsMember(char)
+	}
+
+	return char.InChannel(c)
+}
+
+// Members returns the Characters that have joined the Channel.
+func (c *Channel) Members() []*Character {
+	var members []*Character
+	for _, char := range Armeria.characterManager.Characters() {
+		if c.Listening(char) {
+			members = append(members, char)
+		}
+	}
+
+	return members
+}
+
+// charactersByID returns the Characters matching a list of uuids, skipping any that no longer exist.
+func charactersByID(ids []string) []*Character {
+	var chars []*Character
+	for _, id := range ids {
+		if char := Armeria.characterManager.CharacterById(id); char != nil {
+			chars = append(chars, char)
+		}
+	}
+
+	return chars
+}
+
+// setMember adds or removes an id from a list of ids, and returns the list.
+func setMember(ids []string, id string, member bool) []string {
+	for i, existing := range ids {
+		if existing == id {
+			if member {
+				return ids
+			}
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+
+	if member {
+		ids = append(ids, id)
+	}
+
+	return ids
 }
 
 // Broadcast sends a message to all logged-in players that have joined the channel. You can pass
@@ -74,6 +332,11 @@ func (c *Channel) Broadcast(from *Character, text string) {
 	var verbs []string
 
 	if from != nil {
+		if s := from.Muted(); s != nil {
+			from.Player().client.ShowColorizedText(s.Describe("You have been muted"), ColorError)
+			return
+		}
+
 		normalizedText, textType := TextPunctuation(text)
 		switch textType {
 		case TextQuestion:
@@ -99,8 +362,13 @@ func (c *Channel) Broadcast(from *Character, text string) {
 		msgToOthers = fmt.Sprintf("[%s] %s", TextStyle(c.Name, WithBold()), text)
 	}
 
+	// parties only last while their members are online, so there's no history worth keeping
+	if c.party == nil {
+		Armeria.chatHistoryManager.RecordChannel(c, from, msgToOthers)
+	}
+
 	for _, char := range Armeria.characterManager.OnlineCharacters() {
-		if char.InChannel(c) {
+		if c.Listening(char) && !char.Ignores(from) {
 			if from == nil || from.ID() != char.ID() {
 				char.Player().client.ShowColorizedText(
 					msgToOthers,
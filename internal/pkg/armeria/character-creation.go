@@ -0,0 +1,402 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CreationStep is a step of the character creation wizard.
+type CreationStep int
+
+const (
+	CreationStepName CreationStep = iota
+	CreationStepPassword
+	CreationStepConfirmPassword
+	CreationStepGender
+	CreationStepPicture
+	CreationStepConfirm
+)
+
+const (
+	// DefaultCreationsPerHour is how many characters can be created from the same address each hour, when the
+	// config doesn't specify a limit.
+	DefaultCreationsPerHour int = 3
+	// MinCharacterNameLength is the shortest name a player can give their character.
+	MinCharacterNameLength int = 3
+	// MaxCharacterNameLength is the longest name a player can give their character.
+	MaxCharacterNameLength int = 16
+	// MinPasswordLength is the shortest password a player can give their character.
+	MinPasswordLength int = 6
+)
+
+var (
+	// ErrCharacterNameInvalid is an error for when a character name is the wrong length or contains anything
+	// other than letters.
+	ErrCharacterNameInvalid = fmt.Errorf(
+		"names must be %d to %d letters long, with no spaces, numbers or symbols",
+		MinCharacterNameLength,
+		MaxCharacterNameLength,
+	)
+	// ErrCharacterNameTaken is an error for when a character name is already used by a character, mob or account.
+	ErrCharacterNameTaken = errors.New("that name is already taken")
+	// ErrCharacterNameReserved is an error for when someone else is creating a character with the same name.
+	ErrCharacterNameReserved = errors.New("someone else is creating a character with that name")
+	// ErrCreationRateLimited is an error for when too many characters have been created from the same address.
+	ErrCreationRateLimited = errors.New("too many characters have been created from your address recently")
+	// ErrTooManyCharacters is an error for when an account already has as many characters as it can.
+	ErrTooManyCharacters = fmt.Errorf("accounts can't have more than %d characters", MaxCharactersPerAccount)
+	// ErrNoStartingRoom is an error for when the starting room in the config doesn't exist.
+	ErrNoStartingRoom = errors.New("the starting room does not exist")
+
+	characterNameRegex = regexp.MustCompile(`^[A-Za-z]+$`)
+	creationGenders    = []string{"male", "female", "nonbinary"}
+)
+
+// CharacterCreation is the state of a character a player is in the middle of creating.
+type CharacterCreation struct {
+	Step     CreationStep
+	Name     string
+	Password string
+	Gender   string
+	Picture  string
+}
+
+// CreationManager enforces the rules around players creating their own characters: where they start, how often
+// they can create them and which names are reserved by characters that are still being created.
+type CreationManager struct {
+	sync.Mutex
+	settings creationConfig
+	reserved map[string]*Player
+	history  map[string][]time.Time
+}
+
+// NewCreationManager creates a new CreationManager using the settings from the config file.
+func NewCreationManager(settings creationConfig) *CreationManager {
+	return &CreationManager{
+		settings: settings,
+		reserved: make(map[string]*Player),
+		history:  make(map[string][]time.Time),
+	}
+}
+
+// RequireApproval returns true if characters created by players must be approved by a sysop.
+func (m *CreationManager) RequireApproval() bool {
+	return m.settings.RequireApproval
+}
+
+// StartingPictures returns the picture keys a player can choose from for their character.
+func (m *CreationManager) StartingPictures() []string {
+	return m.settings.StartingPictures
+}
+
+// StartingRoom returns the room that new characters are created in. Without a starting area in the config, the
+// first area is used, and without a starting room, the room at 0,0,0 is used.
+func (m *CreationManager) StartingRoom() (*Room, error) {
+	var a *Area
+	if len(m.settings.StartingArea) > 0 {
+		a = Armeria.worldManager.AreaByName(m.settings.StartingArea)
+	} else if areas := Armeria.worldManager.Areas(); len(areas) > 0 {
+		a = areas[0]
+	}
+
+	if a == nil {
+		return nil, ErrNoStartingRoom
+	}
+
+	coords := NewCoords(0, 0, 0, 0)
+	if len(m.settings.StartingRoom) > 0 {
+		var err error
+		if coords, err = ParseCoords(m.settings.StartingRoom); err != nil {
+			return nil, ErrNoStartingRoom
+		}
+	}
+
+	r := a.RoomAt(coords)
+	if r == nil {
+		return nil, ErrNoStartingRoom
+	}
+
+	return r, nil
+}
+
+// recentCreations returns when characters were created from an address within the last hour. The caller must
+// hold the lock.
+func (m *CreationManager) recentCreations(ip string) []time.Time {
+	var recent []time.Time
+	for _, t := range m.history[ip] {
+		if time.Since(t) < time.Hour {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) == 0 {
+		delete(m.history, ip)
+	} else {
+		m.history[ip] = recent
+	}
+
+	return recent
+}
+
+// limit returns how many characters can be created from the same address each hour.
+func (m *CreationManager) limit() int {
+	if m.settings.PerHour > 0 {
+		return m.settings.PerHour
+	}
+
+	return DefaultCreationsPerHour
+}
+
+// CheckRateLimit returns an error if too many characters have been created from the address within the last hour.
+func (m *CreationManager) CheckRateLimit(ip string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if len(m.recentCreations(ip)) >= m.limit() {
+		return ErrCreationRateLimited
+	}
+
+	return nil
+}
+
+// RecordCreation counts a character created from the address towards its rate limit.
+func (m *CreationManager) RecordCreation(ip string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if len(m.recentCreations(ip)) >= m.limit() {
+		return ErrCreationRateLimited
+	}
+
+	m.history[ip] = append(m.history[ip], time.Now())
+
+	return nil
+}
+
+// ValidateName returns an error if the name can't be used for a new character.
+func (m *CreationManager) ValidateName(name string) error {
+	if len(name) < MinCharacterNameLength || len(name) > MaxCharacterNameLength || !characterNameRegex.MatchString(name) {
+		return ErrCharacterNameInvalid
+	} else if Armeria.characterManager.CharacterByName(name) != nil || Armeria.mobManager.MobByName(name) != nil {
+		return ErrCharacterNameTaken
+	} else if Armeria.accountManager.AccountByUsername(name) != nil {
+		return ErrCharacterNameTaken
+	}
+
+	return nil
+}
+
+// Reserve validates a name and holds it for the player until they finish creating their character, so that no
+// one else can create a character with the same name in the meantime. Any name the player previously reserved is
+// released.
+func (m *CreationManager) Reserve(p *Player, name string) error {
+	if err := m.ValidateName(name); err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	key := strings.ToLower(name)
+	if holder, ok := m.reserved[key]; ok && holder != p {
+		return ErrCharacterNameReserved
+	}
+
+	m.release(p)
+	m.reserved[key] = p
+
+	return nil
+}
+
+// release frees the name reserved by the player. The caller must hold the lock.
+func (m *CreationManager) release(p *Player) {
+	for name, holder := range m.reserved {
+		if holder == p {
+			delete(m.reserved, name)
+		}
+	}
+}
+
+// Release frees the name reserved by the player.
+func (m *CreationManager) Release(p *Player) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.release(p)
+}
+
+// Finish creates the character the player has been working on, in the starting room. A player that is logged in
+// to an account has the character added to it, otherwise a new account is created using the character's name and
+// password. When approval is required, the character is left pending for a sysop to approve.
+func (m *CreationManager) Finish(p *Player, cc *CharacterCreation) (*Character, error) {
+	room, err := m.StartingRoom()
+	if err != nil {
+		return nil, err
+	}
+
+	a := p.Account()
+	if err := m.ValidateName(cc.Name); err != nil {
+		return nil, err
+	} else if a != nil && len(a.Characters()) >= MaxCharactersPerAccount {
+		return nil, ErrTooManyCharacters
+	} else if err := m.RecordCreation(p.IP()); err != nil {
+		return nil, err
+	}
+
+	password := cc.Password
+	if a != nil {
+		// Characters on an existing account are logged in to with the account's password.
+		password = randomHex(SessionTokenBytes)
+	}
+
+	c := Armeria.characterManager.CreateCharacter(cc.Name, password)
+	_ = c.SetAttribute(AttributeGender, cc.Gender)
+	if len(cc.Picture) > 0 {
+		_ = c.SetAttribute(AttributePicture, cc.Picture)
+	}
+	c.SetPending(m.RequireApproval())
+
+	if err := room.Here().Add(c.ID()); err != nil {
+		Armeria.characterManager.RemoveCharacter(c)
+		return nil, err
+	}
+
+	if a == nil {
+		a = Armeria.accountManager.CreateAccount(cc.Name, cc.Password)
+	}
+	a.AddCharacter(c)
+
+	m.Release(p)
+
+	Armeria.log.Info("character created by player",
+		zap.String("name", c.Name()),
+		zap.String("ip", p.IP()),
+		zap.Bool("pending", c.Pending()),
+	)
+
+	return c, nil
+}
+
+// CreationPrompt returns the text asking the player for the answer to the current step of character creation.
+func CreationPrompt(cc *CharacterCreation) string {
+	answer := func(a string) string {
+		return TextStyle(fmt.Sprintf("/create %s", a), WithBold())
+	}
+
+	switch cc.Step {
+	case CreationStepName:
+		return fmt.Sprintf("What would you like your character to be called? Use %s to choose.", answer("&lt;name&gt;"))
+	case CreationStepPassword:
+		return fmt.Sprintf(
+			"Choose a password, at least %d characters long, using %s.",
+			MinPasswordLength,
+			answer("&lt;password&gt;"),
+		)
+	case CreationStepConfirmPassword:
+		return fmt.Sprintf("Enter the same password again, using %s, to confirm it.", answer("&lt;password&gt;"))
+	case CreationStepGender:
+		var options []string
+		for _, g := range creationGenders {
+			options = append(options, TextStyle(g, WithLinkCmd(fmt.Sprintf("/create %s", g))))
+		}
+		return fmt.Sprintf("What is your character's gender? Choose %s.", strings.Join(options, ", "))
+	case CreationStepPicture:
+		var options []string
+		for i := range Armeria.creationManager.StartingPictures() {
+			n := strconv.Itoa(i + 1)
+			options = append(options, TextStyle(n, WithLinkCmd(fmt.Sprintf("/create %s", n))))
+		}
+		return fmt.Sprintf(
+			"Choose a starting appearance for your character: %s, or %s.",
+			strings.Join(options, ", "),
+			TextStyle("none", WithLinkCmd("/create none")),
+		)
+	case CreationStepConfirm:
+		picture := "none"
+		if len(cc.Picture) > 0 {
+			picture = "chosen"
+		}
+		return fmt.Sprintf(
+			"You are about to create %s (%s, appearance %s). Is this correct? Choose %s or %s.",
+			TextStyle(cc.Name, WithBold()),
+			cc.Gender,
+			picture,
+			TextStyle("yes", WithLinkCmd("/create yes")),
+			TextStyle("no", WithLinkCmd("/create no")),
+		)
+	}
+
+	return ""
+}
+
+// AnswerCreationStep applies the player's answer to the current step of character creation and moves on to the
+// next step. Returns true once the player has confirmed the character should be created, or an error, to show to
+// the player, if the answer isn't valid.
+func AnswerCreationStep(p *Player, cc *CharacterCreation, answer string) (bool, error) {
+	switch cc.Step {
+	case CreationStepName:
+		name := strings.Title(strings.ToLower(answer))
+		if err := Armeria.creationManager.Reserve(p, name); err != nil {
+			return false, err
+		}
+		cc.Name = name
+		cc.Step = CreationStepPassword
+		if p.Account() != nil {
+			// The account already has a password.
+			cc.Step = CreationStepGender
+		}
+	case CreationStepPassword:
+		if len(answer) < MinPasswordLength || strings.Contains(answer, " ") {
+			return false, fmt.Errorf("passwords must be at least %d characters long, with no spaces", MinPasswordLength)
+		}
+		cc.Password = answer
+		cc.Step = CreationStepConfirmPassword
+	case CreationStepConfirmPassword:
+		if answer != cc.Password {
+			cc.Password = ""
+			cc.Step = CreationStepPassword
+			return false, errors.New("the passwords didn't match, so you'll need to choose one again")
+		}
+		cc.Step = CreationStepGender
+	case CreationStepGender:
+		gender := strings.ToLower(answer)
+		if !misc.Contains(creationGenders, gender) {
+			return false, fmt.Errorf("choose one of %s", strings.Join(creationGenders, ", "))
+		}
+		cc.Gender = gender
+		cc.Step = CreationStepPicture
+		if len(Armeria.creationManager.StartingPictures()) == 0 {
+			cc.Step = CreationStepConfirm
+		}
+	case CreationStepPicture:
+		pictures := Armeria.creationManager.StartingPictures()
+		if strings.ToLower(answer) == "none" {
+			cc.Picture = ""
+		} else if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(pictures) {
+			cc.Picture = pictures[n-1]
+		} else {
+			return false, fmt.Errorf("choose a number from 1 to %d, or none", len(pictures))
+		}
+		cc.Step = CreationStepConfirm
+	case CreationStepConfirm:
+		switch strings.ToLower(answer) {
+		case "yes":
+			return true, nil
+		case "no":
+			Armeria.creationManager.Release(p)
+			*cc = CharacterCreation{}
+		default:
+			return false, errors.New("choose yes or no")
+		}
+	}
+
+	return false, nil
+}
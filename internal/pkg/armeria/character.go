@@ -33,6 +33,16 @@ type Character struct {
 	UnsafeTempAttributes map[string]string `json:"-"`
 	UnsafeLastSeen       time.Time         `json:"lastSeen"`
 	UnsafeMobConvo       *Conversation     `json:"-"`
+	UnsafeRoomSelection  *RoomSelection    `json:"-"`
+	UnsafeJournal        *EditJournal      `json:"-"`
+	UnsafeTrade          *Trade            `json:"-"`
+	UnsafeParty          *Party            `json:"-"`
+	UnsafeMail           []*Mail           `json:"mail"`
+	UnsafeMailDraft      *MailDraft        `json:"-"`
+	UnsafeCrafting       *CraftingJob      `json:"-"`
+	UnsafePending        bool              `json:"pending,omitempty"`
+	UnsafeSessions       []*Session        `json:"sessions,omitempty"`
+	UnsafeIgnored        []string          `json:"ignored,omitempty"`
 	player               *Player
 }
 
@@ -54,6 +64,9 @@ const (
 	ColorChannelCore
 	ColorChannelBuilders
 	ColorMoney
+	ColorChannelPlayer
+	ColorChannelGuild
+	ColorChannelParty
 
 	PronounSubjective PronounType = iota
 	PronounPossessiveAdjective
@@ -77,6 +90,10 @@ func (c *Character) Init() {
 	// Sync the containers.
 	c.UnsafeInventory.Sync()
 	c.UnsafeEquipment.Sync()
+	// Initialize the mailbox.
+	for _, m := range c.UnsafeMail {
+		m.Init()
+	}
 	// Register the Character with global registry.
 	Armeria.registry.Register(c, c.ID(), RegistryTypeCharacter)
 }
@@ -204,6 +221,100 @@ func (c *Character) SetMobConvo(convo *Conversation) {
 	c.UnsafeMobConvo = convo
 }
 
+// RoomSelection returns the rooms the Character has selected for bulk editing.
+func (c *Character) RoomSelection() *RoomSelection {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeRoomSelection
+}
+
+// SetRoomSelection sets the rooms the Character has selected for bulk editing.
+func (c *Character) SetRoomSelection(rs *RoomSelection) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeRoomSelection = rs
+}
+
+// Trade returns the trade the Character has requested or is taking part in.
+func (c *Character) Trade() *Trade {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeTrade
+}
+
+// SetTrade sets the trade the Character has requested or is taking part in.
+func (c *Character) SetTrade(t *Trade) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeTrade = t
+}
+
+// Mail returns the Mail in the Character's mailbox, oldest first.
+func (c *Character) Mail() []*Mail {
+	c.RLock()
+	defer c.RUnlock()
+
+	return append([]*Mail{}, c.UnsafeMail...)
+}
+
+// ReceiveMail adds Mail to the Character's mailbox.
+func (c *Character) ReceiveMail(m *Mail) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeMail = append(c.UnsafeMail, m)
+}
+
+// RemoveMail removes Mail from the Character's mailbox.
+func (c *Character) RemoveMail(m *Mail) {
+	c.Lock()
+	defer c.Unlock()
+
+	for i, cm := range c.UnsafeMail {
+		if cm == m {
+			c.UnsafeMail = append(c.UnsafeMail[:i], c.UnsafeMail[i+1:]...)
+			return
+		}
+	}
+}
+
+// MailDraft returns the attachments the Character has chosen for the next mail they send, creating the draft if
+// needed.
+func (c *Character) MailDraft() *MailDraft {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.UnsafeMailDraft == nil {
+		c.UnsafeMailDraft = &MailDraft{}
+	}
+
+	return c.UnsafeMailDraft
+}
+
+// ClearMailDraft discards the attachments the Character has chosen for the next mail they send.
+func (c *Character) ClearMailDraft() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeMailDraft = nil
+}
+
+// Journal returns the Character's edit journal, which keeps track of changes made using builder commands.
+func (c *Character) Journal() *EditJournal {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.UnsafeJournal == nil {
+		c.UnsafeJournal = NewEditJournal()
+	}
+
+	return c.UnsafeJournal
+}
+
 // Room returns the Character's Room based on the object container it is within.
 func (c *Character) Room() *Room {
 	oc := Armeria.registry.GetObjectContainer(c.ID())
@@ -242,6 +353,12 @@ func (c *Character) UserColor(color int) string {
 		return "#007cff"
 	case ColorMoney:
 		return "#fec205"
+	case ColorChannelPlayer:
+		return "#f06292"
+	case ColorChannelGuild:
+		return "#66bb6a"
+	case ColorChannelParty:
+		return "#ffa726"
 	default:
 		return ""
 	}
@@ -313,6 +430,35 @@ func (c *Character) LoggedIn() {
 	c.Player().client.SyncMoney()
 	c.Player().client.SyncCommands()
 	c.Player().client.SyncSettings()
+	c.Player().client.SyncParty()
+
+	// Let the character know about unread mail
+	if unread := c.UnreadMail(); unread > 0 {
+		c.Player().client.ShowText(
+			fmt.Sprintf(
+				"You have %s in your mailbox.",
+				TextStyle(fmt.Sprintf("%d unread mail", unread), WithBold(), WithLinkCmd("/mail list")),
+			),
+		)
+	}
+
+	// Catch the character up on what was said in their channels while they were away
+	channels := c.Channels()
+	if g := Armeria.guildManager.GuildOf(c); g != nil {
+		channels = append(channels, g.Channel())
+	}
+
+	for _, ch := range channels {
+		if !ch.HasPermission(c) {
+			continue
+		}
+
+		for _, msg := range Armeria.chatHistoryManager.Channel(ch, ChannelHistoryReplay) {
+			if !c.ignoredMessage(msg) {
+				c.Player().client.ShowColorizedText(msg.String(), ch.Color)
+			}
+		}
+	}
 
 	Armeria.log.Info("character entered the game",
 		zap.String("character", c.Name()),
@@ -348,6 +494,26 @@ func (c *Character) LoggedOut() {
 		delete(c.UnsafeTempAttributes, key)
 	}
 
+	// Clear any room selection
+	c.SetRoomSelection(nil)
+
+	// Discard any mail attachments that were chosen but not sent
+	c.ClearMailDraft()
+
+	// Stop crafting
+	c.SetCrafting(nil)
+
+	// Cancel any trade
+	if t := c.Trade(); t != nil {
+		c.SetTrade(nil)
+		CancelTrade(t, fmt.Sprintf("%s disconnected and the trade was cancelled.", c.Name()))
+	}
+
+	// Leave any party
+	if p := c.Party(); p != nil {
+		p.Leave(c, fmt.Sprintf("%s disconnected and left the party.", c.FormattedName()))
+	}
+
 	// Stop any on-going mob conversations
 	if c.MobConvo() != nil {
 		c.MobConvo().Cancel()
@@ -404,33 +570,42 @@ func (c *Character) Attribute(name string) string {
 	return c.UnsafeAttributes[name]
 }
 
-// Money returns the character's money as a float.
-func (c *Character) Money() float64 {
+// Money returns the money the character is carrying.
+func (c *Character) Money() Money {
 	money := c.Attribute(AttributeMoney)
-	f, err := strconv.ParseFloat(money, 64)
+	m, err := strconv.ParseInt(money, 10, 64)
 	if err != nil {
-		Armeria.log.Fatal("unable to convert money to float64",
+		Armeria.log.Fatal("unable to convert money to int64",
 			zap.Error(err),
 		)
 	}
-	return f
+	return Money(m)
 }
 
-// RemoveMoney attempts to remove money from the character and returns True if they can afford it.
-func (c *Character) RemoveMoney(amount float64) bool {
-	money := c.Money()
-	if amount > money {
-		return false
-	}
-
-	_ = c.SetAttribute(AttributeMoney, fmt.Sprintf("%.2f", money-amount))
+// setMoney sets the money the character is carrying. This must only be called by the EconomyManager.
+func (c *Character) setMoney(m Money) {
+	_ = c.SetAttribute(AttributeMoney, strconv.FormatInt(int64(m), 10))
+}
 
-	return true
+// MoneyHolderName returns the name of the character as it appears in transaction histories.
+func (c *Character) MoneyHolderName() string {
+	return c.Name()
 }
 
-// AddMoney adds money to the character.
-func (c *Character) AddMoney(amount float64) {
-	_ = c.SetAttribute(AttributeMoney, fmt.Sprintf("%.2f", c.Money()+amount))
+// BankCard returns the bank card the character has equipped, or nil if they do not have one equipped.
+func (c *Character) BankCard() *ItemInstance {
+	for _, r := range c.Equipment().AtSlotName(EquipSlotWalletBank) {
+		if r.Type != RegistryTypeItemInstance {
+			continue
+		}
+
+		ii := r.Object.(*ItemInstance)
+		if ii.Attribute(AttributeType) == ItemTypeBankCard {
+			return ii
+		}
+	}
+
+	return nil
 }
 
 // SetSetting sets a Character setting and only valid settings can be set.
@@ -471,6 +646,10 @@ func (c *Character) MoveAllowed(r *Room) (bool, string) {
 		return false, "You cannot walk onto the train tracks!"
 	}
 
+	if r.Attribute("type") == RoomTypeJail {
+		return false, "The door to the jail is locked."
+	}
+
 	return true, ""
 }
 
@@ -483,6 +662,8 @@ func (c *Character) Move(to *Room, msgToChar string, msgToOld string, msgToNew s
 		oldRoom = to
 	}
 
+	followers := c.followersIn(oldRoom)
+
 	oldRoom.Here().Remove(c.ID())
 	if err := to.Here().Add(c.ID()); err != nil {
 		Armeria.log.Fatal("error adding character to destination room")
@@ -530,6 +711,35 @@ func (c *Character) Move(to *Room, msgToChar string, msgToOld string, msgToNew s
 	if c.TempAttribute(TempAttributeEditorOpen) == "true" {
 		c.Player().client.ShowObjectEditor(to.EditorData())
 	}
+
+	// Bring along any party members following the character, unless they can't go there.
+	if oldRoom == to {
+		return
+	}
+
+	for _, f := range followers {
+		if allowed, _ := f.MoveAllowed(to); !allowed || f.Jailed() != nil {
+			f.Player().client.ShowColorizedText(
+				fmt.Sprintf("You are unable to follow %s.", c.FormattedName()),
+				ColorError,
+			)
+			continue
+		}
+
+		f.Move(
+			to,
+			TextStyle(fmt.Sprintf("You follow %s.", c.FormattedName()), WithUserColor(f, ColorMovement)),
+			TextStyle(fmt.Sprintf("%s follows %s.", f.FormattedName(), c.FormattedName()), WithUserColor(f, ColorMovement)),
+			TextStyle(fmt.Sprintf("%s walked in behind %s.", f.FormattedName(), c.FormattedName()), WithUserColor(f, ColorMovement)),
+			"",
+		)
+
+		if f.Setting(SettingBrief) == "true" {
+			Armeria.commandManager.ProcessCommand(f.Player(), "glance", false)
+		} else {
+			Armeria.commandManager.ProcessCommand(f.Player(), "look", false)
+		}
+	}
 }
 
 // EditorData returns the JSON used for the object editor.
@@ -574,13 +784,18 @@ func (c *Character) SettingsJSON() string {
 	return string(b)
 }
 
-// HasPermission returns true if the Character has a particular permission.
+// HasPermission returns true if the Character, or the Account that owns it, has a particular permission.
 func (c *Character) HasPermission(p string) bool {
 	c.RLock()
-	defer c.RUnlock()
-
 	perms := strings.Split(c.UnsafeAttributes[AttributePermissions], " ")
-	return misc.Contains(perms, p)
+	c.RUnlock()
+
+	if misc.Contains(perms, p) {
+		return true
+	}
+
+	a := c.Account()
+	return a != nil && a.HasPermission(p)
 }
 
 // Channels returns the Channel objects for the channels this unsafeCharacter is within.
@@ -641,14 +856,32 @@ func (c *Character) InventoryJSON() string {
 	var inventory []map[string]interface{}
 
 	for _, ii := range c.Inventory().Items() {
-		inventory = append(inventory, map[string]interface{}{
+		item := map[string]interface{}{
 			"uuid":      ii.ID(),
 			"name":      ii.Name(),
 			"picture":   ii.Attribute(AttributePicture),
 			"slot":      c.Inventory().Slot(ii.ID()),
 			"equipSlot": ii.Attribute(AttributeEquipSlot),
 			"color":     ii.RarityColor(),
-		})
+			"quantity":  ii.Quantity(),
+		}
+
+		if ii.IsContainer() {
+			var contents []map[string]interface{}
+			for _, ci := range ii.Contents().Items() {
+				contents = append(contents, map[string]interface{}{
+					"uuid":     ci.ID(),
+					"name":     ci.Name(),
+					"picture":  ci.Attribute(AttributePicture),
+					"color":    ci.RarityColor(),
+					"quantity": ci.Quantity(),
+				})
+			}
+			item["contents"] = contents
+			item["capacity"] = ii.Contents().MaxSize()
+		}
+
+		inventory = append(inventory, item)
 	}
 
 	inventoryJSON, err := json.Marshal(inventory)
@@ -685,7 +918,33 @@ func (c *Character) Pronoun(pt PronounType) string {
 		} else if pt == PronounObjective {
 			return "her"
 		}
+	} else if gender == "nonbinary" {
+		if pt == PronounSubjective {
+			return "they"
+		} else if pt == PronounPossessiveAbsolute {
+			return "theirs"
+		} else if pt == PronounPossessiveAdjective {
+			return "their"
+		} else if pt == PronounObjective {
+			return "them"
+		}
 	}
 
 	return ""
 }
+
+// Pending returns true if the Character was created by a player and is waiting for a sysop to approve it.
+func (c *Character) Pending() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafePending
+}
+
+// SetPending sets whether the Character is waiting for a sysop to approve it.
+func (c *Character) SetPending(pending bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafePending = pending
+}
@@ -146,6 +146,44 @@ func (m *CharacterManager) CreateCharacter(name, password string) *Character {
 	return c
 }
 
+// RemoveCharacter removes a Character, that has never entered the game, from memory and from the room it was
+// created in.
+func (m *CharacterManager) RemoveCharacter(c *Character) {
+	if r := c.Room(); r != nil {
+		r.Here().Remove(c.ID())
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for i, char := range m.UnsafeCharacters {
+		if char == c {
+			m.UnsafeCharacters = append(m.UnsafeCharacters[:i], m.UnsafeCharacters[i+1:]...)
+			break
+		}
+	}
+
+	Armeria.registry.Unregister(c.ID())
+
+	Armeria.log.Info("character removed",
+		zap.String("name", c.Name()),
+	)
+}
+
+// PendingCharacters returns the characters waiting for a sysop to approve them.
+func (m *CharacterManager) PendingCharacters() []*Character {
+	m.RLock()
+	defer m.RUnlock()
+
+	var chars []*Character
+	for _, c := range m.UnsafeCharacters {
+		if c.Pending() {
+			chars = append(chars, c)
+		}
+	}
+	return chars
+}
+
 // OnlineCharacters returns the characters logged in to the game.
 func (m *CharacterManager) OnlineCharacters() []*Character {
 	m.RLock()
@@ -0,0 +1,220 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxChannelHistory is how many messages are kept for each channel.
+	MaxChannelHistory int = 100
+	// MaxWhisperHistory is how many messages are kept for each whisper conversation.
+	MaxWhisperHistory int = 50
+	// ChannelHistoryReplay is how many messages from each joined channel are shown when a character logs in.
+	ChannelHistoryReplay int = 5
+	// DefaultHistoryLines is how many messages the history commands show, unless asked for more or fewer.
+	DefaultHistoryLines int = 20
+)
+
+// ChatMessage is a message that was sent to a channel or whispered, as it was shown to the people who received
+// it. From is empty for system messages.
+type ChatMessage struct {
+	From string    `json:"from"`
+	Text string    `json:"text"`
+	Sent time.Time `json:"sent"`
+}
+
+// String returns the message, prefixed with when it was sent.
+func (cm *ChatMessage) String() string {
+	return fmt.Sprintf("[%s] %s", cm.Sent.Format("Jan 2 15:04"), cm.Text)
+}
+
+// ChatHistoryManager keeps a bounded history of the messages sent to each channel, and whispered between each
+// pair of characters, so they can be read by anyone who wasn't online at the time.
+type ChatHistoryManager struct {
+	sync.RWMutex
+	dataFile       string
+	UnsafeChannels map[string][]*ChatMessage `json:"channels"`
+	UnsafeWhispers map[string][]*ChatMessage `json:"whispers"`
+}
+
+// NewChatHistoryManager creates a new ChatHistoryManager.
+func NewChatHistoryManager() *ChatHistoryManager {
+	m := &ChatHistoryManager{
+		dataFile: fmt.Sprintf("%s/chat-history.json", Armeria.dataPath),
+	}
+
+	m.LoadHistory()
+
+	return m
+}
+
+// LoadHistory loads the chat history from disk into memory.
+func (m *ChatHistoryManager) LoadHistory() {
+	m.Lock()
+	defer m.Unlock()
+
+	historyFile, err := os.Open(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer historyFile.Close()
+
+	jsonParser := json.NewDecoder(historyFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	if m.UnsafeChannels == nil {
+		m.UnsafeChannels = make(map[string][]*ChatMessage)
+	}
+	if m.UnsafeWhispers == nil {
+		m.UnsafeWhispers = make(map[string][]*ChatMessage)
+	}
+
+	Armeria.log.Info("chat history loaded",
+		zap.Int("channels", len(m.UnsafeChannels)),
+		zap.Int("whispers", len(m.UnsafeWhispers)),
+	)
+}
+
+// SaveHistory writes the in-memory chat history to disk.
+func (m *ChatHistoryManager) SaveHistory() {
+	m.RLock()
+	defer m.RUnlock()
+
+	historyFile, err := os.Create(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to create data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer historyFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := historyFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = historyFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// whisperKey returns the key a whisper conversation between two characters is stored under, which is the same
+// whichever of them sent the message.
+func whisperKey(a, b *Character) string {
+	ids := []string{a.ID(), b.ID()}
+	sort.Strings(ids)
+	return strings.Join(ids, ":")
+}
+
+// historyKey returns the key a channel's history is stored under. Guild channels are keyed by the guild, so their
+// history never mixes with another channel of the same name.
+func (ch *Channel) historyKey() string {
+	if ch.guild != nil {
+		return "guild:" + ch.guild.ID()
+	}
+
+	return strings.ToLower(ch.Name)
+}
+
+// appendMessage adds a message to a history, dropping the oldest messages past the limit.
+func appendMessage(history []*ChatMessage, msg *ChatMessage, limit int) []*ChatMessage {
+	history = append(history, msg)
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+
+	return history
+}
+
+// lastMessages returns up to the last n messages of a history.
+func lastMessages(history []*ChatMessage, n int) []*ChatMessage {
+	if n < len(history) {
+		history = history[len(history)-n:]
+	}
+
+	messages := make([]*ChatMessage, len(history))
+	copy(messages, history)
+
+	return messages
+}
+
+// RecordChannel adds a message to a channel's history. The sender is nil for system messages.
+func (m *ChatHistoryManager) RecordChannel(ch *Channel, from *Character, text string) {
+	msg := &ChatMessage{Text: text, Sent: time.Now()}
+	if from != nil {
+		msg.From = from.ID()
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	key := ch.historyKey()
+	m.UnsafeChannels[key] = appendMessage(m.UnsafeChannels[key], msg, MaxChannelHistory)
+}
+
+// RecordWhisper adds a message to the history of the whisper conversation between two characters.
+func (m *ChatHistoryManager) RecordWhisper(from *Character, to *Character, text string) {
+	msg := &ChatMessage{From: from.ID(), Text: text, Sent: time.Now()}
+
+	m.Lock()
+	defer m.Unlock()
+
+	key := whisperKey(from, to)
+	m.UnsafeWhispers[key] = appendMessage(m.UnsafeWhispers[key], msg, MaxWhisperHistory)
+}
+
+// Channel returns up to the last n messages sent to a channel, oldest first.
+func (m *ChatHistoryManager) Channel(ch *Channel, n int) []*ChatMessage {
+	m.RLock()
+	defer m.RUnlock()
+
+	return lastMessages(m.UnsafeChannels[ch.historyKey()], n)
+}
+
+// Whispers returns up to the last n messages whispered between two characters, oldest first.
+func (m *ChatHistoryManager) Whispers(a *Character, b *Character, n int) []*ChatMessage {
+	m.RLock()
+	defer m.RUnlock()
+
+	return lastMessages(m.UnsafeWhispers[whisperKey(a, b)], n)
+}
+
+// ClearChannel forgets the history of a channel.
+func (m *ChatHistoryManager) ClearChannel(ch *Channel) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.UnsafeChannels, ch.historyKey())
+}
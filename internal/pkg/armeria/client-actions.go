@@ -121,7 +121,17 @@ func (ca *ClientActions) SendPong() {
 
 // SyncMoney sets the character's money on the client.
 func (ca *ClientActions) SyncMoney() {
-	ca.parent.CallClientAction("setMoney", ca.parent.Character().Attribute(AttributeMoney))
+	ca.parent.CallClientAction("setMoney", ca.parent.Character().Money().Decimal())
+}
+
+// SyncParty sets the members of the character's party on the client.
+func (ca *ClientActions) SyncParty() {
+	members := "[]"
+	if p := ca.parent.Character().Party(); p != nil {
+		members = p.MembersJSON()
+	}
+
+	ca.parent.CallClientAction("setParty", members)
 }
 
 // SyncPlayerInfo sets the character/player information on the client.
@@ -156,12 +166,13 @@ func (ca *ClientActions) Disconnect() {
 	ca.parent.CallClientAction("disconnect", nil)
 }
 
-// ToggleAutologin sets (or disables) auto-login on the client.
-func (ca *ClientActions) ToggleAutologin() {
-	ca.parent.CallClientAction(
-		"toggleAutoLogin",
-		strings.ToLower(ca.parent.Character().Name())+":"+ca.parent.Character().PasswordHash(),
-	)
+// ToggleAutologin sets auto-login on the client to use a session token, or disables it when the token is empty.
+func (ca *ClientActions) ToggleAutologin(token string) {
+	if len(token) > 0 {
+		token = strings.ToLower(ca.parent.Character().Name()) + ":" + token
+	}
+
+	ca.parent.CallClientAction("toggleAutoLogin", token)
 }
 
 // SetItemTooltipHTML sets the item's tooltip HTML on the client and stores it in the client-side cache.
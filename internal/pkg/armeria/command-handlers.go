@@ -6,8 +6,11 @@ import (
 	"armeria/internal/pkg/validate"
 	"fmt"
 	"log"
+	"net"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/muesli/reflow/wordwrap"
 	"go.uber.org/zap"
@@ -15,9 +18,45 @@ import (
 	lua "github.com/yuin/gopher-lua"
 )
 
+// loginFailed records a failed login, and lets the Core channel know if it locked out the account or address.
+func loginFailed(ctx *CommandContext, name string) {
+	ip := ctx.Player.IP()
+	if !Armeria.loginGuard.RecordFailure(ip, name) {
+		return
+	}
+
+	target := fmt.Sprintf("from %s", ip)
+	if len(name) > 0 {
+		target = fmt.Sprintf("for %s from %s", TextStyle(name, WithBold()), ip)
+	}
+
+	Armeria.channels[ChannelCore].Broadcast(
+		nil,
+		fmt.Sprintf(
+			"Logins %s have been locked for %s after too many failed attempts. Use /unlock to lift this early.",
+			target,
+			LoginLockoutDuration,
+		),
+	)
+}
+
 func handleLoginCommand(ctx *CommandContext) {
 	var c *Character
 
+	name := ctx.Args["username"]
+	if len(ctx.Args) == 1 {
+		name = strings.Split(ctx.Args["token"], ":")[0]
+	}
+
+	if err := Armeria.loginGuard.Check(ctx.Player.IP(), name); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't log in right now: %s.", err), ColorError)
+		return
+	}
+
+	if sanctionBanned(ctx.Player, nil) {
+		return
+	}
+
 	if len(ctx.Args) == 1 {
 		// token auth
 		sections := strings.Split(ctx.Args["token"], ":")
@@ -29,48 +68,282 @@ func handleLoginCommand(ctx *CommandContext) {
 
 		c = Armeria.characterManager.CharacterByName(sections[0])
 		if c == nil {
+			loginFailed(ctx, "")
 			ctx.Player.client.ShowText("Character not found.")
 			return
 		}
 
-		if c.PasswordHash() != sections[1] {
+		if sections[1] == c.PasswordHash() {
+			ctx.Player.client.ShowColorizedText(
+				"This login token is no longer supported. Log in with your password, then use /autologin again.",
+				ColorError,
+			)
+			return
+		}
+
+		s, err := c.UseSession(sections[1])
+		if err == ErrSessionExpired {
+			ctx.Player.client.ShowColorizedText("Your login token has expired. Log in with your password.", ColorError)
+			return
+		} else if err != nil {
+			loginFailed(ctx, c.Name())
 			ctx.Player.client.ShowColorizedText("Invalid token for that character.", ColorError)
 			return
 		}
+
+		ctx.Player.SetSessionID(s.ID)
 	} else {
 		// basic auth
-		c = Armeria.characterManager.CharacterByName(ctx.Args["character"])
-		if c == nil {
-			ctx.Player.client.ShowText("Character not found.")
+		a := Armeria.accountManager.AccountByUsername(name)
+		if a == nil {
+			loginFailed(ctx, "")
+			ctx.Player.client.ShowText("Account not found.")
+			return
+		}
+
+		if !a.CheckPassword(ctx.Args["password"]) {
+			loginFailed(ctx, a.Username())
+			ctx.Player.client.ShowColorizedText("Password incorrect for that account.", ColorError)
 			return
 		}
 
-		if !c.CheckPassword(ctx.Args["password"]) {
-			ctx.Player.client.ShowColorizedText("Password incorrect for that character.", ColorError)
+		Armeria.loginGuard.RecordSuccess(a.Username())
+
+		if accountBanned(ctx.Player, a) {
 			return
 		}
+
+		ctx.Player.AttachAccount(a)
+
+		// Skip character select when there is only one character to choose from.
+		if chars := a.Characters(); len(chars) == 1 {
+			enterGame(ctx.Player, chars[0])
+		} else {
+			showCharacterSelect(ctx.Player)
+		}
+		return
+	}
+
+	Armeria.loginGuard.RecordSuccess(c.Name())
+
+	enterGame(ctx.Player, c)
+}
+
+// accountBanned lets the player know, and returns true, if the account is banned.
+func accountBanned(p *Player, a *Account) bool {
+	if a == nil || !a.Banned() {
+		return false
+	}
+
+	msg := "This account has been banned"
+	if until := a.BannedUntil(); !until.IsZero() {
+		msg = fmt.Sprintf("%s until %s", msg, until.Format("Mon Jan 2 2006 15:04 MST"))
+	}
+	if reason := a.BanReason(); len(reason) > 0 {
+		msg = fmt.Sprintf("%s: %s", msg, reason)
+	}
+
+	p.client.ShowColorizedText(msg+".", ColorError)
+	return true
+}
+
+// sanctionBanned lets the player know, and returns true, if the character or the address they are connecting from
+// is banned. The character can be nil.
+func sanctionBanned(p *Player, c *Character) bool {
+	s := Armeria.moderationManager.Banned(c, p.IP())
+	if s == nil {
+		return false
+	}
+
+	if len(s.Character) > 0 {
+		p.client.ShowColorizedText(s.Describe("This character has been banned"), ColorError)
+	} else {
+		p.client.ShowColorizedText(s.Describe("Your address has been banned"), ColorError)
+	}
+
+	return true
+}
+
+// showCharacterSelect shows the player the characters on their account, to choose one to play.
+func showCharacterSelect(p *Player) {
+	a := p.Account()
+	chars := a.Characters()
+
+	p.client.ShowColorizedText(
+		fmt.Sprintf("You've logged in to the account %s.", TextStyle(a.Username(), WithBold())),
+		ColorSuccess,
+	)
+
+	if len(chars) == 0 {
+		p.client.ShowText(
+			fmt.Sprintf(
+				"You don't have any characters yet. Use %s to create one.",
+				TextStyle("/create", WithLinkCmd("/create")),
+			),
+		)
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Character", header: true},
+		TableCell{content: "Last Played", header: true},
+	)}
+
+	for _, c := range chars {
+		name := TextStyle(c.Name(), WithLinkCmd(fmt.Sprintf("/play %s", c.Name())))
+		if c.Pending() {
+			name = fmt.Sprintf("%s (awaiting approval)", c.Name())
+		}
+
+		played := "never"
+		if !c.LastSeen().IsZero() {
+			played = c.LastSeen().Format("2006-01-02 15:04")
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: name},
+			TableCell{content: played},
+		))
+	}
+
+	p.client.ShowText(TextTable(rows...))
+
+	msg := "Choose a character to play with /play."
+	if len(chars) < MaxCharactersPerAccount {
+		msg = fmt.Sprintf(
+			"Choose a character to play with /play, or %s a new one.",
+			TextStyle("create", WithLinkCmd("/create")),
+		)
+	}
+	p.client.ShowText(msg)
+}
+
+func handlePlayCommand(ctx *CommandContext) {
+	c := ctx.Player.Account().CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("You don't have a character by that name.", ColorError)
+		return
+	}
+
+	enterGame(ctx.Player, c)
+}
+
+// enterGame logs the player into the game world as the character.
+func enterGame(p *Player, c *Character) {
+	a := c.Account()
+	if accountBanned(p, a) || sanctionBanned(p, c) {
+		return
+	}
+
+	if c.Pending() {
+		p.client.ShowColorizedText("This character is still waiting to be approved.", ColorError)
+		return
 	}
 
 	if c.Player() != nil {
-		ctx.Player.client.ShowColorizedText("This character is already logged in.", ColorError)
+		p.client.ShowColorizedText("This character is already logged in.", ColorError)
 		return
 	}
 
 	if c.Room() == nil {
-		ctx.Player.client.ShowColorizedText("This character logged out of a room which no longer exists.", ColorError)
+		p.client.ShowColorizedText("This character logged out of a room which no longer exists.", ColorError)
 		return
 	}
 
-	ctx.Player.AttachCharacter(c)
-	c.SetPlayer(ctx.Player)
+	p.AttachAccount(a)
+	p.AttachCharacter(c)
+	c.SetPlayer(p)
 
-	ctx.Player.client.ShowColorizedText(fmt.Sprintf("You've entered Armeria as %s!", c.FormattedName()), ColorSuccess)
+	p.client.ShowColorizedText(fmt.Sprintf("You've entered Armeria as %s!", c.FormattedName()), ColorSuccess)
 
 	c.LoggedIn()
 }
 
 func handleCreateCommand(ctx *CommandContext) {
-	ctx.Player.client.ShowText("You cannot manually create new characters yet. Stay tuned.")
+	answer := strings.TrimSpace(ctx.Args["answer"])
+
+	cc := ctx.Player.Creation()
+	if cc == nil {
+		if a := ctx.Player.Account(); a != nil && len(a.Characters()) >= MaxCharactersPerAccount {
+			ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't create a character: %s.", ErrTooManyCharacters), ColorError)
+			return
+		}
+
+		if sanctionBanned(ctx.Player, nil) {
+			return
+		}
+
+		if err := Armeria.creationManager.CheckRateLimit(ctx.Player.IP()); err != nil {
+			ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't create a character right now: %s.", err), ColorError)
+			return
+		}
+
+		cc = &CharacterCreation{}
+		ctx.Player.SetCreation(cc)
+		ctx.Player.client.ShowText(
+			fmt.Sprintf(
+				"Let's create your character! You can stop at any time with %s.",
+				TextStyle("/create cancel", WithLinkCmd("/create cancel")),
+			),
+		)
+	}
+
+	if strings.ToLower(answer) == "cancel" {
+		Armeria.creationManager.Release(ctx.Player)
+		ctx.Player.SetCreation(nil)
+		ctx.Player.client.ShowText("You stopped creating your character.")
+		return
+	}
+
+	if len(answer) == 0 {
+		ctx.Player.client.ShowText(CreationPrompt(cc))
+		return
+	}
+
+	done, err := AnswerCreationStep(ctx.Player, cc, answer)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("Sorry, %s.", err), ColorError)
+		ctx.Player.client.ShowText(CreationPrompt(cc))
+		return
+	} else if !done {
+		ctx.Player.client.ShowText(CreationPrompt(cc))
+		return
+	}
+
+	c, err := Armeria.creationManager.Finish(ctx.Player, cc)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("Your character couldn't be created: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.SetCreation(nil)
+
+	if c.Pending() {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"%s has been created, and is waiting to be approved. You'll be able to %s once it is.",
+				c.FormattedName(),
+				TextStyle("/login", WithBold()),
+			),
+			ColorSuccess,
+		)
+
+		for _, char := range Armeria.characterManager.OnlineCharacters() {
+			if char.HasPermission("CAN_CHAREDIT") {
+				char.Player().client.ShowText(
+					fmt.Sprintf(
+						"A new character, %s, is waiting to be %s.",
+						c.FormattedName(),
+						TextStyle("approved", WithLinkCmd("/character pending")),
+					),
+				)
+			}
+		}
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s has been created!", c.FormattedName()), ColorSuccess)
+	enterGame(ctx.Player, c)
 }
 
 func handleLookCommand(ctx *CommandContext) {
@@ -107,6 +380,18 @@ func handleLookCommand(ctx *CommandContext) {
 			lookResult = "There is nothing special about it."
 		}
 
+		if result.Type == RegistryTypeItemInstance && result.Object.(*ItemInstance).IsContainer() {
+			var contents []string
+			for _, ci := range result.Object.(*ItemInstance).Contents().Items() {
+				contents = append(contents, ci.FormattedQuantity(ci.Quantity()))
+			}
+			if len(contents) > 0 {
+				lookResult += fmt.Sprintf("\nInside it is %s.", strings.Join(contents, ", "))
+			} else {
+				lookResult += "\nIt is empty."
+			}
+		}
+
 		ctx.Player.client.ShowText(
 			fmt.Sprintf("You take a look at %s.\n%s", TextStyle(result.Object.FormattedName(), WithBold()), lookResult),
 		)
@@ -291,6 +576,10 @@ func handleSayCommand(ctx *CommandContext) {
 
 	room := ctx.Character.Room()
 	for _, c := range room.Here().Characters(true, ctx.Character) {
+		if c.Ignores(ctx.Character) {
+			continue
+		}
+
 		c.Player().client.ShowText(
 			c.Player().Character().Colorize(
 				fmt.Sprintf("%s %s, \"%s\"", ctx.Character.FormattedName(), verbs[1], normalizedText),
@@ -348,6 +637,8 @@ func handleMoveCommand(ctx *CommandContext) {
 		"",
 	)
 
+	ctx.Character.WearEquipment()
+
 	if newRoom.ParentArea.ID() != oldAreaUUID {
 		ctx.Player.client.ShowColorizedText(
 			fmt.Sprintf("You've just entered %s.", TextStyle(newRoom.ParentArea.Name(), WithBold())),
@@ -427,6 +718,7 @@ func handleRoomSetCommand(ctx *CommandContext) {
 	}
 
 	if tr != nil {
+		ctx.Character.Journal().Record(NewAttributeJournalEntry(tr, attr, ctx.Args["value"]))
 		tr.SetAttribute(attr, ctx.Args["value"])
 	} else {
 		ctx.Player.client.ShowColorizedText("The specified room does not exist.", ColorError)
@@ -481,14 +773,20 @@ func handleRoomMoveCommand(ctx *CommandContext) {
 	}
 
 	// Move the room.
+	entries := []*JournalEntry{NewRoomMoveJournalEntry(rm, newCoords)}
 	rm.Coords.SetFrom(newCoords)
 
 	// Link the rooms (if applicable).
 	oppositeRm := rm.ConnectedRoom(oppositeDir)
 	if oppositeRm != nil {
+		entries = append(entries,
+			NewAttributeJournalEntry(oppositeRm, dir, rm.Coords.String()),
+			NewAttributeJournalEntry(rm, oppositeDir, oppositeRm.Coords.String()),
+		)
 		oppositeRm.SetAttribute(dir, rm.Coords.String())
 		rm.SetAttribute(oppositeDir, oppositeRm.Coords.String())
 	}
+	ctx.Character.Journal().Record(NewCompositeJournalEntry(entries[0].Description, entries))
 
 	// Sync the minimap for anyone in the area.
 	for _, char := range rm.ParentArea.Characters() {
@@ -522,6 +820,7 @@ func handleRoomCreateCommand(ctx *CommandContext) {
 	}
 
 	rm := Armeria.worldManager.CreateRoom(ctx.Character.Room().ParentArea, c)
+	ctx.Character.Journal().Record(NewRoomCreateJournalEntry(rm))
 
 	// Match room colors.
 	rm.SetAttribute(AttributeColor, ctx.Character.Room().Attribute(AttributeColor))
@@ -559,6 +858,7 @@ func handleRoomDestroyCommand(ctx *CommandContext) {
 		return
 	}
 
+	ctx.Character.Journal().Record(NewRoomDestroyJournalEntry(r))
 	r.ParentArea.RemoveRoom(r)
 
 	for _, c := range ctx.Character.Room().ParentArea.Characters() {
@@ -599,8 +899,6 @@ func handleWhisperCommand(ctx *CommandContext) {
 		return
 	}
 
-	c.SetTempAttribute(TempAttributeReplyTo, ctx.Character.Name())
-
 	normalizedText, _ := TextPunctuation(m)
 
 	ctx.Player.client.ShowColorizedText(
@@ -608,6 +906,19 @@ func handleWhisperCommand(ctx *CommandContext) {
 		ColorWhisper,
 	)
 
+	// the whisper looks sent either way, so the sender can't tell they are being ignored
+	if c.Ignores(ctx.Character) {
+		return
+	}
+
+	c.SetTempAttribute(TempAttributeReplyTo, ctx.Character.Name())
+
+	Armeria.chatHistoryManager.RecordWhisper(
+		ctx.Character,
+		c,
+		fmt.Sprintf("%s whispers to %s, \"%s\"", ctx.Character.FormattedName(), c.FormattedName(), normalizedText),
+	)
+
 	c.Player().client.ShowColorizedText(
 		fmt.Sprintf("%s whispers to you from %s, \"%s\"",
 			ctx.Character.FormattedNameWithTitle(),
@@ -630,6 +941,95 @@ func handleReplyCommand(ctx *CommandContext) {
 	Armeria.commandManager.ProcessCommand(ctx.Player, fmt.Sprintf("whisper %s %s", rt, m), false)
 }
 
+func handleWhispersCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That's not a valid character name.", ColorError)
+		return
+	}
+
+	n, ok := historyLines(ctx, MaxWhisperHistory)
+	if !ok {
+		return
+	}
+
+	messages := Armeria.chatHistoryManager.Whispers(ctx.Character, c, n)
+	if len(messages) == 0 {
+		ctx.Player.client.ShowText(fmt.Sprintf("You haven't whispered with %s recently.", c.FormattedName()))
+		return
+	}
+
+	for _, msg := range messages {
+		ctx.Player.client.ShowColorizedText(msg.String(), ColorWhisper)
+	}
+}
+
+func handleIgnoreCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That's not a valid character name.", ColorError)
+		return
+	} else if c.ID() == ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("You can't ignore yourself.", ColorError)
+		return
+	}
+
+	if ctx.Command.Name == "remove" {
+		if !ctx.Character.Ignores(c) {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("You aren't ignoring %s.", c.FormattedName()),
+				ColorError,
+			)
+			return
+		}
+
+		ctx.Character.Unignore(c)
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You are no longer ignoring %s.", c.FormattedName()),
+			ColorSuccess,
+		)
+		return
+	}
+
+	if ctx.Character.Ignores(c) {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You are already ignoring %s.", c.FormattedName()),
+			ColorError,
+		)
+		return
+	} else if c.HasPermission("CAN_SYSOP") {
+		ctx.Player.client.ShowColorizedText("You can't ignore staff members.", ColorError)
+		return
+	} else if len(ctx.Character.Ignored()) >= MaxIgnoredCharacters {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You can't ignore more than %d characters.", MaxIgnoredCharacters),
+			ColorError,
+		)
+		return
+	}
+
+	ctx.Character.Ignore(c)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You are now ignoring %s.", c.FormattedName()),
+		ColorSuccess,
+	)
+}
+
+func handleIgnoreListCommand(ctx *CommandContext) {
+	ignored := ctx.Character.Ignored()
+	if len(ignored) == 0 {
+		ctx.Player.client.ShowText("You aren't ignoring anyone.")
+		return
+	}
+
+	var names []string
+	for _, c := range ignored {
+		names = append(names, c.FormattedName())
+	}
+
+	ctx.Player.client.ShowText(fmt.Sprintf("You are ignoring: %s.", strings.Join(names, ", ")))
+}
+
 func handleWhoCommand(ctx *CommandContext) {
 	chars := Armeria.characterManager.OnlineCharacters()
 
@@ -640,13 +1040,18 @@ func handleWhoCommand(ctx *CommandContext) {
 	)}
 
 	for _, c := range chars {
+		org := "-"
+		if g := Armeria.guildManager.GuildOf(c); g != nil {
+			org = fmt.Sprintf(
+				"%s of %s",
+				TextStyle(g.RankName(g.Rank(c)), WithBold()),
+				g.Name(),
+			)
+		}
+
 		rows = append(rows, TableRow(
 			TableCell{content: fmt.Sprintf("[%d] %s", 0, c.FormattedNameWithTitle())},
-			TableCell{content: fmt.Sprintf(
-				"%s of %s",
-				TextStyle("CEO", WithBold()),
-				"Armeria Industries, Inc.",
-			)},
+			TableCell{content: org},
 			TableCell{content: c.Room().ParentArea.Name()},
 		))
 	}
@@ -710,39 +1115,119 @@ func handleCharacterCreateCommand(ctx *CommandContext) {
 		return
 	}
 
-	Armeria.characterManager.CreateCharacter(charName, charPass)
+	if Armeria.accountManager.AccountByUsername(charName) != nil {
+		ctx.Player.client.ShowColorizedText("An account with that name already exists.", ColorError)
+		return
+	}
+
+	c := Armeria.characterManager.CreateCharacter(charName, charPass)
+	if room, err := Armeria.creationManager.StartingRoom(); err == nil {
+		_ = room.Here().Add(c.ID())
+	}
+	Armeria.accountManager.CreateAccount(charName, charPass).AddCharacter(c)
 
 	ctx.Player.client.ShowColorizedText("The character has been created!", ColorSuccess)
 }
 
-func handleCharacterSetCommand(ctx *CommandContext) {
-	char := ctx.Args["character"]
-	attr := ctx.Args["property"]
-	val := ctx.Args["value"]
-
-	c := Armeria.characterManager.CharacterByName(char)
-	if c == nil {
-		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+func handleCharacterPendingCommand(ctx *CommandContext) {
+	pending := Armeria.characterManager.PendingCharacters()
+	if len(pending) == 0 {
+		ctx.Player.client.ShowText("There are no characters waiting to be approved.")
 		return
 	}
 
-	if !misc.Contains(AttributeList(ObjectTypeCharacter), attr) {
-		ctx.Player.client.ShowColorizedText("That's not a valid character attribute.", ColorError)
-		return
+	rows := []string{TableRow(
+		TableCell{content: "Character", header: true},
+		TableCell{content: "Gender", header: true},
+		TableCell{content: "", header: true},
+	)}
+
+	for _, c := range pending {
+		rows = append(rows, TableRow(
+			TableCell{content: c.FormattedName()},
+			TableCell{content: c.Attribute(AttributeGender)},
+			TableCell{
+				content: fmt.Sprintf(
+					"%s / %s",
+					TextStyle("approve", WithLinkCmd(fmt.Sprintf("/character approve %s", c.Name()))),
+					TextStyle("reject", WithLinkCmd(fmt.Sprintf("/character reject %s", c.Name()))),
+				),
+			},
+		))
 	}
 
-	if len(val) > 0 {
-		valid := AttributeValidate(ObjectTypeCharacter, attr, val)
-		if !valid.Result {
-			ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", valid), ColorError)
-			return
-		}
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleCharacterApproveCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil || !c.Pending() {
+		ctx.Player.client.ShowColorizedText("There is no character by that name waiting to be approved.", ColorError)
+		return
 	}
 
-	_ = c.SetAttribute(attr, val)
+	c.SetPending(false)
 
 	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf("You modified the %s property of the character %s.", TextStyle(attr, WithBold()), c.FormattedName()),
+		fmt.Sprintf("%s has been approved, and can now log in.", c.FormattedName()),
+		ColorSuccess,
+	)
+}
+
+func handleCharacterRejectCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil || !c.Pending() {
+		ctx.Player.client.ShowColorizedText("There is no character by that name waiting to be approved.", ColorError)
+		return
+	}
+
+	if a := c.Account(); a != nil {
+		a.RemoveCharacter(c)
+		if len(a.Characters()) == 0 {
+			Armeria.accountManager.RemoveAccount(a)
+		}
+	}
+	Armeria.characterManager.RemoveCharacter(c)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("%s has been rejected and deleted.", c.FormattedName()),
+		ColorSuccess,
+	)
+}
+
+func handleCharacterSetCommand(ctx *CommandContext) {
+	char := ctx.Args["character"]
+	attr := ctx.Args["property"]
+	val := ctx.Args["value"]
+
+	c := Armeria.characterManager.CharacterByName(char)
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	if !misc.Contains(AttributeList(ObjectTypeCharacter), attr) {
+		ctx.Player.client.ShowColorizedText("That's not a valid character attribute.", ColorError)
+		return
+	}
+
+	if len(val) > 0 {
+		valid := AttributeValidate(ObjectTypeCharacter, attr, val)
+		if !valid.Result {
+			ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", valid), ColorError)
+			return
+		}
+	}
+
+	e := NewAttributeJournalEntry(c, attr, val)
+	if err := journalSetAttribute(c, attr, val); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The %s could not be set: %s.", attr, err), ColorError)
+		return
+	}
+	ctx.Character.Journal().Record(e)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You modified the %s property of the character %s.", TextStyle(attr, WithBold()), c.FormattedName()),
 		ColorSuccess,
 	)
 
@@ -804,6 +1289,7 @@ func handleMobCreateCommand(ctx *CommandContext) {
 
 	m := Armeria.mobManager.CreateMob(n)
 	Armeria.mobManager.AddMob(m)
+	ctx.Character.Journal().Record(NewMobCreateJournalEntry(m))
 
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("A mob named %s has been created.", TextStyle(n, WithBold())),
@@ -825,7 +1311,12 @@ func handleMobDeleteCommand(ctx *CommandContext) {
 		return
 	}
 
-	Armeria.mobManager.RemoveMob(mob)
+	e := NewMobDeleteJournalEntry(mob)
+	if err := e.RedoFunc(); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The mob could not be removed: %s.", err), ColorError)
+		return
+	}
+	ctx.Character.Journal().Record(e)
 
 	ctx.Player.client.ShowColorizedText("The mob has been removed from the game.", ColorSuccess)
 }
@@ -881,6 +1372,7 @@ func handleMobSetCommand(ctx *CommandContext) {
 		}
 	}
 
+	ctx.Character.Journal().Record(NewAttributeJournalEntry(m, attr, val))
 	m.SetAttribute(attr, val)
 
 	ctx.Player.client.ShowColorizedText(
@@ -927,6 +1419,7 @@ func handleMobInstanceSetCommand(ctx *CommandContext) {
 		}
 	}
 
+	ctx.Character.Journal().Record(NewAttributeJournalEntry(mi, attr, val))
 	_ = mi.SetAttribute(attr, val)
 
 	ctx.Player.client.ShowColorizedText(
@@ -953,6 +1446,7 @@ func handleMobSpawnCommand(ctx *CommandContext) {
 
 	mi := m.CreateInstance()
 	_ = ctx.Character.Room().Here().Add(mi.ID())
+	ctx.Character.Journal().Record(NewInstanceSpawnJournalEntry(mi, ctx.Character.Room().Here()))
 
 	for _, c := range ctx.Character.Room().Here().Characters(true) {
 		c.Player().client.ShowText(
@@ -994,6 +1488,7 @@ func handleMobInstancesCommand(ctx *CommandContext) {
 func handleWipeCommand(ctx *CommandContext) {
 	filter := ctx.Args["filter"]
 	matches := 0
+	var entries []*JournalEntry
 
 	for _, o := range ctx.Character.Room().Here().All() {
 		obj := o.(ContainerObject)
@@ -1008,6 +1503,7 @@ func handleWipeCommand(ctx *CommandContext) {
 			m := Armeria.mobManager.MobByName(obj.Name())
 			ctx.Character.Room().Here().Remove(obj.ID())
 			if m != nil {
+				entries = append(entries, NewInstanceDestroyJournalEntry(obj, ctx.Character.Room().Here()))
 				m.DeleteInstance(obj.(*MobInstance))
 				matches = matches + 1
 			}
@@ -1015,12 +1511,20 @@ func handleWipeCommand(ctx *CommandContext) {
 			i := Armeria.itemManager.ItemByName(obj.Name())
 			ctx.Character.Room().Here().Remove(obj.ID())
 			if i != nil {
-				i.DeleteInstance(obj.(*ItemInstance))
+				entries = append(entries, NewInstanceDestroyJournalEntry(obj, ctx.Character.Room().Here()))
+				obj.(*ItemInstance).Delete()
 				matches = matches + 1
 			}
 		}
 	}
 
+	if len(entries) > 0 {
+		ctx.Character.Journal().Record(NewCompositeJournalEntry(
+			fmt.Sprintf("wiped %d things from %s", len(entries), journalObjectName(ctx.Character.Room())),
+			entries,
+		))
+	}
+
 	if len(filter) > 0 && matches == 0 {
 		ctx.Player.client.ShowColorizedText("The filter did not match anything in the room.", ColorError)
 		return
@@ -1047,6 +1551,7 @@ func handleItemCreateCommand(ctx *CommandContext) {
 
 	i := Armeria.itemManager.CreateItem(n)
 	Armeria.itemManager.AddItem(i)
+	ctx.Character.Journal().Record(NewItemCreateJournalEntry(i))
 
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("An item named %s has been created.", TextStyle(n, WithBold())),
@@ -1068,7 +1573,12 @@ func handleItemDeleteCommand(ctx *CommandContext) {
 		return
 	}
 
-	Armeria.itemManager.RemoveItem(item)
+	e := NewItemDeleteJournalEntry(item)
+	if err := e.RedoFunc(); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The item could not be removed: %s.", err), ColorError)
+		return
+	}
+	ctx.Character.Journal().Record(e)
 
 	ctx.Player.client.ShowColorizedText("The item has been removed from the game.", ColorSuccess)
 }
@@ -1124,6 +1634,7 @@ func handleItemSpawnCommand(ctx *CommandContext) {
 
 	ii := i.CreateInstance()
 	_ = ctx.Character.Room().Here().Add(ii.ID())
+	ctx.Character.Journal().Record(NewInstanceSpawnJournalEntry(ii, ctx.Character.Room().Here()))
 
 	for _, c := range ctx.Character.Room().Here().Characters(true) {
 		c.Player().client.ShowText(
@@ -1158,6 +1669,73 @@ func handleItemInstanceEditCommand(ctx *CommandContext) {
 	ctx.Player.client.ShowObjectEditor(ii.EditorData())
 }
 
+func handleUndoCommand(ctx *CommandContext) {
+	e, err := ctx.Character.Journal().Undo()
+	if err == ErrJournalEmpty {
+		ctx.Player.client.ShowColorizedText("You don't have any changes to undo.", ColorError)
+		return
+	} else if err != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The change (%s) could not be undone: %s.", e.Description, err),
+			ColorError,
+		)
+		return
+	}
+
+	syncJournalChanges(ctx)
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("Undone: %s.", e.Description), ColorSuccess)
+}
+
+func handleRedoCommand(ctx *CommandContext) {
+	e, err := ctx.Character.Journal().Redo()
+	if err == ErrJournalEmpty {
+		ctx.Player.client.ShowColorizedText("You don't have any changes to redo.", ColorError)
+		return
+	} else if err != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The change (%s) could not be redone: %s.", e.Description, err),
+			ColorError,
+		)
+		return
+	}
+
+	syncJournalChanges(ctx)
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("Redone: %s.", e.Description), ColorSuccess)
+}
+
+// syncJournalChanges refreshes everyone in the Character's area after a change was undone or redone.
+func syncJournalChanges(ctx *CommandContext) {
+	for _, c := range ctx.Character.Room().ParentArea.Characters() {
+		c.Player().client.SyncMap()
+		c.Player().client.SyncMapLocation()
+		c.Player().client.SyncRoomTitle()
+		c.Player().client.SyncRoomObjects()
+	}
+	ctx.Player.client.SyncInventory()
+}
+
+func handleJournalCommand(ctx *CommandContext) {
+	entries := ctx.Character.Journal().Entries()
+	if len(entries) == 0 {
+		ctx.Player.client.ShowText("You don't have any changes that can be undone.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Time", header: true},
+		TableCell{content: "Change", header: true},
+	)}
+
+	for _, e := range entries {
+		rows = append(rows, TableRow(
+			TableCell{content: e.Time.Format("15:04:05")},
+			TableCell{content: e.Description},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
 func handleItemSetCommand(ctx *CommandContext) {
 	item := ctx.Args["item"]
 	attr := AttributeCasing(ctx.Args["property"])
@@ -1182,6 +1760,7 @@ func handleItemSetCommand(ctx *CommandContext) {
 		}
 	}
 
+	ctx.Character.Journal().Record(NewAttributeJournalEntry(i, attr, val))
 	i.SetAttribute(attr, val)
 
 	ctx.Player.client.ShowColorizedText(
@@ -1232,6 +1811,7 @@ func handleItemInstanceSetCommand(ctx *CommandContext) {
 		}
 	}
 
+	ctx.Character.Journal().Record(NewAttributeJournalEntry(ii, attr, val))
 	_ = ii.SetAttribute(attr, val)
 
 	ctx.Player.client.ShowColorizedText(
@@ -1307,6 +1887,26 @@ func handleItemInstancesCommand(ctx *CommandContext) {
 					content: fmt.Sprintf("Mob: %s (%s)", ii.MobInstance().FormattedName(), ii.MobInstance().ID()),
 				},
 			))
+		} else if ctr.ParentType() == ContainerParentTypeItemInstance {
+			rows = append(rows, TableRow(
+				TableCell{content: ii.FormattedName()},
+				TableCell{content: ii.ID()},
+				TableCell{
+					content: fmt.Sprintf("Container: %s (%s)", ctr.ParentItemInstance().FormattedName(), ctr.ParentItemInstance().ID()),
+				},
+			))
+		} else if ctr.ParentType() == ContainerParentTypeEscrow {
+			loc := "Escrow"
+			if a := ctr.ParentAuction(); a != nil {
+				loc = fmt.Sprintf("Escrow: auction #%d", a.ID)
+			} else if m := ctr.ParentMail(); m != nil {
+				loc = fmt.Sprintf("Escrow: mail from %s", m.From())
+			}
+			rows = append(rows, TableRow(
+				TableCell{content: ii.FormattedName()},
+				TableCell{content: ii.ID()},
+				TableCell{content: loc},
+			))
 		}
 	}
 
@@ -1332,6 +1932,7 @@ func handleAreaCreateCommand(ctx *CommandContext) {
 	}
 
 	a := Armeria.worldManager.CreateArea(n)
+	ctx.Character.Journal().Record(NewAreaCreateJournalEntry(a))
 
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("An area named %s has been created!", TextStyle(a.Name(), WithBold())),
@@ -1383,10 +1984,111 @@ func handleAreaEditCommand(ctx *CommandContext) {
 	ctx.Player.client.ShowObjectEditor(a.EditorData())
 }
 
+func handleAreaExportCommand(ctx *CommandContext) {
+	area := ctx.Args["area"]
+	var a *Area
+	if len(area) == 0 {
+		a = ctx.Character.Room().ParentArea
+	} else {
+		a = Armeria.worldManager.AreaByName(area)
+		if a == nil {
+			ctx.Player.client.ShowColorizedText("That area doesn't exist.", ColorError)
+			return
+		}
+	}
+
+	b, err := ExportArea(a)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The area could not be exported: %s.", err), ColorError)
+		return
+	}
+
+	f := AreaBundleFile(a.Name())
+	if err := WriteAreaBundle(b, f); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The bundle could not be written: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"%s has been exported to %s (%d rooms, %d items, %d mobs, %d ledgers).",
+			TextStyle(a.Name(), WithBold()),
+			TextStyle(filepath.Base(f), WithBold()),
+			len(b.Area.UnsafeRooms),
+			len(b.Items),
+			len(b.Mobs),
+			len(b.Ledgers),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleAreaImportCommand(ctx *CommandContext) {
+	file := filepath.Base(ctx.Args["file"])
+	name := ctx.Args["name"]
+
+	if !strings.HasSuffix(strings.ToLower(file), ".json") {
+		file = file + ".json"
+	}
+
+	b, err := ReadAreaBundle(fmt.Sprintf("%s/bundles/%s", Armeria.dataPath, file))
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The bundle could not be read: %s.", err), ColorError)
+		return
+	}
+
+	if len(name) == 0 {
+		name = b.Area.UnsafeName
+	}
+
+	if conflicts := b.Conflicts(name); len(conflicts) > 0 {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The bundle cannot be imported:\n- %s", strings.Join(conflicts, "\n- ")),
+			ColorError,
+		)
+		return
+	}
+
+	a, err := ImportArea(b, name)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The bundle could not be imported: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"%s has been imported with %d rooms.",
+			TextStyle(a.Name(), WithBold()),
+			len(a.Rooms()),
+		),
+		ColorSuccess,
+	)
+}
+
 func handlePasswordCommand(ctx *CommandContext) {
 	pw := ctx.Args["password"]
 	ctx.Character.SetPassword(pw)
-	ctx.Player.client.ShowColorizedText("Your character password has been set.", ColorSuccess)
+
+	chars := []*Character{ctx.Character}
+	if a := ctx.Character.Account(); a != nil {
+		a.SetPassword(pw)
+		chars = a.Characters()
+	}
+	ctx.Player.client.ShowColorizedText("Your account password has been set.", ColorSuccess)
+
+	revoked := 0
+	for _, c := range chars {
+		revoked += c.RevokeAllSessions()
+	}
+	if revoked > 0 {
+		ctx.Player.client.ShowText(
+			fmt.Sprintf("%d auto-login session(s) were revoked, so those devices will need your new password.", revoked),
+		)
+	}
+	if len(ctx.Player.SessionID()) > 0 {
+		ctx.Player.SetSessionID("")
+		ctx.Player.client.ToggleAutologin("")
+	}
 }
 
 func handleTeleportCommand(ctx *CommandContext) {
@@ -1557,10 +2259,13 @@ func handleClipboardPasteCommand(ctx *CommandContext) {
 			return
 		}
 		// paste room attributes
+		var entries []*JournalEntry
 		for _, attr := range cba {
 			attrValue := ctx.Character.TempAttribute("clipboard_attribute_" + attr)
+			entries = append(entries, NewAttributeJournalEntry(r, attr, attrValue))
 			r.SetAttribute(attr, attrValue)
 		}
+		ctx.Character.Journal().Record(NewCompositeJournalEntry("pasted clipboard onto "+journalObjectName(r), entries))
 		ctx.Player.client.ShowColorizedText("Room attributes on the clipboard have been applied.", ColorSuccess)
 		ctx.Player.client.SyncMap()
 		ctx.Player.client.SyncRoomTitle()
@@ -1582,845 +2287,4323 @@ func handleClipboardClearCommand(ctx *CommandContext) {
 	ctx.Player.client.ShowColorizedText("Your clipboard has been cleared.", ColorSuccess)
 }
 
-func handleGetCommand(ctx *CommandContext) {
-	searchString := ctx.Args["item"]
+func handleSelectionBoxCommand(ctx *CommandContext) {
+	a := ctx.Character.Room().ParentArea
+	if len(ctx.Args["area"]) > 0 {
+		a = Armeria.worldManager.AreaByName(ctx.Args["area"])
+		if a == nil {
+			ctx.Player.client.ShowColorizedText("That area doesn't exist.", ColorError)
+			return
+		}
+	}
 
-	roomObjects := ctx.Character.Room().Here()
-	result := roomObjects.GetLoose(searchString)
-	if result.Type == RegistryTypeUnknown {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
-		return
-	} else if result.Type != RegistryTypeItemInstance {
-		ctx.Player.client.ShowColorizedText("You cannot pick that up.", ColorError)
+	from, ferr := ParseCoords(ctx.Args["from"])
+	to, terr := ParseCoords(ctx.Args["to"])
+	if ferr != nil || terr != nil {
+		ctx.Player.client.ShowColorizedText("Incorrect format for the coordinates. Use [x],[y],[z].", ColorError)
 		return
 	}
 
-	item := result.Object.(*ItemInstance)
+	selectRooms(ctx, a, a.RoomsWithin(from, to))
+}
 
-	if item.Attribute(AttributeHoldable) == "false" {
-		ctx.Player.client.ShowColorizedText("You are not able to pick that up.", ColorError)
+func handleSelectionMatchCommand(ctx *CommandContext) {
+	attr := AttributeCasing(ctx.Args["property"])
+	if !misc.Contains(AttributeList(ObjectTypeRoom), attr) {
+		ctx.Player.client.ShowColorizedText("That's not a valid room attribute.", ColorError)
 		return
 	}
 
-	err := ctx.Character.Inventory().Add(item.ID())
-	if err == ErrContainerNoRoom {
-		ctx.Player.client.ShowColorizedText("You have no room in your inventory.", ColorError)
-		return
-	} else if err == ErrContainerDuplicate {
-		ctx.Player.client.ShowColorizedText("You already have that item instance in your inventory.", ColorError)
+	a := ctx.Character.Room().ParentArea
+	selectRooms(ctx, a, a.RoomsWithAttribute(attr, ctx.Args["value"]))
+}
+
+// selectRooms replaces the Character's room selection and shows the rooms that were selected.
+func selectRooms(ctx *CommandContext, a *Area, rooms []*Room) {
+	if len(rooms) == 0 {
+		ctx.Player.client.ShowColorizedText("There are no rooms matching your selection.", ColorError)
 		return
 	}
-	roomObjects.Remove(item.ID())
 
-	ctx.Player.client.SyncRoomObjects()
-	ctx.Player.client.SyncInventory()
-	ctx.Player.client.PlaySFX(sfx.PickupItem)
+	rs := NewRoomSelection(a, rooms)
+	ctx.Character.SetRoomSelection(rs)
+
 	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf("You picked up a %s.", item.FormattedName()),
+		fmt.Sprintf("You selected %d rooms in %s.\n%s", len(rooms), TextStyle(a.Name(), WithBold()), rs.PreviewTable(10)),
 		ColorSuccess,
 	)
-
-	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
-		c.Player().client.SyncRoomObjects()
-		c.Player().client.ShowText(
-			fmt.Sprintf("%s picked up a %s.", ctx.Character.FormattedName(), item.FormattedName()),
-		)
-	}
 }
 
-func handleDropCommand(ctx *CommandContext) {
-	searchString := ctx.Args["item"]
+// selectedRooms returns the Character's room selection and the selected rooms that still exist. An error is shown
+// to the player if there is nothing selected.
+func selectedRooms(ctx *CommandContext) (*RoomSelection, []*Room) {
+	rs := ctx.Character.RoomSelection()
+	if rs == nil {
+		ctx.Player.client.ShowColorizedText("You don't have any rooms selected.", ColorError)
+		return nil, nil
+	}
 
-	result := ctx.Character.Inventory().GetLoose(searchString)
-	if result.Type == RegistryTypeUnknown {
-		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
-		return
+	rooms := rs.ValidRooms()
+	if len(rooms) == 0 {
+		ctx.Player.client.ShowColorizedText("None of the rooms you selected exist anymore.", ColorError)
+		return nil, nil
 	}
 
-	item := result.Object.(*ItemInstance)
+	return rs, rooms
+}
 
-	ctx.Character.Inventory().Remove(item.ID())
-	_ = ctx.Character.Room().Here().Add(item.ID())
+// previewBulkRoomOperation stores a pending change to the selected rooms and asks the player to confirm it.
+func previewBulkRoomOperation(ctx *CommandContext, rs *RoomSelection, op *BulkRoomOperation) {
+	rs.Pending = op
 
-	ctx.Player.client.SyncRoomObjects()
-	ctx.Player.client.SyncInventory()
-	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf("You dropped a %s.", item.FormattedName()),
-		ColorSuccess,
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"You are about to %s (%d rooms):\n%s\nUse %s to apply this change or %s to discard it.",
+			op.Description,
+			len(rs.ValidRooms()),
+			rs.PreviewTable(10),
+			TextStyle("/selection confirm", WithLinkCmd("/selection confirm")),
+			TextStyle("/selection cancel", WithLinkCmd("/selection cancel")),
+		),
 	)
+}
 
-	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
-		c.Player().client.SyncRoomObjects()
-		c.Player().client.ShowText(
-			fmt.Sprintf("%s dropped a %s.", ctx.Character.FormattedName(), item.FormattedName()),
-		)
+// syncBulkRoomChanges refreshes the minimap for everyone within an area.
+func syncBulkRoomChanges(a *Area) {
+	for _, c := range a.Characters() {
+		c.Player().client.SyncMap()
+		c.Player().client.SyncMapLocation()
+		c.Player().client.SyncRoomTitle()
 	}
 }
 
-func handleSwapCommand(ctx *CommandContext) {
-	source := ctx.Args["source"]
-	destination := ctx.Args["destination"]
-
-	snum, err := strconv.Atoi(source)
-	if err != nil {
-		ctx.Player.client.ShowColorizedText("You must enter a valid slot number as the source slot.", ColorError)
+func handleSelectionShowCommand(ctx *CommandContext) {
+	rs, rooms := selectedRooms(ctx)
+	if rs == nil {
 		return
 	}
 
-	dnum, err := strconv.Atoi(destination)
-	if err != nil {
-		ctx.Player.client.ShowColorizedText("You must enter a valid slot number as the destination slot.", ColorError)
+	ctx.Player.client.ShowText(
+		fmt.Sprintf("You have %d rooms selected in %s.\n%s", len(rooms), TextStyle(rs.Area.Name(), WithBold()), rs.PreviewTable(50)),
+	)
+}
+
+func handleSelectionClearCommand(ctx *CommandContext) {
+	ctx.Character.SetRoomSelection(nil)
+	ctx.Player.client.ShowColorizedText("Your room selection has been cleared.", ColorSuccess)
+}
+
+func handleSelectionSetCommand(ctx *CommandContext) {
+	rs, _ := selectedRooms(ctx)
+	if rs == nil {
 		return
 	}
 
-	if snum >= ctx.Character.Inventory().MaxSize() {
-		ctx.Player.client.ShowColorizedText("Source slot does not exist in your inventory.", ColorError)
+	attr := AttributeCasing(ctx.Args["property"])
+	if !misc.Contains(AttributeList(ObjectTypeRoom), attr) {
+		ctx.Player.client.ShowColorizedText("That's not a valid room attribute.", ColorError)
 		return
 	}
 
-	if dnum >= ctx.Character.Inventory().MaxSize() {
-		ctx.Player.client.ShowColorizedText("Destination slot does not exist in your inventory.", ColorError)
-		return
+	val := ctx.Args["value"]
+	if len(val) > 0 {
+		valid := AttributeValidate(ObjectTypeRoom, attr, val)
+		if !valid.Result {
+			ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", valid), ColorError)
+			return
+		}
 	}
 
-	sourceSlot := ctx.Character.Inventory().AtSlot(snum)
-	destSlot := ctx.Character.Inventory().AtSlot(dnum)
-	if sourceSlot.Type != RegistryTypeUnknown {
-		ctx.Character.Inventory().SetSlot(sourceSlot.Object.(*ItemInstance).ID(), dnum)
-	}
-	if destSlot.Type != RegistryTypeUnknown {
-		ctx.Character.Inventory().SetSlot(destSlot.Object.(*ItemInstance).ID(), snum)
+	desc := fmt.Sprintf("set the %s property to %s", TextStyle(attr, WithBold()), TextStyle(val, WithBold()))
+	if len(val) == 0 {
+		desc = fmt.Sprintf("revert the %s property to default", TextStyle(attr, WithBold()))
 	}
 
-	ctx.Player.client.SyncInventory()
+	previewBulkRoomOperation(ctx, rs, &BulkRoomOperation{
+		Description: desc,
+		Apply: func() (int, error) {
+			rooms := rs.ValidRooms()
+			var entries []*JournalEntry
+			for _, r := range rooms {
+				entries = append(entries, NewAttributeJournalEntry(r, attr, val))
+				r.SetAttribute(attr, val)
+			}
+			ctx.Character.Journal().Record(NewCompositeJournalEntry(
+				fmt.Sprintf("set %s of %d selected rooms to '%s'", attr, len(rooms), val),
+				entries,
+			))
+			return len(rooms), nil
+		},
+	})
 }
 
-func handleAutoLoginCommand(ctx *CommandContext) {
-	ctx.Player.client.ToggleAutologin()
-}
+func handleSelectionPasteCommand(ctx *CommandContext) {
+	rs, _ := selectedRooms(ctx)
+	if rs == nil {
+		return
+	}
 
-func handleChannelListCommand(ctx *CommandContext) {
-	rows := []string{TableRow(
-		TableCell{content: "Channel", header: true},
-		TableCell{content: "Description", header: true},
-		TableCell{content: "Joined", header: true},
-	)}
+	if ctx.Character.TempAttribute("clipboard_type") != "room" {
+		ctx.Player.client.ShowColorizedText("You don't have any room attributes on your clipboard.", ColorError)
+		return
+	}
 
-	for _, c := range Armeria.channels {
-		if c.HasPermission(ctx.Character) {
-			if ctx.Character.InChannel(c) {
-				rows = append(rows, TableRow(
-					TableCell{content: TextStyle(c.Name, WithBold())},
-					TableCell{content: c.Description},
-					TableCell{content: TextStyle("Yes", WithUserColor(ctx.Character, ColorSuccess))},
-				))
-			} else {
-				rows = append(rows, TableRow(
-					TableCell{content: TextStyle(c.Name, WithBold())},
-					TableCell{content: c.Description},
-					TableCell{content: TextStyle("No", WithUserColor(ctx.Character, ColorError))},
-				))
-			}
-		}
+	values := make(map[string]string)
+	for _, attr := range strings.Split(ctx.Character.TempAttribute("clipboard_attributes"), " ") {
+		values[attr] = ctx.Character.TempAttribute("clipboard_attribute_" + attr)
 	}
 
-	ctx.Player.client.ShowText(TextTable(rows...))
+	previewBulkRoomOperation(ctx, rs, &BulkRoomOperation{
+		Description: "paste the room attributes on your clipboard",
+		Apply: func() (int, error) {
+			rooms := rs.ValidRooms()
+			var entries []*JournalEntry
+			for _, r := range rooms {
+				for attr, val := range values {
+					entries = append(entries, NewAttributeJournalEntry(r, attr, val))
+					r.SetAttribute(attr, val)
+				}
+			}
+			ctx.Character.Journal().Record(NewCompositeJournalEntry(
+				fmt.Sprintf("pasted clipboard onto %d selected rooms", len(rooms)),
+				entries,
+			))
+			return len(rooms), nil
+		},
+	})
 }
 
-func handleChannelJoinCommand(ctx *CommandContext) {
-	channelName := ctx.Args["channel"]
-
-	ch := ChannelByName(channelName)
-	if ch == nil {
-		ctx.Player.client.ShowColorizedText("You must enter a valid channel name to join.", ColorError)
+func handleSelectionDestroyCommand(ctx *CommandContext) {
+	rs, rooms := selectedRooms(ctx)
+	if rs == nil {
 		return
 	}
 
-	if ctx.Character.InChannel(ch) {
-		ctx.Player.client.ShowColorizedText("You are already in that channel!", ColorError)
-		return
+	for _, r := range rooms {
+		if r.Here().Count() > 0 {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("There is something in one of the rooms you're attempting to destroy (%s).", r.LocationString()),
+				ColorError,
+			)
+			return
+		}
 	}
 
-	ctx.Character.JoinChannel(ch)
-	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf("You joined the %s channel. You can use %s to communicate.",
-			TextStyle(ch.Name, WithBold()),
-			TextStyle(ch.SlashCommand, WithBold()),
-		),
-		ColorSuccess,
-	)
+	previewBulkRoomOperation(ctx, rs, &BulkRoomOperation{
+		Description: TextStyle("destroy the selected rooms", WithBold()),
+		Apply: func() (int, error) {
+			var entries []*JournalEntry
+			for _, r := range rs.ValidRooms() {
+				// Something may have entered the room since the preview.
+				if r.Here().Count() == 0 {
+					entries = append(entries, NewRoomDestroyJournalEntry(r))
+					r.ParentArea.RemoveRoom(r)
+				}
+			}
+			ctx.Character.Journal().Record(NewCompositeJournalEntry(
+				fmt.Sprintf("destroyed %d selected rooms", len(entries)),
+				entries,
+			))
+			return len(entries), nil
+		},
+	})
 }
 
-func handleChannelLeaveCommand(ctx *CommandContext) {
-	channelName := ctx.Args["channel"]
-
-	ch := ChannelByName(channelName)
-	if ch == nil {
-		ctx.Player.client.ShowColorizedText("You must enter a valid channel name to leave.", ColorError)
+func handleSelectionShiftCommand(ctx *CommandContext) {
+	rs, _ := selectedRooms(ctx)
+	if rs == nil {
 		return
 	}
 
-	if !ctx.Character.InChannel(ch) {
-		ctx.Player.client.ShowColorizedText("You are not in that channel.", ColorError)
+	offset, err := ParseCoords(ctx.Args["offset"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("Incorrect format for the offset. Use [x],[y],[z].", ColorError)
 		return
 	}
 
-	ctx.Character.LeaveChannel(ch)
-	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf("You left the %s channel.",
-			TextStyle(ch.Name, WithBold()),
-		),
-		ColorSuccess,
-	)
+	previewBulkRoomOperation(ctx, rs, &BulkRoomOperation{
+		Description: fmt.Sprintf("shift the selected rooms by %s", TextStyle(offset.String(), WithBold())),
+		Apply: func() (int, error) {
+			rooms := rs.ValidRooms()
+			if err := ShiftRooms(rs.Area, rooms, offset); err != nil {
+				return 0, err
+			}
+			ctx.Character.Journal().Record(NewShiftJournalEntry(rs.Area, rooms, offset))
+			return len(rooms), nil
+		},
+	})
 }
 
-func handleChannelSayCommand(ctx *CommandContext) {
-	channelName := ctx.Args["channel"]
-	sayText := ctx.Args["text"]
+func handleSelectionConfirmCommand(ctx *CommandContext) {
+	rs := ctx.Character.RoomSelection()
+	if rs == nil || rs.Pending == nil {
+		ctx.Player.client.ShowColorizedText("You don't have a change waiting to be applied.", ColorError)
+		return
+	}
 
-	ch := ChannelByName(channelName)
-	if ch == nil {
-		ctx.Player.client.ShowColorizedText("You must enter a valid channel name to talk to.", ColorError)
+	op := rs.Pending
+	rs.Pending = nil
+	if len(rs.ValidRooms()) == 0 {
+		ctx.Player.client.ShowColorizedText("None of the rooms you selected exist anymore.", ColorError)
 		return
 	}
 
-	if !ctx.Character.InChannel(ch) {
-		ctx.Player.client.ShowColorizedText("You are not in that channel.", ColorError)
+	changed, err := op.Apply()
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The change could not be applied: %s.", err), ColorError)
 		return
 	}
 
-	ch.Broadcast(ctx.Character, sayText)
-}
+	syncBulkRoomChanges(rs.Area)
 
-func handleChannelShorthandSayCommand(ctx *CommandContext) {
-	Armeria.commandManager.ProcessCommand(
-		ctx.Player,
-		fmt.Sprintf("channel say %s %s", ctx.Command.Name, ctx.Args["message"]),
-		false,
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The change has been applied to %d rooms.", changed),
+		ColorSuccess,
 	)
 }
 
-func handleSettingsCommand(ctx *CommandContext) {
-	setting := strings.ToLower(ctx.Args["name"])
-	value := ctx.Args["value"]
+func handleSelectionCancelCommand(ctx *CommandContext) {
+	rs := ctx.Character.RoomSelection()
+	if rs == nil || rs.Pending == nil {
+		ctx.Player.client.ShowColorizedText("You don't have a change waiting to be applied.", ColorError)
+		return
+	}
 
-	if len(setting) == 0 {
-		rows := []string{TableRow(
-			TableCell{content: "Name", header: true},
-			TableCell{content: "Description", header: true},
-			TableCell{content: "Current", header: true},
-			TableCell{content: "Default", header: true},
-		)}
+	rs.Pending = nil
+	ctx.Player.client.ShowColorizedText("The change has been discarded.", ColorSuccess)
+}
 
-		valid := ValidSettings()
+func handleGetCommand(ctx *CommandContext) {
+	if idx := strings.LastIndex(ctx.Args["item"], " from "); idx > 0 {
+		handleGetFromContainer(ctx, ctx.Args["item"][:idx], ctx.Args["item"][idx+6:])
+		return
+	}
 
-		for _, s := range valid {
-			reqPerm := SettingPermission(s)
-			if len(reqPerm) > 0 && !ctx.Character.HasPermission(reqPerm) {
-				continue
-			}
+	searchString, qty := parseItemQuantity(ctx.Args["item"])
 
-			rows = append(rows, TableRow(
-				TableCell{content: s},
-				TableCell{content: SettingDesc(s), styling: "padding:0px 2px"},
-				TableCell{content: ctx.Character.Setting(s), styling: "padding:0px 2px"},
-				TableCell{content: SettingDefault(s), styling: "padding:0px 2px;color:#666"},
-			))
-		}
-		ctx.Player.client.ShowText(TextTable(rows...))
+	roomObjects := ctx.Character.Room().Here()
+	result := roomObjects.GetLoose(searchString)
+	if result.Type == RegistryTypeUnknown {
+		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
 		return
-	} else if !misc.Contains(ValidSettings(), setting) {
-		ctx.Player.client.ShowColorizedText(
-			fmt.Sprintf(
-				"%s is not a valid setting name.",
-				TextStyle(setting, WithBold()),
-			),
-			ColorError,
-		)
+	} else if result.Type != RegistryTypeItemInstance {
+		ctx.Player.client.ShowColorizedText("You cannot pick that up.", ColorError)
 		return
 	}
 
-	// Check if the character has permission to modify the setting.
-	reqPerm := SettingPermission(setting)
-	if len(reqPerm) > 0 && !ctx.Character.HasPermission(reqPerm) {
-		ctx.Player.client.ShowColorizedText(
-			fmt.Sprintf(
-				"%s is not a valid setting name.",
-				TextStyle(setting, WithBold()),
-			),
-			ColorError,
-		)
+	item := result.Object.(*ItemInstance)
+
+	if item.Attribute(AttributeHoldable) == "false" {
+		ctx.Player.client.ShowColorizedText("You are not able to pick that up.", ColorError)
 		return
 	}
 
-	// If no value is specified, either swap the bools or set the default value.
-	if len(value) == 0 {
-		if misc.IsStringBool(ctx.Character.Setting(setting)) {
-			value = misc.ToggleStringBool(ctx.Character.Setting(setting))
-		} else {
-			value = SettingDefault(setting)
-		}
-	} else {
-		// Validate the setting value.
-		valid := validate.Check(value, SettingValidationString(setting))
-		if !valid.Result {
-			ctx.Player.client.ShowColorizedText(
-				fmt.Sprintf("You cannot use that value due to: %s", valid),
-				ColorError,
-			)
-			return
-		}
+	if qty == 0 || qty > item.Quantity() {
+		qty = item.Quantity()
 	}
 
-	if err := ctx.Character.SetSetting(setting, value); err != nil {
-		ctx.Player.client.ShowColorizedText(
-			fmt.Sprintf(
-				"That setting cannot be changed: %s",
-				err,
-			),
-			ColorError,
-		)
+	stack, err := roomObjects.MoveItem(item, qty, ctx.Character.Inventory())
+	if err == ErrContainerNoRoom {
+		ctx.Player.client.ShowColorizedText("You have no room in your inventory.", ColorError)
+		return
+	} else if err == ErrContainerDuplicate {
+		ctx.Player.client.ShowColorizedText("You already have that item instance in your inventory.", ColorError)
+		return
 	}
 
-	ctx.Player.client.SyncSettings()
+	ctx.Player.client.SyncRoomObjects()
+	ctx.Player.client.SyncInventory()
+	ctx.Player.client.PlaySFX(sfx.PickupItem)
 	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf("Setting %s has been set to '%s'.", TextStyle(setting, WithBold()), value),
+		fmt.Sprintf("You picked up %s.", stack.FormattedQuantity(qty)),
 		ColorSuccess,
 	)
 
+	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+		c.Player().client.SyncRoomObjects()
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s picked up %s.", ctx.Character.FormattedName(), stack.FormattedQuantity(qty)),
+		)
+	}
 }
 
-func handleBugCommand(ctx *CommandContext) {
-	bug := ctx.Args["bug"]
+// containerFromName returns a container item, within the character's inventory or the room, showing an error if
+// there isn't one.
+func containerFromName(ctx *CommandContext, name string) *ItemInstance {
+	for _, oc := range []*ObjectContainer{ctx.Character.Inventory(), ctx.Character.Room().Here()} {
+		if result := oc.GetLoose(name); result.Type == RegistryTypeItemInstance {
+			if ii := result.Object.(*ItemInstance); ii.IsContainer() {
+				return ii
+			}
+		}
+	}
 
-	issueBody := fmt.Sprintf(
-		"This was reported in-game by **%s**.\n\n"+
-			"**Location:** %s\n\n%s",
-		ctx.Character.Name(),
-		ctx.Character.Room().LocationString(),
-		bug,
-	)
+	ctx.Player.client.ShowColorizedText("There is no container by that name here.", ColorError)
+	return nil
+}
 
-	issue, err := Armeria.github.CreateIssue(ctx.Character.Name(), issueBody, bug)
-	if err != nil {
-		Armeria.log.Error(
-			"error submitting bug to github repo",
-			zap.Error(err),
-		)
+func handleGetFromContainer(ctx *CommandContext, item string, container string) {
+	searchString, qty := parseItemQuantity(item)
+
+	ci := containerFromName(ctx, container)
+	if ci == nil {
+		return
+	}
+
+	result := ci.Contents().GetLoose(searchString)
+	if result.Type != RegistryTypeItemInstance {
 		ctx.Player.client.ShowColorizedText(
-			fmt.Sprintf(
-				"There was an error submitting the bug report to GitHub.\nAs an alternative, you can manually "+
-					"submit a bug report %s.",
-				TextStyle("here", WithLink("https://github.com/heyitsmdr/armeria/issues/new")),
-			),
+			fmt.Sprintf("There is nothing by that name inside the %s.", ci.FormattedName()),
 			ColorError,
 		)
 		return
 	}
 
-	ctx.Player.client.ShowText(
-		fmt.Sprintf("Thank you for your submission! You can view/track it %s.",
-			TextStyle("here", WithLink(issue.GetHTMLURL())),
-		),
+	ii := result.Object.(*ItemInstance)
+	if qty == 0 || qty > ii.Quantity() {
+		qty = ii.Quantity()
+	}
+
+	stack, err := ci.Contents().MoveItem(ii, qty, ctx.Character.Inventory())
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("You have no room in your inventory.", ColorError)
+		return
+	}
+
+	ctx.Player.client.SyncRoomObjects()
+	ctx.Player.client.SyncInventory()
+	ctx.Player.client.PlaySFX(sfx.PickupItem)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You took %s out of the %s.", stack.FormattedQuantity(qty), ci.FormattedName()),
+		ColorSuccess,
 	)
+
+	if ci.Room() != nil {
+		for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+			c.Player().client.ShowText(
+				fmt.Sprintf("%s took something out of the %s.", ctx.Character.FormattedName(), ci.FormattedName()),
+			)
+		}
+	}
 }
 
-func handleGiveCommand(ctx *CommandContext) {
-	target := ctx.Args["target"]
-	item := ctx.Args["item"]
+func handlePutCommand(ctx *CommandContext) {
+	idx := strings.LastIndex(ctx.Args["item"], " in ")
+	if idx <= 0 {
+		ctx.Player.client.ShowColorizedText("You must say what to put in which container (ie: apple in bag).", ColorError)
+		return
+	}
 
-	ctr := ctx.Character.Room().Here()
-	targetResult := ctr.GetByAny(target)
-	if targetResult.Type == RegistryTypeUnknown {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+	searchString, qty := parseItemQuantity(ctx.Args["item"][:idx])
+
+	ci := containerFromName(ctx, ctx.Args["item"][idx+4:])
+	if ci == nil {
 		return
 	}
 
-	itemResult := ctx.Character.Inventory().GetByAny(item)
-	if itemResult.Type != RegistryTypeItemInstance {
+	result := ctx.Character.Inventory().GetLoose(searchString)
+	if result.Type != RegistryTypeItemInstance {
 		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
 		return
 	}
 
-	if targetResult.Type == RegistryTypeItemInstance && targetResult.Object.(*ItemInstance).Attribute(AttributeType) == ItemTypeTrashCan {
-		// Destroy the item.
-		ii := itemResult.Object.(*ItemInstance)
-		ctx.Character.Inventory().Remove(ii.ID())
-		ii.Delete()
+	ii := result.Object.(*ItemInstance)
+	if qty == 0 || qty > ii.Quantity() {
+		qty = ii.Quantity()
+	}
+
+	stack, err := ci.PutItem(ii, qty, ctx.Character.Inventory())
+	if err == ErrContainerNested {
+		ctx.Player.client.ShowColorizedText("You cannot put a container inside another container.", ColorError)
+		return
+	} else if err != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("There is no room inside the %s.", ci.FormattedName()),
+			ColorError,
+		)
+		return
+	}
+
+	ctx.Player.client.SyncInventory()
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You put %s in the %s.", stack.FormattedQuantity(qty), ci.FormattedName()),
+		ColorSuccess,
+	)
+
+	if ci.Room() != nil {
+		for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+			c.Player().client.ShowText(
+				fmt.Sprintf("%s put something in the %s.", ctx.Character.FormattedName(), ci.FormattedName()),
+			)
+		}
+	}
+}
+
+func handleDropCommand(ctx *CommandContext) {
+	searchString, qty := parseItemQuantity(ctx.Args["item"])
+
+	result := ctx.Character.Inventory().GetLoose(searchString)
+	if result.Type == RegistryTypeUnknown {
+		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		return
+	}
+
+	item := result.Object.(*ItemInstance)
+
+	if qty == 0 || qty > item.Quantity() {
+		qty = item.Quantity()
+	}
+
+	stack, err := ctx.Character.Inventory().MoveItem(item, qty, ctx.Character.Room().Here())
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("You cannot drop that here.", ColorError)
+		return
+	}
+
+	ctx.Player.client.SyncRoomObjects()
+	ctx.Player.client.SyncInventory()
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You dropped %s.", stack.FormattedQuantity(qty)),
+		ColorSuccess,
+	)
+
+	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+		c.Player().client.SyncRoomObjects()
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s dropped %s.", ctx.Character.FormattedName(), stack.FormattedQuantity(qty)),
+		)
+	}
+}
+
+func handleSwapCommand(ctx *CommandContext) {
+	source := ctx.Args["source"]
+	destination := ctx.Args["destination"]
+
+	snum, err := strconv.Atoi(source)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("You must enter a valid slot number as the source slot.", ColorError)
+		return
+	}
+
+	dnum, err := strconv.Atoi(destination)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("You must enter a valid slot number as the destination slot.", ColorError)
+		return
+	}
+
+	if snum >= ctx.Character.Inventory().MaxSize() {
+		ctx.Player.client.ShowColorizedText("Source slot does not exist in your inventory.", ColorError)
+		return
+	}
+
+	if dnum >= ctx.Character.Inventory().MaxSize() {
+		ctx.Player.client.ShowColorizedText("Destination slot does not exist in your inventory.", ColorError)
+		return
+	}
+
+	sourceSlot := ctx.Character.Inventory().AtSlot(snum)
+	destSlot := ctx.Character.Inventory().AtSlot(dnum)
+
+	// Merge the source stack into the destination stack when they hold the same item
+	if sourceSlot.Type == RegistryTypeItemInstance && destSlot.Type == RegistryTypeItemInstance {
+		src := sourceSlot.Object.(*ItemInstance)
+		dst := destSlot.Object.(*ItemInstance)
+		if dst.CanStackWith(src) && dst.Quantity() < dst.MaxStack() {
+			space := dst.MaxStack() - dst.Quantity()
+			if space >= src.Quantity() {
+				dst.SetQuantity(dst.Quantity() + src.Quantity())
+				ctx.Character.Inventory().Remove(src.ID())
+				src.Delete()
+			} else {
+				dst.SetQuantity(dst.MaxStack())
+				src.SetQuantity(src.Quantity() - space)
+			}
+			ctx.Player.client.SyncInventory()
+			return
+		}
+	}
+
+	if sourceSlot.Type != RegistryTypeUnknown {
+		ctx.Character.Inventory().SetSlot(sourceSlot.Object.(*ItemInstance).ID(), dnum)
+	}
+	if destSlot.Type != RegistryTypeUnknown {
+		ctx.Character.Inventory().SetSlot(destSlot.Object.(*ItemInstance).ID(), snum)
+	}
+
+	ctx.Player.client.SyncInventory()
+}
+
+func handleAutoLoginCommand(ctx *CommandContext) {
+	// A player that logged in with a session token is turning auto-login off for their device.
+	if id := ctx.Player.SessionID(); len(id) > 0 {
+		ctx.Character.RevokeSession(id)
+		ctx.Player.SetSessionID("")
+		ctx.Player.client.ToggleAutologin("")
+		return
+	}
+
+	s, token := ctx.Character.CreateSession(ctx.Args["device"])
+	ctx.Player.SetSessionID(s.ID)
+	ctx.Player.client.ToggleAutologin(token)
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"A session for %s has been created, and expires after %d days without use. See %s.",
+			TextStyle(s.Label, WithBold()),
+			int(SessionLifetime.Hours()/24),
+			TextStyle("/sessions list", WithLinkCmd("/sessions list")),
+		),
+	)
+}
+
+// sanctionDuration parses the duration argument of a moderation command, which is either a length of time or
+// "permanent". Lets the player know, and returns false, if it isn't valid.
+func sanctionDuration(ctx *CommandContext) (time.Duration, bool) {
+	if strings.ToLower(ctx.Args["duration"]) == "permanent" {
+		return 0, true
+	}
+
+	d, err := misc.ParseDuration(ctx.Args["duration"])
+	if err != nil || d <= 0 {
+		ctx.Player.client.ShowColorizedText("The duration must be a length of time (ie: 12h or 7d), or permanent.", ColorError)
+		return 0, false
+	}
+
+	return d, true
+}
+
+// accountFromName returns an account by its username, or by the name of one of its characters.
+func accountFromName(name string) *Account {
+	if a := Armeria.accountManager.AccountByUsername(name); a != nil {
+		return a
+	}
+
+	if c := Armeria.characterManager.CharacterByName(name); c != nil {
+		return c.Account()
+	}
+
+	return nil
+}
+
+func handleAccountListCommand(ctx *CommandContext) {
+	filter := strings.ToLower(ctx.Args["filter"])
+
+	rows := []string{TableRow(
+		TableCell{content: "Account", header: true},
+		TableCell{content: "Characters", header: true},
+		TableCell{content: "Permissions", header: true},
+		TableCell{content: "Banned", header: true},
+	)}
+
+	for _, a := range Armeria.accountManager.Accounts() {
+		if len(filter) > 0 && !strings.Contains(strings.ToLower(a.Username()), filter) {
+			continue
+		}
+
+		var names []string
+		for _, c := range a.Characters() {
+			names = append(names, c.Name())
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(a.Username(), WithLinkCmd(fmt.Sprintf("/account info %s", a.Username())))},
+			TableCell{content: strings.Join(names, ", ")},
+			TableCell{content: a.Permissions()},
+			TableCell{content: misc.BoolToWords(a.Banned(), "yes", "no")},
+		))
+	}
+
+	if len(rows) == 1 {
+		ctx.Player.client.ShowText("There are no accounts matching that filter.")
+		return
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleAccountInfoCommand(ctx *CommandContext) {
+	a := accountFromName(ctx.Args["account"])
+	if a == nil {
+		ctx.Player.client.ShowColorizedText("There is no account by that name.", ColorError)
+		return
+	}
+
+	var names []string
+	for _, c := range a.Characters() {
+		names = append(names, c.FormattedName())
+	}
+
+	banned := "no"
+	if a.Banned() {
+		banned = "permanently"
+		if until := a.BannedUntil(); !until.IsZero() {
+			banned = fmt.Sprintf("until %s", until.Format("2006-01-02 15:04"))
+		}
+		if len(a.BanReason()) > 0 {
+			banned = fmt.Sprintf("%s (%s)", banned, a.BanReason())
+		}
+	}
+
+	permissions := a.Permissions()
+	if len(permissions) == 0 {
+		permissions = "none"
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"Account: %s\nCreated: %s\nCharacters: %s\nPermissions: %s\nBanned: %s",
+			TextStyle(a.Username(), WithBold()),
+			a.Created().Format("2006-01-02 15:04"),
+			strings.Join(names, ", "),
+			permissions,
+			banned,
+		),
+	)
+}
+
+func handleAccountPermissionsCommand(ctx *CommandContext) {
+	a := accountFromName(ctx.Args["account"])
+	if a == nil {
+		ctx.Player.client.ShowColorizedText("There is no account by that name.", ColorError)
+		return
+	}
+
+	a.SetPermissions(ctx.Args["permissions"])
+
+	for _, c := range a.Characters() {
+		if c.Online() {
+			c.Player().client.SyncPermissions()
+			c.Player().client.SyncCommands()
+		}
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The permissions for %s have been set.", TextStyle(a.Username(), WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleAccountBanCommand(ctx *CommandContext) {
+	a := accountFromName(ctx.Args["account"])
+	if a == nil {
+		ctx.Player.client.ShowColorizedText("There is no account by that name.", ColorError)
+		return
+	} else if a.Owns(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("You can't ban your own account.", ColorError)
+		return
+	}
+
+	d, ok := sanctionDuration(ctx)
+	if !ok {
+		return
+	}
+
+	a.Ban(ctx.Args["reason"], d)
+
+	// This includes players that are still choosing a character, not just those playing one.
+	for _, p := range Armeria.playerManager.PlayersFromAccount(a) {
+		accountBanned(p, a)
+		p.client.Disconnect()
+	}
+
+	Armeria.log.Info("account banned",
+		zap.String("account", a.Username()),
+		zap.String("by", ctx.Character.Name()),
+		zap.Duration("duration", d),
+	)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("%s has been banned.", TextStyle(a.Username(), WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleAccountUnbanCommand(ctx *CommandContext) {
+	a := accountFromName(ctx.Args["account"])
+	if a == nil {
+		ctx.Player.client.ShowColorizedText("There is no account by that name.", ColorError)
+		return
+	} else if !a.Banned() {
+		ctx.Player.client.ShowColorizedText("That account isn't banned.", ColorError)
+		return
+	}
+
+	a.Unban()
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("%s is no longer banned.", TextStyle(a.Username(), WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleUnlockCommand(ctx *CommandContext) {
+	target := ctx.Args["target"]
+	if !Armeria.loginGuard.Clear(target) {
+		ctx.Player.client.ShowColorizedText("There are no failed logins for that character or address.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Failed logins for %s have been cleared, and they can log in again.", TextStyle(target, WithBold())),
+		ColorSuccess,
+	)
+}
+
+// sanctionTarget returns the character a moderation command is being used on. Lets the player know, and returns nil,
+// if there isn't one or it's the player's own character.
+func sanctionTarget(ctx *CommandContext, name string) *Character {
+	c := Armeria.characterManager.CharacterByName(name)
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("There is no character by that name.", ColorError)
+		return nil
+	} else if c.ID() == ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("You can't do that to yourself.", ColorError)
+		return nil
+	}
+
+	return c
+}
+
+func handleMuteCommand(ctx *CommandContext) {
+	c := sanctionTarget(ctx, ctx.Args["character"])
+	if c == nil {
+		return
+	} else if c.Muted() != nil {
+		ctx.Player.client.ShowColorizedText("That character is already muted.", ColorError)
+		return
+	}
+
+	d, ok := sanctionDuration(ctx)
+	if !ok {
+		return
+	}
+
+	s := &Sanction{
+		Type:      SanctionMute,
+		Character: c.ID(),
+		Reason:    ctx.Args["reason"],
+		By:        ctx.Character.Name(),
+	}
+	Armeria.moderationManager.AddSanction(s, d)
+
+	if c.Online() {
+		c.Player().client.ShowColorizedText(s.Describe("You have been muted"), ColorError)
+	}
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s has been muted.", c.FormattedName()), ColorSuccess)
+}
+
+func handleUnmuteCommand(ctx *CommandContext) {
+	c := sanctionTarget(ctx, ctx.Args["character"])
+	if c == nil {
+		return
+	}
+
+	s := c.Muted()
+	if s == nil {
+		ctx.Player.client.ShowColorizedText("That character isn't muted.", ColorError)
+		return
+	}
+
+	Armeria.moderationManager.Lift(s)
+
+	if c.Online() {
+		c.Player().client.ShowColorizedText("You are no longer muted.", ColorSuccess)
+	}
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s is no longer muted.", c.FormattedName()), ColorSuccess)
+}
+
+func handleKickCommand(ctx *CommandContext) {
+	c := sanctionTarget(ctx, ctx.Args["character"])
+	if c == nil {
+		return
+	} else if !c.Online() {
+		ctx.Player.client.ShowColorizedText("That character is not online.", ColorError)
+		return
+	}
+
+	Armeria.moderationManager.AddSanction(&Sanction{
+		Type:      SanctionKick,
+		Character: c.ID(),
+		Reason:    ctx.Args["reason"],
+		By:        ctx.Character.Name(),
+	}, 0)
+
+	msg := "You have been kicked from the game"
+	if len(ctx.Args["reason"]) > 0 {
+		msg = fmt.Sprintf("%s: %s", msg, ctx.Args["reason"])
+	}
+	c.Player().client.ShowColorizedText(msg+".", ColorError)
+	c.Player().client.Disconnect()
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s has been kicked.", c.FormattedName()), ColorSuccess)
+}
+
+func handleBanCommand(ctx *CommandContext) {
+	target := ctx.Args["target"]
+
+	s := &Sanction{
+		Type:   SanctionBan,
+		Reason: ctx.Args["reason"],
+		By:     ctx.Character.Name(),
+	}
+
+	var players []*Player
+	if net.ParseIP(target) != nil {
+		if target == ctx.Player.IP() {
+			ctx.Player.client.ShowColorizedText("You can't ban your own address.", ColorError)
+			return
+		} else if Armeria.moderationManager.Active(SanctionBan, "", target) != nil {
+			ctx.Player.client.ShowColorizedText("That address is already banned.", ColorError)
+			return
+		}
+
+		s.IP = target
+		players = Armeria.playerManager.PlayersFromIP(target)
+	} else {
+		c := sanctionTarget(ctx, target)
+		if c == nil {
+			return
+		} else if Armeria.moderationManager.Active(SanctionBan, c.ID(), "") != nil {
+			ctx.Player.client.ShowColorizedText("That character is already banned.", ColorError)
+			return
+		}
+
+		s.Character = c.ID()
+		target = c.Name()
+		if c.Online() {
+			players = append(players, c.Player())
+		}
+	}
+
+	d, ok := sanctionDuration(ctx)
+	if !ok {
+		return
+	}
+
+	Armeria.moderationManager.AddSanction(s, d)
+
+	for _, p := range players {
+		sanctionBanned(p, p.Character())
+		p.client.Disconnect()
+	}
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s has been banned.", TextStyle(target, WithBold())), ColorSuccess)
+}
+
+func handleUnbanCommand(ctx *CommandContext) {
+	target := ctx.Args["target"]
+
+	var s *Sanction
+	if net.ParseIP(target) != nil {
+		s = Armeria.moderationManager.Active(SanctionBan, "", target)
+	} else if c := Armeria.characterManager.CharacterByName(target); c != nil {
+		s = Armeria.moderationManager.Active(SanctionBan, c.ID(), "")
+		target = c.Name()
+	}
+
+	if s == nil {
+		ctx.Player.client.ShowColorizedText("That character or address isn't banned.", ColorError)
+		return
+	}
+
+	Armeria.moderationManager.Lift(s)
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s is no longer banned.", TextStyle(target, WithBold())), ColorSuccess)
+}
+
+func handleJailCommand(ctx *CommandContext) {
+	c := sanctionTarget(ctx, ctx.Args["character"])
+	if c == nil {
+		return
+	} else if c.Jailed() != nil {
+		ctx.Player.client.ShowColorizedText("That character is already in jail.", ColorError)
+		return
+	}
+
+	d, ok := sanctionDuration(ctx)
+	if !ok {
+		return
+	}
+
+	if _, err := Armeria.moderationManager.Jail(c, ctx.Args["reason"], ctx.Character, d); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The character couldn't be jailed: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s has been sent to jail.", c.FormattedName()), ColorSuccess)
+}
+
+func handleReleaseCommand(ctx *CommandContext) {
+	c := sanctionTarget(ctx, ctx.Args["character"])
+	if c == nil {
+		return
+	}
+
+	s := c.Jailed()
+	if s == nil {
+		ctx.Player.client.ShowColorizedText("That character isn't in jail.", ColorError)
+		return
+	}
+
+	Armeria.moderationManager.Lift(s)
+	Armeria.moderationManager.Release(s)
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s has been released from jail.", c.FormattedName()), ColorSuccess)
+}
+
+func handleModerationListCommand(ctx *CommandContext) {
+	all := strings.ToLower(ctx.Args["all"]) == "all"
+
+	rows := []string{TableRow(
+		TableCell{content: "ID", header: true},
+		TableCell{content: "Type", header: true},
+		TableCell{content: "Target", header: true},
+		TableCell{content: "By", header: true},
+		TableCell{content: "Reason", header: true},
+		TableCell{content: "Created", header: true},
+		TableCell{content: "Expires", header: true},
+	)}
+
+	for _, s := range Armeria.moderationManager.Sanctions() {
+		if !all && !s.Active() {
+			continue
+		}
+
+		expires := "never"
+		if s.Type == SanctionKick {
+			expires = "-"
+		} else if !s.Lifted.IsZero() && !s.Lifted.Equal(s.Expires) {
+			expires = fmt.Sprintf("lifted %s", s.Lifted.Format("2006-01-02 15:04"))
+		} else if !s.Expires.IsZero() {
+			expires = s.Expires.Format("2006-01-02 15:04")
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: s.ID},
+			TableCell{content: string(s.Type)},
+			TableCell{content: TextStyle(s.TargetName(), WithBold())},
+			TableCell{content: s.By},
+			TableCell{content: s.Reason},
+			TableCell{content: s.Created.Format("2006-01-02 15:04")},
+			TableCell{content: expires},
+		))
+	}
+
+	if len(rows) == 1 {
+		ctx.Player.client.ShowText("There are no sanctions to show.")
+		return
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleSessionsListCommand(ctx *CommandContext) {
+	sessions := ctx.Character.Sessions()
+	if len(sessions) == 0 {
+		ctx.Player.client.ShowText("You don't have any auto-login sessions.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "ID", header: true},
+		TableCell{content: "Device", header: true},
+		TableCell{content: "Created", header: true},
+		TableCell{content: "Last Used", header: true},
+		TableCell{content: "Expires", header: true},
+	)}
+
+	for _, s := range sessions {
+		label := s.Label
+		if s.ID == ctx.Player.SessionID() {
+			label = fmt.Sprintf("%s (this device)", label)
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(s.ID, WithLinkCmd(fmt.Sprintf("/sessions revoke %s", s.ID)))},
+			TableCell{content: label},
+			TableCell{content: s.Created.Format("2006-01-02 15:04")},
+			TableCell{content: s.LastUsed.Format("2006-01-02 15:04")},
+			TableCell{content: s.Expires.Format("2006-01-02 15:04")},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleSessionsRevokeCommand(ctx *CommandContext) {
+	id := ctx.Args["id"]
+
+	if strings.ToLower(id) == "all" {
+		revoked := ctx.Character.RevokeAllSessions()
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You revoked %d auto-login session(s).", revoked),
+			ColorSuccess,
+		)
+	} else if ctx.Character.RevokeSession(id) {
+		ctx.Player.client.ShowColorizedText("The auto-login session has been revoked.", ColorSuccess)
+	} else {
+		ctx.Player.client.ShowColorizedText("You don't have a session with that id.", ColorError)
+		return
+	}
+
+	// Revoking the session this device logged in with turns auto-login off here too.
+	if sid := ctx.Player.SessionID(); len(sid) > 0 && (strings.ToLower(id) == "all" || strings.ToLower(id) == sid) {
+		ctx.Player.SetSessionID("")
+		ctx.Player.client.ToggleAutologin("")
+	}
+}
+
+func handleChannelListCommand(ctx *CommandContext) {
+	rows := []string{TableRow(
+		TableCell{content: "Channel", header: true},
+		TableCell{content: "Description", header: true},
+		TableCell{content: "Joined", header: true},
+	)}
+
+	for _, c := range AllChannels() {
+		if !c.HasPermission(ctx.Character) {
+			continue
+		}
+
+		name := TextStyle(c.Name, WithBold())
+		description := c.Description
+		if c.PlayerCreated() {
+			if c.InviteOnly() && !c.Invited(ctx.Character) && !c.IsModerator(ctx.Character) && !ctx.Character.InChannel(c) {
+				continue
+			}
+
+			name = TextStyle(c.Name, WithBold(), WithLinkCmd(fmt.Sprintf("/channel info %s", c.Name)))
+			if c.InviteOnly() {
+				name = fmt.Sprintf("%s (invite-only)", name)
+			} else if c.HasPassword() {
+				name = fmt.Sprintf("%s (password)", name)
+			}
+
+			description = c.Topic()
+			if len(description) == 0 {
+				description = "A channel created by a player."
+				if owner := c.Owner(); owner != nil {
+					description = fmt.Sprintf("A channel created by %s.", owner.Name())
+				}
+			}
+		}
+
+		if ctx.Character.InChannel(c) {
+			rows = append(rows, TableRow(
+				TableCell{content: name},
+				TableCell{content: description},
+				TableCell{content: TextStyle("Yes", WithUserColor(ctx.Character, ColorSuccess))},
+			))
+		} else {
+			rows = append(rows, TableRow(
+				TableCell{content: name},
+				TableCell{content: description},
+				TableCell{content: TextStyle("No", WithUserColor(ctx.Character, ColorError))},
+			))
+		}
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleChannelJoinCommand(ctx *CommandContext) {
+	channelName := ctx.Args["channel"]
+
+	ch := ChannelByName(channelName)
+	if ch == nil || (!ch.PlayerCreated() && !ch.HasPermission(ctx.Character)) {
+		ctx.Player.client.ShowColorizedText("You must enter a valid channel name to join.", ColorError)
+		return
+	}
+
+	if ctx.Character.InChannel(ch) {
+		ctx.Player.client.ShowColorizedText("You are already in that channel!", ColorError)
+		return
+	}
+
+	if ch.PlayerCreated() {
+		if err := ch.CanJoin(ctx.Character, ctx.Args["password"]); err != nil {
+			ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't join that channel: %s.", err), ColorError)
+			return
+		}
+	}
+
+	slashCommand := ch.SlashCommand
+	if len(slashCommand) == 0 {
+		slashCommand = fmt.Sprintf("/channel say %s", ch.Name)
+	}
+
+	ctx.Character.JoinChannel(ch)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You joined the %s channel. You can use %s to communicate.",
+			TextStyle(ch.Name, WithBold()),
+			TextStyle(slashCommand, WithBold()),
+		),
+		ColorSuccess,
+	)
+
+	if topic := ch.Topic(); len(topic) > 0 {
+		ctx.Player.client.ShowText(fmt.Sprintf("The topic is: %s", topic))
+	}
+}
+
+func handleChannelLeaveCommand(ctx *CommandContext) {
+	channelName := ctx.Args["channel"]
+
+	ch := ChannelByName(channelName)
+	if ch == nil {
+		ctx.Player.client.ShowColorizedText("You must enter a valid channel name to leave.", ColorError)
+		return
+	}
+
+	if !ctx.Character.InChannel(ch) {
+		ctx.Player.client.ShowColorizedText("You are not in that channel.", ColorError)
+		return
+	}
+
+	ctx.Character.LeaveChannel(ch)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You left the %s channel.",
+			TextStyle(ch.Name, WithBold()),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleChannelSayCommand(ctx *CommandContext) {
+	channelName := ctx.Args["channel"]
+	sayText := ctx.Args["text"]
+
+	ch := ChannelByName(channelName)
+	if ch == nil {
+		ctx.Player.client.ShowColorizedText("You must enter a valid channel name to talk to.", ColorError)
+		return
+	}
+
+	if !ctx.Character.InChannel(ch) {
+		ctx.Player.client.ShowColorizedText("You are not in that channel.", ColorError)
+		return
+	}
+
+	ch.Broadcast(ctx.Character, sayText)
+}
+
+func handleChannelShorthandSayCommand(ctx *CommandContext) {
+	Armeria.commandManager.ProcessCommand(
+		ctx.Player,
+		fmt.Sprintf("channel say %s %s", ctx.Command.Name, ctx.Args["message"]),
+		false,
+	)
+}
+
+// historyLines parses the optional lines argument of the history commands. Lets the player know, and returns
+// false, if it isn't valid.
+func historyLines(ctx *CommandContext, max int) (int, bool) {
+	if len(ctx.Args["lines"]) == 0 {
+		return DefaultHistoryLines, true
+	}
+
+	n, err := strconv.Atoi(ctx.Args["lines"])
+	if err != nil || n < 1 || n > max {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The number of lines must be between 1 and %d.", max), ColorError)
+		return 0, false
+	}
+
+	return n, true
+}
+
+func handleChannelHistoryCommand(ctx *CommandContext) {
+	ch := ChannelByName(ctx.Args["channel"])
+	if ch == nil || !ch.HasPermission(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("There is no channel by that name.", ColorError)
+		return
+	} else if !ctx.Character.InChannel(ch) {
+		ctx.Player.client.ShowColorizedText("You must join the channel to see its history.", ColorError)
+		return
+	}
+
+	n, ok := historyLines(ctx, MaxChannelHistory)
+	if !ok {
+		return
+	}
+
+	messages := Armeria.chatHistoryManager.Channel(ch, n)
+	if len(messages) == 0 {
+		ctx.Player.client.ShowText("Nothing has been said in that channel recently.")
+		return
+	}
+
+	for _, msg := range messages {
+		if !ctx.Character.ignoredMessage(msg) {
+			ctx.Player.client.ShowColorizedText(msg.String(), ch.Color)
+		}
+	}
+}
+
+// managedChannel returns the player-created channel a channel management command is being used on. Lets the
+// player know, and returns nil, if there isn't one or they aren't allowed to manage it. Characters that can use
+// CAN_SYSOP can manage any player-created channel.
+func managedChannel(ctx *CommandContext, ownerOnly bool) *Channel {
+	ch := ChannelByName(ctx.Args["channel"])
+	if ch == nil {
+		ctx.Player.client.ShowColorizedText("There is no channel by that name.", ColorError)
+		return nil
+	} else if !ch.PlayerCreated() {
+		ctx.Player.client.ShowColorizedText("The built-in channels can't be changed.", ColorError)
+		return nil
+	}
+
+	if ctx.Character.HasPermission("CAN_SYSOP") {
+		return ch
+	} else if ownerOnly && !ch.IsOwner(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("Only the owner of the channel can do that.", ColorError)
+		return nil
+	} else if !ch.IsModerator(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("Only the moderators of the channel can do that.", ColorError)
+		return nil
+	}
+
+	return ch
+}
+
+func handleChannelCreateCommand(ctx *CommandContext) {
+	ch, err := Armeria.channelManager.CreateChannel(ctx.Args["channel"], ctx.Character)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The channel couldn't be created: %s.", err), ColorError)
+		return
+	}
+
+	if pw := ctx.Args["password"]; len(pw) > 0 {
+		ch.SetPassword(pw)
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You created the %s channel. You can use %s to communicate, and %s to see how to manage it.",
+			TextStyle(ch.Name, WithBold()),
+			TextStyle(fmt.Sprintf("/channel say %s", ch.Name), WithBold()),
+			TextStyle("/channel", WithLinkCmd("/channel")),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleChannelDeleteCommand(ctx *CommandContext) {
+	ch := managedChannel(ctx, true)
+	if ch == nil {
+		return
+	}
+
+	ch.Broadcast(nil, fmt.Sprintf("This channel has been deleted by %s.", ctx.Character.FormattedName()))
+	Armeria.channelManager.RemoveChannel(ch)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The %s channel has been deleted.", TextStyle(ch.Name, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleChannelInfoCommand(ctx *CommandContext) {
+	ch := ChannelByName(ctx.Args["channel"])
+	if ch == nil || !ch.HasPermission(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("There is no channel by that name.", ColorError)
+		return
+	}
+
+	topic := ch.Description
+	owner := "none (built-in)"
+	access := "anyone who can see it"
+	var moderators []string
+	if ch.PlayerCreated() {
+		topic = ch.Topic()
+		if len(topic) == 0 {
+			topic = "none"
+		}
+		if o := ch.Owner(); o != nil {
+			owner = o.FormattedName()
+		}
+		for _, c := range ch.Moderators() {
+			moderators = append(moderators, c.FormattedName())
+		}
+
+		access = "anyone"
+		if ch.InviteOnly() {
+			access = "invited characters only"
+		} else if ch.HasPassword() {
+			access = "anyone with the password"
+		}
+	}
+
+	if len(moderators) == 0 {
+		moderators = []string{"none"}
+	}
+
+	var online []string
+	for _, c := range ch.Members() {
+		if c.Online() {
+			online = append(online, c.FormattedName())
+		}
+	}
+
+	if len(online) == 0 {
+		online = []string{"none"}
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"Channel: %s\nTopic: %s\nOwner: %s\nModerators: %s\nWho can join: %s\nOnline: %s",
+			TextStyle(ch.Name, WithBold()),
+			topic,
+			owner,
+			strings.Join(moderators, ", "),
+			access,
+			strings.Join(online, ", "),
+		),
+	)
+}
+
+func handleChannelTopicCommand(ctx *CommandContext) {
+	ch := managedChannel(ctx, false)
+	if ch == nil {
+		return
+	}
+
+	topic := strings.TrimSpace(ctx.Args["topic"])
+	if len(topic) > MaxChannelTopicLength {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The topic can't be longer than %d characters.", MaxChannelTopicLength),
+			ColorError,
+		)
+		return
+	}
+
+	ch.SetTopic(topic)
+
+	if len(topic) == 0 {
+		ch.Broadcast(nil, fmt.Sprintf("%s cleared the topic.", ctx.Character.FormattedName()))
+	} else {
+		ch.Broadcast(nil, fmt.Sprintf("%s set the topic to: %s", ctx.Character.FormattedName(), topic))
+	}
+
+	if !ctx.Character.InChannel(ch) {
+		ctx.Player.client.ShowColorizedText("The topic has been set.", ColorSuccess)
+	}
+}
+
+func handleChannelInviteCommand(ctx *CommandContext) {
+	ch := managedChannel(ctx, false)
+	if ch == nil {
+		return
+	}
+
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("There is no character by that name.", ColorError)
+		return
+	} else if ch.Banned(c) {
+		ctx.Player.client.ShowColorizedText("That character is banned from the channel.", ColorError)
+		return
+	}
+
+	ch.Invite(c)
+
+	if c.Online() {
+		c.Player().client.ShowText(
+			fmt.Sprintf(
+				"%s invited you to the %s channel.",
+				ctx.Character.FormattedName(),
+				TextStyle(ch.Name, WithBold(), WithLinkCmd(fmt.Sprintf("/channel join %s", ch.Name))),
+			),
+		)
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You invited %s to the %s channel.", c.FormattedName(), TextStyle(ch.Name, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleChannelPrivateCommand(ctx *CommandContext) {
+	ch := managedChannel(ctx, true)
+	if ch == nil {
+		return
+	}
+
+	switch strings.ToLower(ctx.Args["enabled"]) {
+	case "on", "true", "yes":
+		ch.SetInviteOnly(true)
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The %s channel is now invite-only.", TextStyle(ch.Name, WithBold())),
+			ColorSuccess,
+		)
+	case "off", "false", "no":
+		ch.SetInviteOnly(false)
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("Anyone can now join the %s channel.", TextStyle(ch.Name, WithBold())),
+			ColorSuccess,
+		)
+	default:
+		ctx.Player.client.ShowColorizedText("Use on or off.", ColorError)
+	}
+}
+
+func handleChannelPasswordCommand(ctx *CommandContext) {
+	ch := managedChannel(ctx, true)
+	if ch == nil {
+		return
+	}
+
+	ch.SetPassword(ctx.Args["password"])
+
+	if len(ctx.Args["password"]) == 0 {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The %s channel no longer needs a password.", TextStyle(ch.Name, WithBold())),
+			ColorSuccess,
+		)
+	} else {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The %s channel now needs a password to join.", TextStyle(ch.Name, WithBold())),
+			ColorSuccess,
+		)
+	}
+}
+
+func handleChannelModCommand(ctx *CommandContext) {
+	ch := managedChannel(ctx, true)
+	if ch == nil {
+		return
+	}
+
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("There is no character by that name.", ColorError)
+		return
+	} else if ch.IsOwner(c) {
+		ctx.Player.client.ShowColorizedText("The owner of a channel is always a moderator.", ColorError)
+		return
+	}
+
+	moderator := ctx.Command.Name == "mod"
+	if moderator && ch.Banned(c) {
+		ctx.Player.client.ShowColorizedText("That character is banned from the channel.", ColorError)
+		return
+	} else if moderator == ch.IsModerator(c) {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("That character is %s a moderator.", misc.BoolToWords(moderator, "already", "not")),
+			ColorError,
+		)
+		return
+	}
+
+	ch.SetModerator(c, moderator)
+
+	if moderator {
+		ch.Broadcast(nil, fmt.Sprintf("%s is now a moderator.", c.FormattedName()))
+	} else {
+		ch.Broadcast(nil, fmt.Sprintf("%s is no longer a moderator.", c.FormattedName()))
+	}
+
+	if !ctx.Character.InChannel(ch) {
+		ctx.Player.client.ShowColorizedText("The channel's moderators have been updated.", ColorSuccess)
+	}
+}
+
+func handleChannelBanCommand(ctx *CommandContext) {
+	ch := managedChannel(ctx, false)
+	if ch == nil {
+		return
+	}
+
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("There is no character by that name.", ColorError)
+		return
+	}
+
+	banned := ctx.Command.Name == "ban"
+	if banned && (ch.IsOwner(c) || c.ID() == ctx.Character.ID()) {
+		ctx.Player.client.ShowColorizedText("You can't ban that character from the channel.", ColorError)
+		return
+	} else if banned && ch.IsModerator(c) && !ch.IsOwner(ctx.Character) && !ctx.Character.HasPermission("CAN_SYSOP") {
+		ctx.Player.client.ShowColorizedText("Only the owner of the channel can ban a moderator.", ColorError)
+		return
+	} else if banned == ch.Banned(c) {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("That character is %s banned from the channel.", misc.BoolToWords(banned, "already", "not")),
+			ColorError,
+		)
+		return
+	}
+
+	ch.SetBanned(c, banned)
+
+	if banned {
+		if c.InChannel(ch) {
+			c.LeaveChannel(ch)
+		}
+		if c.Online() {
+			c.Player().client.ShowColorizedText(
+				fmt.Sprintf("You have been banned from the %s channel.", TextStyle(ch.Name, WithBold())),
+				ColorError,
+			)
+		}
+		ch.Broadcast(nil, fmt.Sprintf("%s has been banned from the channel.", c.FormattedName()))
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"%s is %s banned from the %s channel.",
+			c.FormattedName(),
+			misc.BoolToWords(banned, "now", "no longer"),
+			TextStyle(ch.Name, WithBold()),
+		),
+		ColorSuccess,
+	)
+}
+
+// memberGuild returns the guild the character belongs to, letting them know if they don't belong to one.
+func memberGuild(ctx *CommandContext) *Guild {
+	g := Armeria.guildManager.GuildOf(ctx.Character)
+	if g == nil {
+		ctx.Player.client.ShowColorizedText("You don't belong to a guild.", ColorError)
+	}
+
+	return g
+}
+
+// guildMemberTarget returns the member of the character's guild named in the arguments, who must be ranked below
+// them, letting the character know if they can't act on them.
+func guildMemberTarget(ctx *CommandContext, g *Guild) *Character {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil || !g.IsMember(c) {
+		ctx.Player.client.ShowColorizedText("There is no member of your guild by that name.", ColorError)
+		return nil
+	} else if !g.Outranks(ctx.Character, c) {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You can only do that to members ranked below you, and %s isn't.", c.FormattedName()),
+			ColorError,
+		)
+		return nil
+	}
+
+	return c
+}
+
+func handleGuildListCommand(ctx *CommandContext) {
+	guilds := Armeria.guildManager.Guilds()
+	if len(guilds) == 0 {
+		ctx.Player.client.ShowText("There are no guilds yet.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Guild", header: true},
+		TableCell{content: "Leader", header: true},
+		TableCell{content: "Members", header: true},
+	)}
+
+	for _, g := range guilds {
+		leader := "none"
+		if c := g.Leader(); c != nil {
+			leader = c.FormattedName()
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(g.Name(), WithBold(), WithLinkCmd(fmt.Sprintf("/guild info %s", g.Name())))},
+			TableCell{content: leader},
+			TableCell{content: strconv.Itoa(len(g.Members()))},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleGuildInfoCommand(ctx *CommandContext) {
+	var g *Guild
+	if name := ctx.Args["guild"]; len(name) > 0 {
+		g = Armeria.guildManager.GuildByName(name)
+		if g == nil {
+			ctx.Player.client.ShowColorizedText("There is no guild by that name.", ColorError)
+			return
+		}
+	} else if g = memberGuild(ctx); g == nil {
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Rank", header: true},
+		TableCell{content: "Permissions", header: true},
+		TableCell{content: "Members", header: true},
+	)}
+
+	members := g.Members()
+	for i, r := range g.Ranks() {
+		permissions := "everything"
+		if i > 0 {
+			var names []string
+			for _, p := range r.Permissions {
+				names = append(names, string(p))
+			}
+			permissions = strings.Join(names, ", ")
+			if len(permissions) == 0 {
+				permissions = "none"
+			}
+		}
+
+		var names []string
+		for _, c := range members {
+			if g.Rank(c) == i {
+				names = append(names, c.FormattedName())
+			}
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(r.Name, WithBold())},
+			TableCell{content: permissions},
+			TableCell{content: strings.Join(names, ", ")},
+		))
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"Guild: %s\nFounded: %s\nMembers: %d",
+			TextStyle(g.Name(), WithBold()),
+			g.Created.Format("Mon Jan 2 2006"),
+			len(members),
+		),
+	)
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleGuildCreateCommand(ctx *CommandContext) {
+	g, err := Armeria.guildManager.CreateGuild(ctx.Args["name"], ctx.Character)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The guild couldn't be created: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You founded %s, and are its %s. Use %s to invite members, and %s to talk with them.",
+			TextStyle(g.Name(), WithBold()),
+			g.RankName(0),
+			TextStyle("/guild invite", WithBold()),
+			TextStyle("/guild say", WithBold()),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleGuildDisbandCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	} else if g.Rank(ctx.Character) != 0 {
+		ctx.Player.client.ShowColorizedText("Only the leader of your guild can disband it.", ColorError)
+		return
+	} else if g.Money() > 0 {
+		ctx.Player.client.ShowColorizedText("You must withdraw everything from the guild bank first.", ColorError)
+		return
+	}
+
+	g.Broadcast(fmt.Sprintf("The guild has been disbanded by %s.", ctx.Character.FormattedName()))
+	Armeria.guildManager.RemoveGuild(g)
+}
+
+func handleGuildInviteCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	} else if !g.Can(ctx.Character, GuildPermissionInvite) {
+		ctx.Player.client.ShowColorizedText("Your rank doesn't allow you to invite members.", ColorError)
+		return
+	}
+
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("There is no character by that name.", ColorError)
+		return
+	} else if Armeria.guildManager.GuildOf(c) != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("%s already belongs to a guild.", c.FormattedName()),
+			ColorError,
+		)
+		return
+	}
+
+	g.Invite(c)
+
+	if c.Online() {
+		c.Player().client.ShowText(
+			fmt.Sprintf(
+				"%s invited you to join %s.",
+				ctx.Character.FormattedName(),
+				TextStyle(g.Name(), WithBold(), WithLinkCmd(fmt.Sprintf("/guild accept %s", g.Name()))),
+			),
+		)
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You invited %s to join %s.", c.FormattedName(), TextStyle(g.Name(), WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleGuildAcceptCommand(ctx *CommandContext) {
+	g := Armeria.guildManager.GuildByName(ctx.Args["guild"])
+	if g == nil || !g.Invited(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("You haven't been invited to a guild by that name.", ColorError)
+		return
+	} else if Armeria.guildManager.GuildOf(ctx.Character) != nil {
+		ctx.Player.client.ShowColorizedText("You must leave your guild first.", ColorError)
+		return
+	}
+
+	g.AddMember(ctx.Character, g.LowestRank())
+	g.Broadcast(fmt.Sprintf("%s has joined the guild.", ctx.Character.FormattedName()))
+}
+
+func handleGuildLeaveCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	} else if g.Rank(ctx.Character) == 0 {
+		ctx.Player.client.ShowColorizedText(
+			"The leader can't leave the guild. Make someone else the leader with /guild rank, or disband it.",
+			ColorError,
+		)
+		return
+	}
+
+	g.RemoveMember(ctx.Character)
+	g.Broadcast(fmt.Sprintf("%s has left the guild.", ctx.Character.FormattedName()))
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You left %s.", TextStyle(g.Name(), WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleGuildKickCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	} else if !g.Can(ctx.Character, GuildPermissionKick) {
+		ctx.Player.client.ShowColorizedText("Your rank doesn't allow you to remove members.", ColorError)
+		return
+	}
+
+	c := guildMemberTarget(ctx, g)
+	if c == nil {
+		return
+	}
+
+	g.RemoveMember(c)
+	g.Broadcast(fmt.Sprintf("%s was removed from the guild by %s.", c.FormattedName(), ctx.Character.FormattedName()))
+
+	if c.Online() {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf("You were removed from %s by %s.", TextStyle(g.Name(), WithBold()), ctx.Character.FormattedName()),
+			ColorError,
+		)
+	}
+}
+
+func handleGuildRankCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	} else if !g.Can(ctx.Character, GuildPermissionPromote) {
+		ctx.Player.client.ShowColorizedText("Your rank doesn't allow you to change the ranks of members.", ColorError)
+		return
+	}
+
+	c := guildMemberTarget(ctx, g)
+	if c == nil {
+		return
+	}
+
+	rank := g.RankByName(ctx.Args["rank"])
+	if rank < 0 {
+		ctx.Player.client.ShowColorizedText("There is no rank by that name in your guild.", ColorError)
+		return
+	}
+
+	own := g.Rank(ctx.Character)
+	if rank == 0 && own == 0 {
+		// handing over leadership moves the old leader down to the next rank
+		g.SetRank(c, 0)
+		g.SetRank(ctx.Character, 1)
+		g.Broadcast(fmt.Sprintf(
+			"%s has made %s the %s of the guild.",
+			ctx.Character.FormattedName(),
+			c.FormattedName(),
+			g.RankName(0),
+		))
+		return
+	} else if rank <= own {
+		ctx.Player.client.ShowColorizedText("You can only give members a rank below your own.", ColorError)
+		return
+	}
+
+	g.SetRank(c, rank)
+	g.Broadcast(fmt.Sprintf("%s has been given the %s rank.", c.FormattedName(), g.RankName(rank)))
+}
+
+func handleGuildPermissionCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	} else if !g.Can(ctx.Character, GuildPermissionManage) {
+		ctx.Player.client.ShowColorizedText("Your rank doesn't allow you to change what ranks can do.", ColorError)
+		return
+	}
+
+	rank := g.RankByName(ctx.Args["rank"])
+	if rank < 0 {
+		ctx.Player.client.ShowColorizedText("There is no rank by that name in your guild.", ColorError)
+		return
+	} else if rank <= g.Rank(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("You can only change what the ranks below your own can do.", ColorError)
+		return
+	}
+
+	var p GuildPermission
+	for _, gp := range GuildPermissions {
+		if string(gp) == strings.ToLower(ctx.Args["permission"]) {
+			p = gp
+		}
+	}
+
+	if len(p) == 0 {
+		ctx.Player.client.ShowColorizedText(
+			"The permission must be one of: invite, kick, promote, withdraw or manage.",
+			ColorError,
+		)
+		return
+	}
+
+	var allowed bool
+	switch strings.ToLower(ctx.Args["enabled"]) {
+	case "on", "true", "yes":
+		allowed = true
+	case "off", "false", "no":
+		allowed = false
+	default:
+		ctx.Player.client.ShowColorizedText("You must specify on or off.", ColorError)
+		return
+	}
+
+	g.SetRankPermission(rank, p, allowed)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"The %s rank %s has the %s permission.",
+			TextStyle(g.RankName(rank), WithBold()),
+			misc.BoolToWords(allowed, "now", "no longer"),
+			TextStyle(string(p), WithBold()),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleGuildSayCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	}
+
+	g.Channel().Broadcast(ctx.Character, ctx.Args["text"])
+}
+
+func handleGuildHistoryCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	}
+
+	n, ok := historyLines(ctx, MaxChannelHistory)
+	if !ok {
+		return
+	}
+
+	ch := g.Channel()
+	messages := Armeria.chatHistoryManager.Channel(ch, n)
+	if len(messages) == 0 {
+		ctx.Player.client.ShowText("Nothing has been said in your guild recently.")
+		return
+	}
+
+	for _, msg := range messages {
+		if !ctx.Character.ignoredMessage(msg) {
+			ctx.Player.client.ShowColorizedText(msg.String(), ch.Color)
+		}
+	}
+}
+
+func handleGuildBankCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"The balance of the %s guild bank is %s.",
+			TextStyle(g.Name(), WithBold()),
+			ctx.Character.Colorize(g.Money().String(), ColorMoney),
+		),
+	)
+
+	txs := g.Transactions()
+	if len(txs) == 0 {
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Time", header: true},
+		TableCell{content: "Type", header: true},
+		TableCell{content: "Amount", header: true},
+		TableCell{content: "Balance", header: true},
+		TableCell{content: "Counterparty", header: true},
+	)}
+
+	for i := len(txs) - 1; i >= 0 && i >= len(txs)-10; i-- {
+		tx := txs[i]
+		rows = append(rows, TableRow(
+			TableCell{content: tx.Time.Format("2006-01-02 15:04:05")},
+			TableCell{content: tx.Type},
+			TableCell{content: tx.Amount.String()},
+			TableCell{content: tx.Balance.String()},
+			TableCell{content: tx.Counterparty},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleGuildDepositCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	}
+
+	banker := bankerHere(ctx.Character)
+	if banker == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoBankerHere, ColorError)
+		return
+	}
+
+	amount, ok := parseMoneyAmount(ctx.Args["amount"])
+	if !ok {
+		ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
+		return
+	}
+
+	if err := TransferMoney(ctx.Character, g, amount, MoneyReasonGuildDeposit); err != nil {
+		ctx.Player.client.ShowColorizedText("You don't have that much money.", ColorError)
+		return
+	}
+
+	ctx.Player.client.SyncMoney()
+	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You deposited %s into the guild bank with %s. Its balance is now %s.",
+			ctx.Character.Colorize(amount.String(), ColorMoney),
+			banker.FormattedName(),
+			ctx.Character.Colorize(g.Money().String(), ColorMoney),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleGuildWithdrawCommand(ctx *CommandContext) {
+	g := memberGuild(ctx)
+	if g == nil {
+		return
+	} else if !g.Can(ctx.Character, GuildPermissionWithdraw) {
+		ctx.Player.client.ShowColorizedText("Your rank doesn't allow you to withdraw from the guild bank.", ColorError)
+		return
+	}
+
+	banker := bankerHere(ctx.Character)
+	if banker == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoBankerHere, ColorError)
+		return
+	}
+
+	amount, ok := parseMoneyAmount(ctx.Args["amount"])
+	if !ok {
+		ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
+		return
+	}
+
+	if err := TransferMoney(g, ctx.Character, amount, MoneyReasonGuildWithdrawal); err != nil {
+		ctx.Player.client.ShowColorizedText("The guild bank doesn't have that much money.", ColorError)
+		return
+	}
+
+	ctx.Player.client.SyncMoney()
+	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You withdrew %s from the guild bank with %s. Its balance is now %s.",
+			ctx.Character.Colorize(amount.String(), ColorMoney),
+			banker.FormattedName(),
+			ctx.Character.Colorize(g.Money().String(), ColorMoney),
+		),
+		ColorSuccess,
+	)
+}
+
+// memberParty returns the party the character is in, letting them know if they aren't in one.
+func memberParty(ctx *CommandContext) *Party {
+	p := ctx.Character.Party()
+	if p == nil {
+		ctx.Player.client.ShowColorizedText("You aren't in a party.", ColorError)
+	}
+
+	return p
+}
+
+func handlePartyListCommand(ctx *CommandContext) {
+	p := memberParty(ctx)
+	if p == nil {
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Character", header: true},
+		TableCell{content: "Role", header: true},
+		TableCell{content: "Location", header: true},
+	)}
+
+	for _, c := range p.Members() {
+		role := "Member"
+		if p.IsLeader(c) {
+			role = "Leader"
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: c.FormattedNameWithTitle()},
+			TableCell{content: role},
+			TableCell{content: c.Room().ParentArea.Name()},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handlePartyInviteCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That's not a valid character name.", ColorError)
+		return
+	} else if c.Player() == nil {
+		ctx.Player.client.ShowColorizedText("That character is not online.", ColorError)
+		return
+	} else if c.ID() == ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("You can't invite yourself.", ColorError)
+		return
+	}
+
+	p := ctx.Character.Party()
+	if p != nil && !p.IsLeader(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("Only the leader of your party can invite members.", ColorError)
+		return
+	} else if p != nil && p.Full() {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("Parties can't have more than %d members.", MaxPartySize),
+			ColorError,
+		)
+		return
+	} else if c.Party() != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("%s is already in a party.", c.FormattedName()),
+			ColorError,
+		)
+		return
+	}
+
+	if p == nil {
+		p = NewParty(ctx.Character)
+		p.Sync()
+	}
+
+	p.Invite(c)
+
+	if !c.Ignores(ctx.Character) {
+		c.Player().client.ShowText(
+			fmt.Sprintf(
+				"%s invited you to join a %s.",
+				ctx.Character.FormattedName(),
+				TextStyle("party", WithBold(), WithLinkCmd(fmt.Sprintf("/party accept %s", ctx.Character.Name()))),
+			),
+		)
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You invited %s to join your party.", c.FormattedName()),
+		ColorSuccess,
+	)
+}
+
+func handlePartyAcceptCommand(ctx *CommandContext) {
+	leader := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	var p *Party
+	if leader != nil {
+		p = leader.Party()
+	}
+
+	if p == nil || !p.Invited(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("You haven't been invited to a party by that character.", ColorError)
+		return
+	} else if ctx.Character.Party() != nil {
+		ctx.Player.client.ShowColorizedText("You must leave your party first.", ColorError)
+		return
+	} else if p.Full() {
+		ctx.Player.client.ShowColorizedText("That party is full.", ColorError)
+		return
+	}
+
+	p.Add(ctx.Character)
+}
+
+func handlePartyLeaveCommand(ctx *CommandContext) {
+	p := memberParty(ctx)
+	if p == nil {
+		return
+	}
+
+	p.Leave(ctx.Character, fmt.Sprintf("%s has left the party.", ctx.Character.FormattedName()))
+	ctx.Character.SetFollowing(nil)
+
+	ctx.Player.client.ShowColorizedText("You left the party.", ColorSuccess)
+}
+
+func handlePartyKickCommand(ctx *CommandContext) {
+	p := memberParty(ctx)
+	if p == nil {
+		return
+	} else if !p.IsLeader(ctx.Character) {
+		ctx.Player.client.ShowColorizedText("Only the leader of your party can remove members.", ColorError)
+		return
+	}
+
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil || !p.IsMember(c) {
+		ctx.Player.client.ShowColorizedText("There is no member of your party by that name.", ColorError)
+		return
+	} else if c.ID() == ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("Use /party leave to leave your party.", ColorError)
+		return
+	}
+
+	p.Leave(c, fmt.Sprintf("%s was removed from the party.", c.FormattedName()))
+	c.SetFollowing(nil)
+
+	if c.Online() {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf("You were removed from the party by %s.", ctx.Character.FormattedName()),
+			ColorError,
+		)
+	}
+}
+
+func handlePartySayCommand(ctx *CommandContext) {
+	p := memberParty(ctx)
+	if p == nil {
+		return
+	}
+
+	p.Channel().Broadcast(ctx.Character, ctx.Args["text"])
+}
+
+func handleFollowCommand(ctx *CommandContext) {
+	if len(ctx.Args["character"]) == 0 {
+		leader := ctx.Character.Following()
+		if leader == nil {
+			ctx.Player.client.ShowColorizedText("You aren't following anyone.", ColorError)
+			return
+		}
+
+		ctx.Character.SetFollowing(nil)
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You stop following %s.", leader.FormattedName()),
+			ColorSuccess,
+		)
+		if leader.Online() {
+			leader.Player().client.ShowText(fmt.Sprintf("%s stops following you.", ctx.Character.FormattedName()))
+		}
+		return
+	}
+
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That's not a valid character name.", ColorError)
+		return
+	} else if c.Player() == nil {
+		ctx.Player.client.ShowColorizedText("That character is not online.", ColorError)
+		return
+	} else if c.ID() == ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("You can't follow yourself.", ColorError)
+		return
+	} else if !ctx.Character.InPartyWith(c) {
+		ctx.Player.client.ShowColorizedText("You can only follow members of your party.", ColorError)
+		return
+	}
+
+	ctx.Character.SetFollowing(c)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You are now following %s.", c.FormattedName()),
+		ColorSuccess,
+	)
+	c.Player().client.ShowText(fmt.Sprintf("%s is now following you.", ctx.Character.FormattedName()))
+}
+
+func handleSettingsCommand(ctx *CommandContext) {
+	setting := strings.ToLower(ctx.Args["name"])
+	value := ctx.Args["value"]
+
+	if len(setting) == 0 {
+		rows := []string{TableRow(
+			TableCell{content: "Name", header: true},
+			TableCell{content: "Description", header: true},
+			TableCell{content: "Current", header: true},
+			TableCell{content: "Default", header: true},
+		)}
+
+		valid := ValidSettings()
+
+		for _, s := range valid {
+			reqPerm := SettingPermission(s)
+			if len(reqPerm) > 0 && !ctx.Character.HasPermission(reqPerm) {
+				continue
+			}
+
+			rows = append(rows, TableRow(
+				TableCell{content: s},
+				TableCell{content: SettingDesc(s), styling: "padding:0px 2px"},
+				TableCell{content: ctx.Character.Setting(s), styling: "padding:0px 2px"},
+				TableCell{content: SettingDefault(s), styling: "padding:0px 2px;color:#666"},
+			))
+		}
+		ctx.Player.client.ShowText(TextTable(rows...))
+		return
+	} else if !misc.Contains(ValidSettings(), setting) {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"%s is not a valid setting name.",
+				TextStyle(setting, WithBold()),
+			),
+			ColorError,
+		)
+		return
+	}
+
+	// Check if the character has permission to modify the setting.
+	reqPerm := SettingPermission(setting)
+	if len(reqPerm) > 0 && !ctx.Character.HasPermission(reqPerm) {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"%s is not a valid setting name.",
+				TextStyle(setting, WithBold()),
+			),
+			ColorError,
+		)
+		return
+	}
+
+	// If no value is specified, either swap the bools or set the default value.
+	if len(value) == 0 {
+		if misc.IsStringBool(ctx.Character.Setting(setting)) {
+			value = misc.ToggleStringBool(ctx.Character.Setting(setting))
+		} else {
+			value = SettingDefault(setting)
+		}
+	} else {
+		// Validate the setting value.
+		valid := validate.Check(value, SettingValidationString(setting))
+		if !valid.Result {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("You cannot use that value due to: %s", valid),
+				ColorError,
+			)
+			return
+		}
+	}
+
+	if err := ctx.Character.SetSetting(setting, value); err != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"That setting cannot be changed: %s",
+				err,
+			),
+			ColorError,
+		)
+	}
+
+	ctx.Player.client.SyncSettings()
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Setting %s has been set to '%s'.", TextStyle(setting, WithBold()), value),
+		ColorSuccess,
+	)
+
+}
+
+func handleBugCommand(ctx *CommandContext) {
+	bug := ctx.Args["bug"]
+
+	issueBody := fmt.Sprintf(
+		"This was reported in-game by **%s**.\n\n"+
+			"**Location:** %s\n\n%s",
+		ctx.Character.Name(),
+		ctx.Character.Room().LocationString(),
+		bug,
+	)
+
+	issue, err := Armeria.github.CreateIssue(ctx.Character.Name(), issueBody, bug)
+	if err != nil {
+		Armeria.log.Error(
+			"error submitting bug to github repo",
+			zap.Error(err),
+		)
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"There was an error submitting the bug report to GitHub.\nAs an alternative, you can manually "+
+					"submit a bug report %s.",
+				TextStyle("here", WithLink("https://github.com/heyitsmdr/armeria/issues/new")),
+			),
+			ColorError,
+		)
+		return
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf("Thank you for your submission! You can view/track it %s.",
+			TextStyle("here", WithLink(issue.GetHTMLURL())),
+		),
+	)
+}
+
+func handleGiveCommand(ctx *CommandContext) {
+	target := ctx.Args["target"]
+	item, qty := parseItemQuantity(ctx.Args["item"])
+
+	ctr := ctx.Character.Room().Here()
+	targetResult := ctr.GetByAny(target)
+	if targetResult.Type == RegistryTypeUnknown {
+		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		return
+	}
+
+	itemResult := ctx.Character.Inventory().GetByAny(item)
+	if itemResult.Type != RegistryTypeItemInstance {
+		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		return
+	}
+
+	ii := itemResult.Object.(*ItemInstance)
+	if qty == 0 || qty > ii.Quantity() {
+		qty = ii.Quantity()
+	}
+
+	if targetResult.Type == RegistryTypeItemInstance && targetResult.Object.(*ItemInstance).Attribute(AttributeType) == ItemTypeTrashCan {
+		if ii.HasContents() {
+			ctx.Player.client.ShowColorizedText(CommonContainerNotEmpty, ColorError)
+			return
+		}
+
+		// Destroy the item.
+		name := ii.FormattedQuantity(qty)
+		trashed := ii.Split(qty)
+		if trashed == ii {
+			ctx.Character.Inventory().Remove(ii.ID())
+		}
+		trashed.Delete()
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You put %s into the %s. Goodbye!",
+				name,
+				targetResult.Object.(*ItemInstance).FormattedName(),
+			),
+			ColorSuccess,
+		)
+		ctx.Player.client.SyncInventory()
+		for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+			c.Player().client.ShowText(
+				fmt.Sprintf(
+					"%s put an item into the %s.",
+					ctx.Character.FormattedName(),
+					targetResult.Object.(*ItemInstance).FormattedName(),
+				),
+			)
+		}
+		return
+	} else if targetResult.Type != RegistryTypeCharacter && targetResult.Type != RegistryTypeMobInstance {
+		ctx.Player.client.ShowColorizedText("You can only give things to other characters or mobs!", ColorError)
+		return
+	} else if targetResult.Object.ID() == ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("You cannot give things to yourself.", ColorError)
+		return
+	}
+
+	// set the target object container
+	var toc *ObjectContainer
+	if targetResult.Type == RegistryTypeCharacter {
+		toc = targetResult.Object.(*Character).Inventory()
+	} else {
+		toc = targetResult.Object.(*MobInstance).Inventory()
+	}
+
+	// check if the target object container can hold it
+	if !toc.HasRoomFor(ii, qty) {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"%s does not have enough room to hold that!",
+				targetResult.Object.FormattedName(),
+			),
+			ColorError,
+		)
+		return
+	}
+
+	// characters that are ignoring the giver turn it down, the same way a mob would
+	if targetResult.Type == RegistryTypeCharacter && targetResult.Object.(*Character).Ignores(ctx.Character) {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("%s does not want that.", targetResult.Object.FormattedName()),
+			ColorError,
+		)
+		return
+	}
+
+	// check if the mob can handle it?
+	if targetResult.Type == RegistryTypeMobInstance {
+		if !misc.Contains(toc.ParentMobInstance().Parent.ScriptFuncs(), "received_item") {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf(
+					"%s does not want that.",
+					toc.ParentMobInstance().FormattedName(),
+				),
+				ColorError,
+			)
+			return
+		}
+	}
+
+	tco := targetResult.Object
+
+	// move item from source to target
+	stack, err := ctx.Character.Inventory().MoveItem(ii, qty, toc)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"%s does not have enough room to hold that!",
+				tco.FormattedName(),
+			),
+			ColorError,
+		)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You gave %s %s.",
+			tco.FormattedName(),
+			stack.FormattedQuantity(qty),
+		),
+		ColorSuccess,
+	)
+	ctx.Player.client.SyncInventory()
+
+	if targetResult.Type == RegistryTypeCharacter {
+		targetResult.Object.(*Character).Player().client.ShowText(
+			fmt.Sprintf(
+				"%s gave you %s.",
+				ctx.Character.FormattedName(),
+				stack.FormattedQuantity(qty),
+			),
+		)
+		targetResult.Object.(*Character).Player().client.SyncInventory()
+	} else if targetResult.Type == RegistryTypeMobInstance {
+		go CallMobFunc(
+			ctx.Character,
+			targetResult.Object.(*MobInstance),
+			"received_item",
+			lua.LString(ctx.Character.ID()),
+			lua.LString(stack.ID()),
+		)
+	}
+
+	roomExceptions := []*Character{ctx.Character}
+	if targetResult.Type == RegistryTypeCharacter {
+		roomExceptions = append(roomExceptions, targetResult.Object.(*Character))
+	}
+	for _, c := range ctx.Character.Room().Here().Characters(true, roomExceptions...) {
+		c.Player().client.ShowText(
+			fmt.Sprintf(
+				"%s gave %s something.",
+				ctx.Character.FormattedName(),
+				tco.FormattedName(),
+			),
+		)
+	}
+}
+
+func handleEmoteCommand(ctx *CommandContext) {
+	emotion := ctx.Args["emote"]
+
+	if emotion[len(emotion)-1:] == "." {
+		emotion = emotion[:len(emotion)-1]
+	}
+
+	for _, c := range ctx.Character.Room().Here().Characters(true) {
+		if c.Ignores(ctx.Character) {
+			continue
+		}
+
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s %s.", ctx.Character.FormattedName(), emotion),
+		)
+	}
+}
+
+func handleLedgerListCommand(ctx *CommandContext) {
+	rows := []string{TableRow(
+		TableCell{content: "Ledger", header: true},
+		TableCell{content: "Items", header: true},
+	)}
+
+	for _, l := range Armeria.ledgerManager.Ledgers() {
+		rows = append(rows, TableRow(
+			TableCell{content: fmt.Sprintf("[cmd=/ledger show %[1]s]%[1]s[/cmd]", l.Name())},
+			TableCell{content: fmt.Sprintf("%d items", len(l.Entries()))},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleLedgerCreateCommand(ctx *CommandContext) {
+	name := ctx.Args["name"]
+
+	if strings.Contains(name, " ") {
+		ctx.Player.client.ShowColorizedText("The ledger name cannot contain a space.", ColorError)
+		return
+	}
+
+	exists := Armeria.ledgerManager.LedgerByName(name)
+	if exists != nil {
+		ctx.Player.client.ShowColorizedText("A ledger already exists with that name.", ColorError)
+		return
+	}
+
+	l := Armeria.ledgerManager.CreateLedger(name)
+	Armeria.ledgerManager.AddLedger(l)
+	ctx.Character.Journal().Record(NewLedgerCreateJournalEntry(l))
+
+	ctx.Player.client.ShowColorizedText("The ledger has been created.", ColorSuccess)
+}
+
+func handleLedgerRenameCommand(ctx *CommandContext) {
+	ledgerName := ctx.Args["ledger_name"]
+	newName := ctx.Args["new_name"]
+
+	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
+	if ledger == nil {
+		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+		return
+	}
+
+	if strings.Contains(newName, " ") {
+		ctx.Player.client.ShowColorizedText("The new ledger name cannot contain a space.", ColorError)
+		return
+	}
+
+	e := NewLedgerRenameJournalEntry(ledger, newName)
+	if err := e.RedoFunc(); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The ledger could not be renamed: %s.", err), ColorError)
+		return
+	}
+	ctx.Character.Journal().Record(e)
+
+	ctx.Player.client.ShowColorizedText("The ledger has been renamed.", ColorSuccess)
+}
+
+func handleLedgerAddCommand(ctx *CommandContext) {
+	ledgerName := ctx.Args["ledger_name"]
+	itemName := ctx.Args["item_name"]
+
+	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
+	if ledger == nil {
+		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+		return
+	}
+
+	item := Armeria.itemManager.ItemByName(itemName)
+	if item == nil {
+		ctx.Player.client.ShowColorizedText("An item by that name doesn't exist.", ColorError)
+		return
+	}
+
+	if ledger.Contains(item.Name()) != nil {
+		ctx.Player.client.ShowColorizedText("That item is already on that ledger.", ColorError)
+		return
+	}
+
+	entry := &LedgerEntry{
+		ItemName:  item.Name(),
+		BuyPrice:  0,
+		SellPrice: 0,
+	}
+	ledger.AddEntry(entry)
+	ctx.Character.Journal().Record(NewLedgerAddJournalEntry(ledger, entry))
+
+	ctx.Player.client.ShowColorizedText("Entry has been added to the ledger.", ColorSuccess)
+}
+
+func handleLedgerRemoveCommand(ctx *CommandContext) {
+	ledgerName := ctx.Args["ledger_name"]
+	itemName := ctx.Args["item_name"]
+
+	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
+	if ledger == nil {
+		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+		return
+	}
+
+	entry := ledger.Contains(itemName)
+	if entry == nil {
+		ctx.Player.client.ShowColorizedText("That item doesn't exist on that ledger.", ColorError)
+		return
+	}
+
+	ledger.RemoveEntry(entry)
+	ctx.Character.Journal().Record(NewLedgerRemoveJournalEntry(ledger, entry))
+
+	ctx.Player.client.ShowColorizedText("Entry has been removed from the ledger.", ColorSuccess)
+}
+
+func handleLedgerShowCommand(ctx *CommandContext) {
+	ledgerName := ctx.Args["ledger_name"]
+
+	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
+	if ledger == nil {
+		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Item", header: true},
+		TableCell{content: "Buy", header: true},
+		TableCell{content: "Sell", header: true},
+		TableCell{content: "Stock", header: true},
+		TableCell{content: "Restock", header: true},
+		TableCell{content: "Elasticity", header: true},
+	)}
+
+	for _, entry := range ledger.Entries() {
+		stock := "Unlimited"
+		if entry.Stock > 0 {
+			stock = strconv.Itoa(entry.Stock)
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: entry.ItemName},
+			TableCell{content: entry.BuyPrice.String()},
+			TableCell{content: entry.SellPrice.String()},
+			TableCell{content: stock},
+			TableCell{content: entry.RestockInterval().String()},
+			TableCell{content: strconv.FormatFloat(entry.Elasticity, 'f', -1, 64)},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleLedgerSearchCommand(ctx *CommandContext) {
+	itemName := ctx.Args["item_name"]
+
+	matches := make(map[string][]string)
+
+	for _, ledger := range Armeria.ledgerManager.Ledgers() {
+		for _, entry := range ledger.Entries() {
+			if strings.Contains(strings.ToLower(entry.ItemName), strings.ToLower(itemName)) {
+				matches[ledger.Name()] = append(matches[ledger.Name()], entry.ItemName)
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		ctx.Player.client.ShowColorizedText("No matches found across all ledgers.", ColorError)
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Ledger", header: true},
+		TableCell{content: "Matched", header: true},
+	)}
+
+	for ledger, matches := range matches {
+		for _, item := range matches {
+			rows = append(rows, TableRow(
+				TableCell{content: fmt.Sprintf("[cmd=/ledger show %[1]s]%[1]s[/cmd]", ledger)},
+				TableCell{content: item},
+			))
+		}
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleLedgerSetCommand(ctx *CommandContext) {
+	property := strings.ToLower(ctx.Args["property"])
+	ledgerName := ctx.Args["ledger_name"]
+	itemName := ctx.Args["item_name"]
+	value := ctx.Args["value"]
+
+	if !misc.Contains([]string{"buy", "sell", "stock", "restock", "elasticity"}, property) {
+		ctx.Player.client.ShowColorizedText(
+			"You must set either a BUY or SELL price, the STOCK, the RESTOCK interval or the ELASTICITY.",
+			ColorError,
+		)
+		return
+	}
+
+	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
+	if ledger == nil {
+		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+		return
+	}
+
+	entry := ledger.Contains(itemName)
+	if entry == nil {
+		ctx.Player.client.ShowColorizedText("That item doesn't exist on that ledger.", ColorError)
+		return
+	}
+
+	updated := *entry
+	if property == "stock" || property == "restock" {
+		amount, err := strconv.Atoi(value)
+		if err != nil || amount < 0 {
+			ctx.Player.client.ShowColorizedText("You must set a whole number that is zero or more.", ColorError)
+			return
+		}
+
+		if property == "stock" {
+			updated.Stock = amount
+		} else {
+			updated.RestockMinutes = amount
+		}
+		setLedgerEntry(ctx, ledger, entry, updated)
+
+		ctx.Player.client.ShowColorizedText("The stock has been set on the ledger.", ColorSuccess)
+		return
+	}
+
+	if property == "elasticity" {
+		amount, err := strconv.ParseFloat(value, 64)
+		if err != nil || amount < 0 || amount >= 1 {
+			ctx.Player.client.ShowColorizedText("The elasticity must be at least 0 and less than 1.", ColorError)
+			return
+		}
+		updated.Elasticity = amount
+		setLedgerEntry(ctx, ledger, entry, updated)
+		ctx.Player.client.ShowColorizedText("The elasticity has been set on the ledger.", ColorSuccess)
+		return
+	}
+
+	amount, err := ParseMoney(value)
+	if err != nil || amount < 0 {
+		ctx.Player.client.ShowColorizedText("You must set a numerical price.", ColorError)
+		return
+	}
+
+	if property == "buy" {
+		updated.BuyPrice = amount
+	} else {
+		updated.SellPrice = amount
+	}
+	setLedgerEntry(ctx, ledger, entry, updated)
+
+	ctx.Player.client.ShowColorizedText("The price has been set on the ledger.", ColorSuccess)
+}
+
+// setLedgerEntry replaces the prices and stock of a ledger entry, recording the change in the Character's journal.
+func setLedgerEntry(ctx *CommandContext, l *Ledger, le *LedgerEntry, updated LedgerEntry) {
+	ctx.Character.Journal().Record(NewLedgerSetJournalEntry(l, le, updated))
+	*le = updated
+}
+
+func handleBuyCommand(ctx *CommandContext) {
+	mobName := ctx.Args["npc"]
+	itemName := ctx.Args["item"]
+
+	// Ensure mob is present in the room
+	result := ctx.Character.Room().Here().GetByName(mobName)
+	if result.Type != RegistryTypeMobInstance {
+		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		return
+	}
+	mobInstance := result.Object.(*MobInstance)
+
+	// Ensure mob is aware of a ledger that contains the item
+	var item *ItemInstance
+	var itemLedger *LedgerEntry
+	for _, ledger := range mobInstance.ItemLedgers() {
+		ledgerEntry := ledger.Contains(itemName)
+		if ledgerEntry != nil {
+			itemLedger = ledgerEntry
+			mobInstance.Inventory().PopulateFromLedger(ledger)
+			mobInstance.RestockFromLedger(ledger)
+			if result := mobInstance.Inventory().GetByName(ledgerEntry.ItemName); result.Type == RegistryTypeItemInstance {
+				item = result.Object.(*ItemInstance)
+				break
+			}
+		}
+	}
+	if item == nil || itemLedger == nil || itemLedger.BuyPrice == 0 {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s does not have that to sell.", mobInstance.Name()), ColorError)
+		return
+	}
+
+	// Ensure character has room in their inventory
+	if !ctx.Character.Inventory().HasRoomFor(item, 1) {
+		ctx.Player.client.ShowColorizedText(CommonInventoryFilled, ColorError)
+		return
+	}
+
+	// Ensure character can afford it
+	price := mobInstance.ShopBuyPrice(itemLedger)
+	if ctx.Character.Money() < price {
+		ctx.Player.client.ShowColorizedText("You can't afford that.", ColorError)
+		return
+	}
+
+	// Transfer one of the item, and then the money, putting the item back if either fails
+	stack, err := mobInstance.Inventory().MoveItem(item, 1, ctx.Character.Inventory())
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("Something went wrong with the transaction.", ColorError)
+		return
+	}
+	if err := TransferMoney(ctx.Character, MoneyWorld, price, MoneyReasonShopBuy); err != nil {
+		_, _ = ctx.Character.Inventory().MoveItem(stack, 1, mobInstance.Inventory())
+		ctx.Player.client.ShowColorizedText("You can't afford that.", ColorError)
+		return
+	}
+
+	mobInstance.AdjustShopSupply(itemLedger.ItemName, -1)
+
+	ctx.Player.client.SyncMoney()
+	ctx.Player.client.SyncInventory()
+	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You bought a %s from %s for %s.",
+			stack.FormattedName(),
+			mobInstance.FormattedName(),
+			ctx.Character.Colorize(price.String(), ColorMoney),
+		),
+		ColorSuccess,
+	)
+
+	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+		c.Player().client.ShowText(
+			fmt.Sprintf(
+				"%s bought something from %s.",
+				ctx.Character.FormattedName(),
+				mobInstance.FormattedName(),
+			),
+		)
+	}
+}
+
+func handleSellCommand(ctx *CommandContext) {
+	mobName := ctx.Args["npc"]
+	itemName := ctx.Args["item"]
+
+	// Ensure mob is present in the room
+	result := ctx.Character.Room().Here().GetByName(mobName)
+	if result.Type != RegistryTypeMobInstance {
+		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		return
+	}
+	mobInstance := result.Object.(*MobInstance)
+
+	// Ensure item exists in the character's inventory
+	var item *ItemInstance
+	if result := ctx.Character.Inventory().GetByAny(itemName); result.Type == RegistryTypeItemInstance {
+		item = result.Object.(*ItemInstance)
+	}
+	if item == nil {
+		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		return
+	} else if item.HasContents() {
+		ctx.Player.client.ShowColorizedText(CommonContainerNotEmpty, ColorError)
+		return
+	}
+
+	// Ensure mob is aware of a ledger that contains the item
+	var itemLedger *LedgerEntry
+	for _, ledger := range mobInstance.ItemLedgers() {
+		ledgerEntry := ledger.Contains(item.Name())
+		if ledgerEntry != nil {
+			itemLedger = ledgerEntry
+			break
+		}
+	}
+	if itemLedger == nil || itemLedger.SellPrice == 0 {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s does not want that item.", mobInstance.Name()), ColorError)
+		return
+	}
+
+	// Add money to the character
+	price := mobInstance.ShopSellPrice(itemLedger)
+	if price <= 0 {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s does not want that item.", mobInstance.Name()), ColorError)
+		return
+	}
+	if err := TransferMoney(MoneyWorld, ctx.Character, price, MoneyReasonShopSell); err != nil {
+		ctx.Player.client.ShowColorizedText("Something went wrong with the transaction.", ColorError)
+		return
+	}
+
+	// Destroy one of the item
+	sold := item.Split(1)
+	if sold == item {
+		ctx.Character.Inventory().Remove(item.ID())
+	}
+	sold.Delete()
+
+	mobInstance.AdjustShopSupply(itemLedger.ItemName, 1)
+
+	ctx.Player.client.SyncMoney()
+	ctx.Player.client.SyncInventory()
+	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You sold a %s to %s for %s.",
+			item.FormattedName(),
+			mobInstance.FormattedName(),
+			ctx.Character.Colorize(price.String(), ColorMoney),
+		),
+		ColorSuccess,
+	)
+
+	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+		c.Player().client.ShowText(
+			fmt.Sprintf(
+				"%s sold something to %s.",
+				ctx.Character.FormattedName(),
+				mobInstance.FormattedName(),
+			),
+		)
+	}
+}
+
+// parseMoneyAmount parses a positive amount of money.
+func parseMoneyAmount(s string) (Money, bool) {
+	amount, err := ParseMoney(s)
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+
+	return amount, true
+}
+
+// parseItemQuantity splits an optional quantity off the front of an item argument (ie: "5 apple"). A quantity of 0
+// is returned when one isn't specified, which means the whole stack.
+func parseItemQuantity(s string) (string, int) {
+	parts := strings.SplitN(s, " ", 2)
+	if len(parts) == 2 {
+		if qty, err := strconv.Atoi(parts[0]); err == nil && qty > 0 {
+			return parts[1], qty
+		}
+	}
+
+	return s, 0
+}
+
+// bankerHere returns a mob, in the same room as the character, that can handle banking.
+func bankerHere(c *Character) *MobInstance {
+	for _, mi := range c.Room().Here().Mobs() {
+		if mi.Banker() {
+			return mi
+		}
+	}
+
+	return nil
+}
+
+func handleDepositCommand(ctx *CommandContext) {
+	banker := bankerHere(ctx.Character)
+	if banker == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoBankerHere, ColorError)
+		return
+	}
+
+	card := ctx.Character.BankCard()
+	if card == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoBankCard, ColorError)
+		return
+	}
+
+	amount, ok := parseMoneyAmount(ctx.Args["amount"])
+	if !ok {
+		ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
+		return
+	}
+
+	ba := Armeria.bankManager.AccountForCard(card, ctx.Character)
+	if err := TransferMoney(ctx.Character, ba, amount, MoneyReasonBankDeposit); err != nil {
+		ctx.Player.client.ShowColorizedText("You don't have that much money.", ColorError)
+		return
+	}
+
+	ctx.Player.client.SyncMoney()
+	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You deposited %s with %s. Your balance is now %s.",
+			ctx.Character.Colorize(amount.String(), ColorMoney),
+			banker.FormattedName(),
+			ctx.Character.Colorize(ba.Money().String(), ColorMoney),
+		),
+		ColorSuccess,
+	)
+}
+
+// repairerHere returns a mob, in the same room as the character, that can repair items.
+func repairerHere(c *Character) *MobInstance {
+	for _, mi := range c.Room().Here().Mobs() {
+		if mi.Repairer() {
+			return mi
+		}
+	}
+
+	return nil
+}
+
+func handleRepairCommand(ctx *CommandContext) {
+	repairer := repairerHere(ctx.Character)
+	if repairer == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoRepairerHere, ColorError)
+		return
+	}
+
+	name := ctx.Args["item"]
+	if len(name) == 0 {
+		rows := []string{TableRow(
+			TableCell{content: "Item", header: true},
+			TableCell{content: "Condition", header: true},
+			TableCell{content: "Cost", header: true},
+		)}
+
+		items := append(ctx.Character.Equipment().Items(), ctx.Character.Inventory().Items()...)
+		for _, ii := range items {
+			if !ii.Damaged() {
+				continue
+			}
+
+			cost := "-"
+			if c, ok := repairer.RepairCost(ii); ok {
+				cost = ctx.Character.Colorize(c.String(), ColorMoney)
+			}
+
+			rows = append(rows, TableRow(
+				TableCell{content: TextStyle(ii.Name(), WithLinkCmd(fmt.Sprintf("/repair %s", ii.ID())))},
+				TableCell{content: fmt.Sprintf("%d / %d (%s)", ii.Condition(), ii.MaxDurability(), ii.ConditionName())},
+				TableCell{content: cost},
+			))
+		}
+
+		if len(rows) == 1 {
+			ctx.Player.client.ShowText("None of your items need repairing.")
+			return
+		}
+
+		ctx.Player.client.ShowText(TextTable(rows...))
+		return
+	}
+
+	var item *ItemInstance
+	if r := ctx.Character.Inventory().GetByAny(name); r.Type == RegistryTypeItemInstance {
+		item = r.Object.(*ItemInstance)
+	} else if r := ctx.Character.Equipment().GetByAny(name); r.Type == RegistryTypeItemInstance {
+		item = r.Object.(*ItemInstance)
+	}
+
+	if item == nil {
+		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		return
+	} else if !item.Damaged() {
+		ctx.Player.client.ShowColorizedText("That doesn't need repairing.", ColorError)
+		return
+	}
+
+	cost, ok := repairer.RepairCost(item)
+	if !ok {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("%s doesn't know how to repair that.", repairer.FormattedName()),
+			ColorError,
+		)
+		return
+	}
+
+	if err := TransferMoney(ctx.Character, MoneyWorld, cost, MoneyReasonRepair); err != nil {
+		ctx.Player.client.ShowColorizedText("You can't afford to repair that.", ColorError)
+		return
+	}
+
+	item.SetCondition(item.MaxDurability())
+
+	ctx.Player.client.SyncMoney()
+	ctx.Player.client.SyncInventory()
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"%s repaired your %s for %s.",
+			repairer.FormattedName(),
+			item.FormattedName(),
+			ctx.Character.Colorize(cost.String(), ColorMoney),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleWithdrawCommand(ctx *CommandContext) {
+	banker := bankerHere(ctx.Character)
+	if banker == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoBankerHere, ColorError)
+		return
+	}
+
+	card := ctx.Character.BankCard()
+	if card == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoBankCard, ColorError)
+		return
+	}
+
+	amount, ok := parseMoneyAmount(ctx.Args["amount"])
+	if !ok {
+		ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
+		return
+	}
+
+	ba := Armeria.bankManager.AccountByCard(card.ID())
+	if ba == nil || TransferMoney(ba, ctx.Character, amount, MoneyReasonBankWithdrawal) != nil {
+		ctx.Player.client.ShowColorizedText("You don't have that much money in your account.", ColorError)
+		return
+	}
+
+	ctx.Player.client.SyncMoney()
+	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You withdrew %s from %s. Your balance is now %s.",
+			ctx.Character.Colorize(amount.String(), ColorMoney),
+			banker.FormattedName(),
+			ctx.Character.Colorize(ba.Money().String(), ColorMoney),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleBalanceCommand(ctx *CommandContext) {
+	card := ctx.Character.BankCard()
+	if card == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoBankCard, ColorError)
+		return
+	}
+
+	ba := Armeria.bankManager.AccountByCard(card.ID())
+	if ba == nil {
 		ctx.Player.client.ShowColorizedText(
-			fmt.Sprintf("You put a %s into the %s. Goodbye!",
-				ii.FormattedName(),
-				targetResult.Object.(*ItemInstance).FormattedName(),
+			"Your bank card isn't tied to an account yet. Visit a banker to open one.",
+			ColorError,
+		)
+		return
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"The balance of your account is %s.",
+			ctx.Character.Colorize(ba.Money().String(), ColorMoney),
+		),
+	)
+
+	txs := ba.Transactions()
+	if len(txs) == 0 {
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Time", header: true},
+		TableCell{content: "Type", header: true},
+		TableCell{content: "Amount", header: true},
+		TableCell{content: "Balance", header: true},
+		TableCell{content: "Counterparty", header: true},
+	)}
+
+	for i := len(txs) - 1; i >= 0 && i >= len(txs)-10; i-- {
+		tx := txs[i]
+		rows = append(rows, TableRow(
+			TableCell{content: tx.Time.Format("2006-01-02 15:04:05")},
+			TableCell{content: tx.Type},
+			TableCell{content: tx.Amount.String()},
+			TableCell{content: tx.Balance.String()},
+			TableCell{content: tx.Counterparty},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleTransferCommand(ctx *CommandContext) {
+	card := ctx.Character.BankCard()
+	if card == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoBankCard, ColorError)
+		return
+	}
+
+	from := Armeria.bankManager.AccountByCard(card.ID())
+	if from == nil {
+		ctx.Player.client.ShowColorizedText(
+			"Your bank card isn't tied to an account yet. Visit a banker to open one.",
+			ColorError,
+		)
+		return
+	}
+
+	target := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if target == nil {
+		ctx.Player.client.ShowColorizedText("There is no character by that name.", ColorError)
+		return
+	}
+
+	amount, ok := parseMoneyAmount(ctx.Args["amount"])
+	if !ok {
+		ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
+		return
+	}
+
+	to := Armeria.bankManager.AccountForCharacter(target)
+	if to == nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("%s doesn't have a bank account.", target.FormattedName()),
+			ColorError,
+		)
+		return
+	}
+
+	err := Armeria.bankManager.Transfer(from, to, amount)
+	if err == ErrInsufficientFunds {
+		ctx.Player.client.ShowColorizedText("You don't have that much money in your account.", ColorError)
+		return
+	} else if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The transfer could not be made: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You transferred %s to %s. Your balance is now %s.",
+			ctx.Character.Colorize(amount.String(), ColorMoney),
+			target.FormattedName(),
+			ctx.Character.Colorize(from.Money().String(), ColorMoney),
+		),
+		ColorSuccess,
+	)
+
+	if target.Online() && target != ctx.Character {
+		target.Player().client.ShowText(
+			fmt.Sprintf(
+				"%s transferred %s to your bank account.",
+				ctx.Character.FormattedName(),
+				target.Colorize(amount.String(), ColorMoney),
 			),
-			ColorSuccess,
 		)
-		ctx.Player.client.SyncInventory()
-		for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
-			c.Player().client.ShowText(
+	}
+}
+
+// currentTrade returns the open trade the character is taking part in, showing an error if there isn't one.
+func currentTrade(ctx *CommandContext) *Trade {
+	t := ctx.Character.Trade()
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("You aren't trading with anyone.", ColorError)
+		return nil
+	} else if !t.IsOpen() {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("%s hasn't joined the trade yet.", t.Other(ctx.Character).FormattedName()),
+			ColorError,
+		)
+		return nil
+	}
+
+	return t
+}
+
+// showTradeWindow shows a message, along with both offers, to each character in a trade.
+func showTradeWindow(t *Trade, msg string) {
+	for _, c := range []*Character{t.Initiator, t.Partner} {
+		if c.Online() {
+			c.Player().client.ShowText(fmt.Sprintf("%s\n%s", msg, t.Window(c)))
+		}
+	}
+}
+
+func handleTradeWithCommand(ctx *CommandContext) {
+	result := ctx.Character.Room().Here().GetByName(ctx.Args["character"])
+	if result.Type != RegistryTypeCharacter {
+		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		return
+	}
+
+	partner := result.Object.(*Character)
+	if partner == ctx.Character {
+		ctx.Player.client.ShowColorizedText("You cannot trade with yourself.", ColorError)
+		return
+	}
+
+	if existing := ctx.Character.Trade(); existing != nil {
+		if existing.IsOpen() {
+			ctx.Player.client.ShowColorizedText(
 				fmt.Sprintf(
-					"%s put an item into the %s.",
-					ctx.Character.FormattedName(),
-					targetResult.Object.(*ItemInstance).FormattedName(),
+					"You are already trading with %s. Use %s first.",
+					existing.Other(ctx.Character).FormattedName(),
+					TextStyle("/trade cancel", WithLinkCmd("/trade cancel")),
 				),
+				ColorError,
+			)
+			return
+		} else if existing.Partner == partner {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("You already asked %s to trade.", partner.FormattedName()),
+				ColorError,
 			)
+			return
 		}
+
+		// Withdraw the earlier request in favour of this one.
+		ctx.Character.SetTrade(nil)
+		existing.Close()
+	}
+
+	if t := partner.Trade(); t != nil && t.Partner == ctx.Character && !t.IsOpen() {
+		t.Open()
+		ctx.Character.SetTrade(t)
+		showTradeWindow(t, fmt.Sprintf(
+			"%s and %s are now trading. Use %s to make an offer.",
+			partner.FormattedName(),
+			ctx.Character.FormattedName(),
+			TextStyle("/trade add", WithBold()),
+		))
 		return
-	} else if targetResult.Type != RegistryTypeCharacter && targetResult.Type != RegistryTypeMobInstance {
-		ctx.Player.client.ShowColorizedText("You can only give things to other characters or mobs!", ColorError)
+	} else if t != nil && t.IsOpen() {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("%s is busy trading with someone else.", partner.FormattedName()),
+			ColorError,
+		)
 		return
-	} else if targetResult.Object.ID() == ctx.Character.ID() {
-		ctx.Player.client.ShowColorizedText("You cannot give things to yourself.", ColorError)
+	}
+
+	ctx.Character.SetTrade(NewTrade(ctx.Character, partner))
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You asked %s to trade with you.", partner.FormattedName()),
+		ColorSuccess,
+	)
+
+	if partner.Ignores(ctx.Character) {
+		return
+	}
+
+	partner.Player().client.ShowText(
+		fmt.Sprintf(
+			"%s would like to trade with you. %s",
+			ctx.Character.FormattedName(),
+			TextStyle("Start Trading", WithLinkCmd(fmt.Sprintf("/trade with \"%s\"", ctx.Character.Name()))),
+		),
+	)
+}
+
+func handleTradeAddCommand(ctx *CommandContext) {
+	t := currentTrade(ctx)
+	if t == nil {
+		return
+	}
+
+	result := ctx.Character.Inventory().GetByAny(ctx.Args["item"])
+	if result.Type != RegistryTypeItemInstance {
+		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		return
+	}
+
+	ii := result.Object.(*ItemInstance)
+	if err := t.AddItem(ctx.Character, ii); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't offer that: %s.", err), ColorError)
+		return
+	}
+
+	showTradeWindow(t, fmt.Sprintf("%s offered a %s.", ctx.Character.FormattedName(), ii.FormattedName()))
+}
+
+func handleTradeRemoveCommand(ctx *CommandContext) {
+	t := currentTrade(ctx)
+	if t == nil {
+		return
+	}
+
+	result := ctx.Character.Inventory().GetByAny(ctx.Args["item"])
+	if result.Type != RegistryTypeItemInstance {
+		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		return
+	}
+
+	ii := result.Object.(*ItemInstance)
+	if err := t.RemoveItem(ctx.Character, ii); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't remove that: %s.", err), ColorError)
+		return
+	}
+
+	showTradeWindow(t, fmt.Sprintf("%s withdrew a %s.", ctx.Character.FormattedName(), ii.FormattedName()))
+}
+
+func handleTradeMoneyCommand(ctx *CommandContext) {
+	t := currentTrade(ctx)
+	if t == nil {
+		return
+	}
+
+	var amount Money
+	if ctx.Args["amount"] != "0" {
+		var ok bool
+		if amount, ok = parseMoneyAmount(ctx.Args["amount"]); !ok {
+			ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
+			return
+		}
+	}
+
+	if err := t.SetMoney(ctx.Character, amount); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't offer that: %s.", err), ColorError)
+		return
+	}
+
+	showTradeWindow(t, fmt.Sprintf(
+		"%s offered %s.",
+		ctx.Character.FormattedName(),
+		ctx.Character.Colorize(amount.String(), ColorMoney),
+	))
+}
+
+func handleTradeShowCommand(ctx *CommandContext) {
+	t := currentTrade(ctx)
+	if t == nil {
+		return
+	}
+
+	ctx.Player.client.ShowText(t.Window(ctx.Character))
+}
+
+func handleTradeAcceptCommand(ctx *CommandContext) {
+	t := currentTrade(ctx)
+	if t == nil {
+		return
+	}
+
+	done, err := t.Accept(ctx.Character)
+	if err == ErrContainerNoRoom {
+		showTradeWindow(t, TextStyle(
+			"The trade could not be completed because an inventory is full. Nothing has changed hands.",
+			WithItalics(),
+		))
+		return
+	} else if err != nil {
+		showTradeWindow(t, TextStyle(
+			fmt.Sprintf("The trade could not be completed because %s. Nothing has changed hands.", err),
+			WithItalics(),
+		))
+		return
+	}
+
+	if !done {
+		showTradeWindow(t, fmt.Sprintf("%s accepted the trade.", ctx.Character.FormattedName()))
+		return
+	}
+
+	for _, c := range []*Character{t.Initiator, t.Partner} {
+		c.SetTrade(nil)
+		c.Player().client.SyncInventory()
+		c.Player().client.SyncMoney()
+		c.Player().client.PlaySFX(sfx.SellBuyItem)
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf("Your trade with %s is complete.", t.Other(c).FormattedName()),
+			ColorSuccess,
+		)
+	}
+}
+
+func handleTradeCancelCommand(ctx *CommandContext) {
+	t := ctx.Character.Trade()
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("You aren't trading with anyone.", ColorError)
+		return
+	}
+
+	ctx.Character.SetTrade(nil)
+	CancelTrade(t, fmt.Sprintf("%s cancelled the trade.", ctx.Character.Name()))
+
+	ctx.Player.client.ShowColorizedText("You cancelled the trade.", ColorSuccess)
+}
+
+// mailFromArgs returns the mail specified by the number argument, showing an error if there isn't one.
+func mailFromArgs(ctx *CommandContext) *Mail {
+	mail := ctx.Character.Mail()
+	n, err := strconv.Atoi(strings.TrimPrefix(ctx.Args["number"], "#"))
+	if err != nil || n < 1 || n > len(mail) {
+		ctx.Player.client.ShowColorizedText("There is no mail in your mailbox with that number.", ColorError)
+		return nil
+	}
+
+	return mail[n-1]
+}
+
+func handleMailListCommand(ctx *CommandContext) {
+	mail := ctx.Character.Mail()
+	if len(mail) == 0 {
+		ctx.Player.client.ShowText("Your mailbox is empty.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "#", header: true},
+		TableCell{content: "From", header: true},
+		TableCell{content: "Subject", header: true},
+		TableCell{content: "Sent", header: true},
+		TableCell{content: "Attachments", header: true},
+	)}
+
+	for i, m := range mail {
+		subject := TextStyle(m.Subject(), WithLinkCmd(fmt.Sprintf("/mail read %d", i+1)))
+		if !m.Read() {
+			subject = TextStyle(subject, WithBold())
+		}
+
+		var attachments []string
+		if count := m.Attachments().Count(); count > 0 {
+			attachments = append(attachments, fmt.Sprintf("%d items", count))
+		}
+		if m.Money() > 0 {
+			attachments = append(attachments, ctx.Character.Colorize(m.Money().String(), ColorMoney))
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: strconv.Itoa(i + 1)},
+			TableCell{content: m.From()},
+			TableCell{content: subject},
+			TableCell{content: m.Sent().Format("2006-01-02 15:04")},
+			TableCell{content: strings.Join(attachments, ", ")},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleMailReadCommand(ctx *CommandContext) {
+	m := mailFromArgs(ctx)
+	if m == nil {
+		return
+	}
+
+	m.SetRead(true)
+
+	text := fmt.Sprintf(
+		"From: %s\nSent: %s\nSubject: %s",
+		TextStyle(m.From(), WithBold()),
+		m.Sent().Format("Mon Jan 2 2006 15:04:05 MST"),
+		TextStyle(m.Subject(), WithBold()),
+	)
+	if len(m.Body()) > 0 {
+		text = fmt.Sprintf("%s\n\n%s", text, m.Body())
+	}
+	ctx.Player.client.ShowText(text)
+
+	if !m.HasAttachments() {
+		return
+	}
+
+	taken, money := ctx.Character.TakeAttachments(m)
+	if len(taken) > 0 {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You took %s from the mail.", strings.Join(taken, ", ")),
+			ColorSuccess,
+		)
+		ctx.Player.client.SyncInventory()
+	}
+	if money > 0 {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You took %s from the mail.", ctx.Character.Colorize(money.String(), ColorMoney)),
+			ColorSuccess,
+		)
+		ctx.Player.client.SyncMoney()
+	}
+	if m.HasAttachments() {
+		ctx.Player.client.ShowColorizedText(
+			"You don't have room in your inventory for everything attached. Read the mail again once you've made room.",
+			ColorError,
+		)
+	}
+}
+
+func handleMailAttachCommand(ctx *CommandContext) {
+	result := ctx.Character.Inventory().GetByAny(ctx.Args["item"])
+	if result.Type != RegistryTypeItemInstance {
+		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
 		return
 	}
+	ii := result.Object.(*ItemInstance)
 
-	// set the target object container
-	var toc *ObjectContainer
-	if targetResult.Type == RegistryTypeCharacter {
-		toc = targetResult.Object.(*Character).Inventory()
-	} else {
-		toc = targetResult.Object.(*MobInstance).Inventory()
+	draft := ctx.Character.MailDraft()
+	for _, a := range draft.Items {
+		if a.ID() == ii.ID() {
+			ctx.Player.client.ShowColorizedText("That item is already attached.", ColorError)
+			return
+		}
 	}
 
-	// check if the target object container can hold it
-	if toc.MaxSize() > 0 && toc.Count() >= toc.MaxSize() {
+	if len(draft.Items) >= MaxMailAttachments {
 		ctx.Player.client.ShowColorizedText(
-			fmt.Sprintf(
-				"%s does not have enough room to hold that!",
-				targetResult.Object.FormattedName(),
-			),
+			fmt.Sprintf("You can only attach up to %d items to a mail.", MaxMailAttachments),
 			ColorError,
 		)
 		return
 	}
 
-	// check if the mob can handle it?
-	if targetResult.Type == RegistryTypeMobInstance {
-		if !misc.Contains(toc.ParentMobInstance().Parent.ScriptFuncs(), "received_item") {
-			ctx.Player.client.ShowColorizedText(
-				fmt.Sprintf(
-					"%s does not want that.",
-					toc.ParentMobInstance().FormattedName(),
-				),
-				ColorError,
-			)
-			return
-		}
-	}
+	draft.Items = append(draft.Items, ii)
 
-	ii := itemResult.Object.(*ItemInstance)
-	tco := targetResult.Object
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("A %s will be attached to the next mail you send.", ii.FormattedName()),
+		ColorSuccess,
+	)
+}
 
-	// remove item from source
-	ctx.Character.Inventory().Remove(ii.ID())
+func handleMailMoneyCommand(ctx *CommandContext) {
+	amount, err := ParseMoney(ctx.Args["amount"])
+	if err != nil || amount < 0 {
+		ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
+		return
+	} else if amount > ctx.Character.Money() {
+		ctx.Player.client.ShowColorizedText("You don't have that much money.", ColorError)
+		return
+	}
 
-	// add item to target
-	_ = toc.Add(ii.ID())
+	ctx.Character.MailDraft().Money = amount
 
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf(
-			"You gave %s a %s.",
-			tco.FormattedName(),
-			ii.FormattedName(),
+			"%s will be attached to the next mail you send.",
+			ctx.Character.Colorize(amount.String(), ColorMoney),
 		),
 		ColorSuccess,
 	)
-	ctx.Player.client.SyncInventory()
+}
 
-	if targetResult.Type == RegistryTypeCharacter {
-		targetResult.Object.(*Character).Player().client.ShowText(
+func handleMailSendCommand(ctx *CommandContext) {
+	to := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if to == nil {
+		ctx.Player.client.ShowColorizedText("There is no character by that name.", ColorError)
+		return
+	} else if to.ID() == ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("You can't send mail to yourself.", ColorError)
+		return
+	}
+
+	m, err := SendMail(ctx.Character, to, ctx.Args["subject"], ctx.Args["message"], ctx.Character.MailDraft())
+	if err == ErrMailboxFull {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s's mailbox is full.", to.FormattedName()), ColorError)
+		return
+	} else if err == ErrMailItemMissing {
+		ctx.Player.client.ShowColorizedText("An item you attached is no longer in your inventory.", ColorError)
+		return
+	} else if err == ErrInsufficientFunds {
+		ctx.Player.client.ShowColorizedText("You don't have enough money for the money you attached.", ColorError)
+		return
+	} else if err != nil {
+		ctx.Player.client.ShowColorizedText("Your mail couldn't be sent right now.", ColorError)
+		return
+	}
+
+	ctx.Character.ClearMailDraft()
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You sent mail to %s.", to.FormattedName()),
+		ColorSuccess,
+	)
+	if m.HasAttachments() {
+		ctx.Player.client.SyncInventory()
+		ctx.Player.client.SyncMoney()
+	}
+
+	if to.Online() {
+		to.Player().client.ShowText(
 			fmt.Sprintf(
-				"%s gave you a %s.",
+				"You have %s from %s.",
+				TextStyle("new mail", WithBold(), WithLinkCmd("/mail list")),
 				ctx.Character.FormattedName(),
-				ii.FormattedName(),
 			),
 		)
-		targetResult.Object.(*Character).Player().client.SyncInventory()
-	} else if targetResult.Type == RegistryTypeMobInstance {
-		go CallMobFunc(
-			ctx.Character,
-			targetResult.Object.(*MobInstance),
-			"received_item",
-			lua.LString(ctx.Character.ID()),
-			lua.LString(ii.ID()),
-		)
 	}
+}
 
-	roomExceptions := []*Character{ctx.Character}
-	if targetResult.Type == RegistryTypeCharacter {
-		roomExceptions = append(roomExceptions, targetResult.Object.(*Character))
+func handleMailDeleteCommand(ctx *CommandContext) {
+	m := mailFromArgs(ctx)
+	if m == nil {
+		return
 	}
-	for _, c := range ctx.Character.Room().Here().Characters(true, roomExceptions...) {
-		c.Player().client.ShowText(
-			fmt.Sprintf(
-				"%s gave %s something.",
-				ctx.Character.FormattedName(),
-				tco.FormattedName(),
-			),
+
+	if m.HasAttachments() {
+		ctx.Player.client.ShowColorizedText(
+			"You must read the mail and take its attachments before deleting it.",
+			ColorError,
 		)
+		return
 	}
+
+	ctx.Character.RemoveMail(m)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You deleted the mail from %s.", TextStyle(m.From(), WithBold())),
+		ColorSuccess,
+	)
 }
 
-func handleEmoteCommand(ctx *CommandContext) {
-	emotion := ctx.Args["emote"]
+// auctioneerHere returns a mob, in the same room as the character, that can handle the auction house.
+func auctioneerHere(c *Character) *MobInstance {
+	for _, mi := range c.Room().Here().Mobs() {
+		if mi.Auctioneer() {
+			return mi
+		}
+	}
 
-	if emotion[len(emotion)-1:] == "." {
-		emotion = emotion[:len(emotion)-1]
+	return nil
+}
+
+// auctionFromArgs returns the auction specified by the auction argument, showing an error if there isn't one.
+func auctionFromArgs(ctx *CommandContext) *Auction {
+	id, err := strconv.Atoi(strings.TrimPrefix(ctx.Args["auction"], "#"))
+	if err == nil {
+		if a := Armeria.auctionManager.AuctionByID(id); a != nil {
+			return a
+		}
 	}
 
-	for _, c := range ctx.Character.Room().Here().Characters(true) {
-		c.Player().client.ShowText(
-			fmt.Sprintf("%s %s.", ctx.Character.FormattedName(), emotion),
+	ctx.Player.client.ShowColorizedText(CommonAuctionNotFound, ColorError)
+	return nil
+}
+
+// showAuctionError shows a friendly message for an error returned by the auction house.
+func showAuctionError(ctx *CommandContext, err error) {
+	var msg string
+	switch err {
+	case ErrAuctionEnded:
+		msg = "That auction has already ended."
+	case ErrAuctionOwnItem:
+		msg = "You can't bid on your own auction."
+	case ErrAuctionBidTooLow:
+		msg = "Your bid is too low."
+	case ErrAuctionNoBuyout:
+		msg = "That auction doesn't have a buyout price."
+	case ErrAuctionHasBids:
+		msg = "You can't cancel an auction that already has bids."
+	case ErrAuctionNotSeller:
+		msg = "You can only cancel your own auctions."
+	case ErrAuctionInvalidPrice:
+		msg = "The buyout price can't be lower than the starting price."
+	case ErrAuctionInvalidDuration:
+		msg = fmt.Sprintf(
+			"Auctions can run for between %d hour and %d days.",
+			int(MinAuctionDuration.Hours()),
+			int(MaxAuctionDuration.Hours()/24),
 		)
+	case ErrInsufficientFunds:
+		msg = "You don't have enough money."
+	default:
+		msg = "The auction house couldn't handle that right now."
 	}
+
+	ctx.Player.client.ShowColorizedText(msg, ColorError)
 }
 
-func handleLedgerListCommand(ctx *CommandContext) {
+func handleAuctionListCommand(ctx *CommandContext) {
+	if auctioneerHere(ctx.Character) == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoAuctioneerHere, ColorError)
+		return
+	}
+
+	auctions := Armeria.auctionManager.Auctions()
+	if len(auctions) == 0 {
+		ctx.Player.client.ShowText("There are no items up for auction.")
+		return
+	}
+
 	rows := []string{TableRow(
-		TableCell{content: "Ledger", header: true},
-		TableCell{content: "Items", header: true},
+		TableCell{content: "#", header: true},
+		TableCell{content: "Item", header: true},
+		TableCell{content: "Seller", header: true},
+		TableCell{content: "Bid", header: true},
+		TableCell{content: "Buyout", header: true},
+		TableCell{content: "Time Left", header: true},
 	)}
 
-	for _, l := range Armeria.ledgerManager.Ledgers() {
+	for _, a := range auctions {
+		seller := "unknown"
+		if s := a.Seller(); s != nil {
+			seller = s.FormattedName()
+		}
+
+		bid := fmt.Sprintf("%s (starting)", a.StartingPrice().String())
+		if b := a.Bidder(); b != nil {
+			bid = fmt.Sprintf("%s (%s)", a.Bid().String(), b.FormattedName())
+		}
+
+		buyout := "-"
+		if a.BuyoutPrice() > 0 {
+			buyout = TextStyle(a.BuyoutPrice().String(), WithLinkCmd(fmt.Sprintf("/auction buyout %d", a.ID)))
+		}
+
 		rows = append(rows, TableRow(
-			TableCell{content: fmt.Sprintf("[cmd=/ledger show %[1]s]%[1]s[/cmd]", l.Name())},
-			TableCell{content: fmt.Sprintf("%d items", len(l.Entries()))},
+			TableCell{content: strconv.Itoa(a.ID)},
+			TableCell{content: a.ItemName()},
+			TableCell{content: seller},
+			TableCell{content: ctx.Character.Colorize(bid, ColorMoney)},
+			TableCell{content: buyout},
+			TableCell{content: a.TimeLeft()},
 		))
 	}
 
 	ctx.Player.client.ShowText(TextTable(rows...))
 }
 
-func handleLedgerCreateCommand(ctx *CommandContext) {
-	name := ctx.Args["name"]
+func handleAuctionSellCommand(ctx *CommandContext) {
+	if auctioneerHere(ctx.Character) == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoAuctioneerHere, ColorError)
+		return
+	}
 
-	if strings.Contains(name, " ") {
-		ctx.Player.client.ShowColorizedText("The ledger name cannot contain a space.", ColorError)
+	result := ctx.Character.Inventory().GetByAny(ctx.Args["item"])
+	if result.Type != RegistryTypeItemInstance {
+		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
 		return
 	}
+	ii := result.Object.(*ItemInstance)
 
-	exists := Armeria.ledgerManager.LedgerByName(name)
-	if exists != nil {
-		ctx.Player.client.ShowColorizedText("A ledger already exists with that name.", ColorError)
+	price, ok := parseMoneyAmount(ctx.Args["price"])
+	if !ok {
+		ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
 		return
 	}
 
-	l := Armeria.ledgerManager.CreateLedger(name)
-	Armeria.ledgerManager.AddLedger(l)
+	duration := DefaultAuctionDuration
+	if len(ctx.Args["duration"]) > 0 {
+		d, err := misc.ParseDuration(ctx.Args["duration"])
+		if err != nil {
+			ctx.Player.client.ShowColorizedText("That's not a valid duration.", ColorError)
+			return
+		}
+		duration = d
+	}
 
-	ctx.Player.client.ShowColorizedText("The ledger has been created.", ColorSuccess)
+	var buyout Money
+	if len(ctx.Args["buyout"]) > 0 {
+		buyout, ok = parseMoneyAmount(ctx.Args["buyout"])
+		if !ok {
+			ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
+			return
+		}
+	}
+
+	a, err := Armeria.auctionManager.List(ctx.Character, ii, price, buyout, duration)
+	if err != nil {
+		showAuctionError(ctx, err)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You listed a %s at the auction house as auction #%d, with bids starting at %s. The auction ends in %s.",
+			ii.FormattedName(),
+			a.ID,
+			price.String(),
+			a.TimeLeft(),
+		),
+		ColorSuccess,
+	)
+	ctx.Player.client.SyncInventory()
 }
 
-func handleLedgerRenameCommand(ctx *CommandContext) {
-	ledgerName := ctx.Args["ledger_name"]
-	newName := ctx.Args["new_name"]
+func handleAuctionBidCommand(ctx *CommandContext) {
+	if auctioneerHere(ctx.Character) == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoAuctioneerHere, ColorError)
+		return
+	}
 
-	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
-	if ledger == nil {
-		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+	a := auctionFromArgs(ctx)
+	if a == nil {
 		return
 	}
 
-	if strings.Contains(newName, " ") {
-		ctx.Player.client.ShowColorizedText("The new ledger name cannot contain a space.", ColorError)
+	amount, ok := parseMoneyAmount(ctx.Args["amount"])
+	if !ok {
+		ctx.Player.client.ShowColorizedText(CommonInvalidMoneyAmount, ColorError)
 		return
 	}
 
-	ledger.SetName(newName)
+	minimum := a.MinimumBid()
+	if err := Armeria.auctionManager.Bid(a, ctx.Character, amount); err != nil {
+		if err == ErrAuctionBidTooLow {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("You must bid at least %s on auction #%d.", minimum.String(), a.ID),
+				ColorError,
+			)
+			return
+		}
+		showAuctionError(ctx, err)
+		return
+	}
 
-	ctx.Player.client.ShowColorizedText("The ledger has been renamed.", ColorSuccess)
+	// A bid at or above the buyout price ends the auction, and the winning message has already been shown.
+	if Armeria.auctionManager.AuctionByID(a.ID) != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You bid %s on auction #%d (%s).", amount.String(), a.ID, a.ItemName()),
+			ColorSuccess,
+		)
+	}
+	ctx.Player.client.SyncMoney()
 }
 
-func handleLedgerAddCommand(ctx *CommandContext) {
-	ledgerName := ctx.Args["ledger_name"]
-	itemName := ctx.Args["item_name"]
+func handleAuctionBuyoutCommand(ctx *CommandContext) {
+	if auctioneerHere(ctx.Character) == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoAuctioneerHere, ColorError)
+		return
+	}
 
-	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
-	if ledger == nil {
-		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+	a := auctionFromArgs(ctx)
+	if a == nil {
 		return
 	}
 
-	item := Armeria.itemManager.ItemByName(itemName)
-	if item == nil {
-		ctx.Player.client.ShowColorizedText("An item by that name doesn't exist.", ColorError)
+	if err := Armeria.auctionManager.Buyout(a, ctx.Character); err != nil {
+		showAuctionError(ctx, err)
 		return
 	}
 
-	if ledger.Contains(item.Name()) != nil {
-		ctx.Player.client.ShowColorizedText("That item is already on that ledger.", ColorError)
+	ctx.Player.client.SyncMoney()
+}
+
+func handleAuctionCancelCommand(ctx *CommandContext) {
+	if auctioneerHere(ctx.Character) == nil {
+		ctx.Player.client.ShowColorizedText(CommonNoAuctioneerHere, ColorError)
 		return
 	}
 
-	ledger.AddEntry(&LedgerEntry{
-		ItemName:  item.Name(),
-		BuyPrice:  0.00,
-		SellPrice: 0.00,
-	})
+	a := auctionFromArgs(ctx)
+	if a == nil {
+		return
+	}
 
-	ctx.Player.client.ShowColorizedText("Entry has been added to the ledger.", ColorSuccess)
+	name := a.ItemName()
+	if err := Armeria.auctionManager.Cancel(a, ctx.Character); err != nil {
+		showAuctionError(ctx, err)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You cancelled auction #%d, and the %s was returned to you.", a.ID, name),
+		ColorSuccess,
+	)
 }
 
-func handleLedgerRemoveCommand(ctx *CommandContext) {
-	ledgerName := ctx.Args["ledger_name"]
-	itemName := ctx.Args["item_name"]
+func handleCraftListCommand(ctx *CommandContext) {
+	recipes := CraftableRecipes(ctx.Character.Room())
+	if len(recipes) == 0 {
+		ctx.Player.client.ShowColorizedText("There is nothing you can craft here.", ColorError)
+		return
+	}
 
-	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
-	if ledger == nil {
-		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+	rows := []string{TableRow(
+		TableCell{content: "Recipe", header: true},
+		TableCell{content: "Creates", header: true},
+		TableCell{content: "Needs", header: true},
+		TableCell{content: "Time", header: true},
+	)}
+
+	for _, r := range recipes {
+		creates := r.Output()
+		if r.OutputCount() > 1 {
+			creates = fmt.Sprintf("%dx %s", r.OutputCount(), creates)
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(r.Name(), WithLinkCmd("/craft make "+r.Name()))},
+			TableCell{content: creates},
+			TableCell{content: r.Requirements()},
+			TableCell{content: r.Duration().String()},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleCraftMakeCommand(ctx *CommandContext) {
+	r := Armeria.recipeManager.RecipeByName(ctx.Args["recipe"])
+	if r == nil {
+		ctx.Player.client.ShowColorizedText("A recipe by that name doesn't exist.", ColorError)
+		return
+	}
+
+	if !r.Unlocked(ctx.Character.Room()) {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You need to be near a %s to craft that.", r.Workstation()),
+			ColorError,
+		)
+		return
+	}
+
+	if err := StartCrafting(ctx.Character, r); err != nil {
+		switch err {
+		case ErrAlreadyCrafting:
+			ctx.Player.client.ShowColorizedText(
+				"You are already crafting something. Use [cmd=/craft cancel]/craft cancel[/cmd] to stop.",
+				ColorError,
+			)
+		default:
+			ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't craft that: %s.", err), ColorError)
+		}
+		return
+	}
+
+	if ctx.Character.Crafting() != nil {
+		ctx.Player.client.ShowText(
+			fmt.Sprintf("You begin crafting %s. It will take %s.", TextStyle(r.Name(), WithBold()), r.Duration()),
+		)
+	}
+}
+
+func handleCraftStatusCommand(ctx *CommandContext) {
+	j := ctx.Character.Crafting()
+	if j == nil {
+		ctx.Player.client.ShowColorizedText("You aren't crafting anything.", ColorError)
 		return
 	}
 
-	entry := ledger.Contains(itemName)
-	if entry == nil {
-		ctx.Player.client.ShowColorizedText("That item doesn't exist on that ledger.", ColorError)
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"You are crafting %s. It is %d%% complete, with %s left.",
+			TextStyle(j.Recipe.Name(), WithBold()),
+			j.Progress(),
+			time.Until(j.Completes).Round(time.Second),
+		),
+	)
+}
+
+func handleCraftCancelCommand(ctx *CommandContext) {
+	j := ctx.Character.Crafting()
+	if j == nil {
+		ctx.Player.client.ShowColorizedText("You aren't crafting anything.", ColorError)
 		return
 	}
 
-	ledger.RemoveEntry(entry)
+	CancelCrafting(ctx.Character, "")
 
-	ctx.Player.client.ShowColorizedText("Entry has been removed from the ledger.", ColorSuccess)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You stopped crafting %s.", TextStyle(j.Recipe.Name(), WithBold())),
+		ColorSuccess,
+	)
 }
 
-func handleLedgerShowCommand(ctx *CommandContext) {
-	ledgerName := ctx.Args["ledger_name"]
-
-	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
-	if ledger == nil {
-		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
-		return
-	}
+func handleRecipeListCommand(ctx *CommandContext) {
+	filter := strings.ToLower(ctx.Args["filter"])
 
 	rows := []string{TableRow(
-		TableCell{content: "Item", header: true},
-		TableCell{content: "Buy", header: true},
-		TableCell{content: "Sell", header: true},
+		TableCell{content: "Recipe", header: true},
+		TableCell{content: "Creates", header: true},
+		TableCell{content: "Workstation", header: true},
 	)}
 
-	for _, entry := range ledger.Entries() {
+	for _, r := range Armeria.recipeManager.Recipes() {
+		if len(filter) > 0 && !strings.Contains(strings.ToLower(r.Name()), filter) {
+			continue
+		}
 		rows = append(rows, TableRow(
-			TableCell{content: entry.ItemName},
-			TableCell{content: misc.Money.FormatMoney(entry.BuyPrice)},
-			TableCell{content: misc.Money.FormatMoney(entry.SellPrice)},
+			TableCell{content: fmt.Sprintf("[cmd=/recipe show %[1]s]%[1]s[/cmd]", r.Name())},
+			TableCell{content: r.Output()},
+			TableCell{content: r.Workstation()},
 		))
 	}
 
 	ctx.Player.client.ShowText(TextTable(rows...))
 }
 
-func handleLedgerSearchCommand(ctx *CommandContext) {
-	itemName := ctx.Args["item_name"]
-
-	matches := make(map[string][]string)
+func handleRecipeCreateCommand(ctx *CommandContext) {
+	name := ctx.Args["name"]
 
-	for _, ledger := range Armeria.ledgerManager.Ledgers() {
-		for _, entry := range ledger.Entries() {
-			if strings.Contains(strings.ToLower(entry.ItemName), strings.ToLower(itemName)) {
-				matches[ledger.Name()] = append(matches[ledger.Name()], entry.ItemName)
-			}
-		}
+	if strings.Contains(name, " ") {
+		ctx.Player.client.ShowColorizedText("The recipe name cannot contain a space.", ColorError)
+		return
 	}
 
-	if len(matches) == 0 {
-		ctx.Player.client.ShowColorizedText("No matches found across all ledgers.", ColorError)
+	if Armeria.recipeManager.RecipeByName(name) != nil {
+		ctx.Player.client.ShowColorizedText("A recipe already exists with that name.", ColorError)
 		return
 	}
 
-	rows := []string{TableRow(
-		TableCell{content: "Ledger", header: true},
-		TableCell{content: "Matched", header: true},
-	)}
-
-	for ledger, matches := range matches {
-		for _, item := range matches {
-			rows = append(rows, TableRow(
-				TableCell{content: fmt.Sprintf("[cmd=/ledger show %[1]s]%[1]s[/cmd]", ledger)},
-				TableCell{content: item},
-			))
-		}
+	item := Armeria.itemManager.ItemByName(ctx.Args["output"])
+	if item == nil {
+		ctx.Player.client.ShowColorizedText("An item by that name doesn't exist.", ColorError)
+		return
 	}
 
-	ctx.Player.client.ShowText(TextTable(rows...))
-}
+	r := Armeria.recipeManager.CreateRecipe(name, item.Name())
+	Armeria.recipeManager.AddRecipe(r)
 
-func handleLedgerSetCommand(ctx *CommandContext) {
-	buyOrSell := strings.ToLower(ctx.Args["buy_or_sell"])
-	ledgerName := ctx.Args["ledger_name"]
-	itemName := ctx.Args["item_name"]
-	price := ctx.Args["price"]
+	ctx.Player.client.ShowColorizedText("The recipe has been created.", ColorSuccess)
+}
 
-	if buyOrSell != "buy" && buyOrSell != "sell" {
-		ctx.Player.client.ShowColorizedText("You must set either a BUY or SELL price.", ColorError)
+func handleRecipeDeleteCommand(ctx *CommandContext) {
+	r := Armeria.recipeManager.RecipeByName(ctx.Args["recipe_name"])
+	if r == nil {
+		ctx.Player.client.ShowColorizedText("A recipe by that name doesn't exist.", ColorError)
 		return
 	}
 
-	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
-	if ledger == nil {
-		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
-		return
-	}
+	Armeria.recipeManager.RemoveRecipe(r)
 
-	entry := ledger.Contains(itemName)
-	if entry == nil {
-		ctx.Player.client.ShowColorizedText("That item doesn't exist on that ledger.", ColorError)
+	ctx.Player.client.ShowColorizedText("The recipe has been deleted.", ColorSuccess)
+}
+
+func handleRecipeShowCommand(ctx *CommandContext) {
+	r := Armeria.recipeManager.RecipeByName(ctx.Args["recipe_name"])
+	if r == nil {
+		ctx.Player.client.ShowColorizedText("A recipe by that name doesn't exist.", ColorError)
 		return
 	}
 
-	amount, err := strconv.ParseFloat(price, 64)
-	if err != nil {
-		ctx.Player.client.ShowColorizedText("You must set a numerical price.", ColorError)
-		return
+	workstation := r.Workstation()
+	if len(workstation) == 0 {
+		workstation = "None"
 	}
 
-	if buyOrSell == "buy" {
-		entry.BuyPrice = amount
-	} else {
-		entry.SellPrice = amount
+	rows := []string{
+		TableRow(TableCell{content: "Output", header: true}, TableCell{content: r.Output()}),
+		TableRow(TableCell{content: "Count", header: true}, TableCell{content: strconv.Itoa(r.OutputCount())}),
+		TableRow(TableCell{content: "Workstation", header: true}, TableCell{content: workstation}),
+		TableRow(TableCell{content: "Duration", header: true}, TableCell{content: r.Duration().String()}),
+	}
+	for _, in := range r.Inputs() {
+		rows = append(rows, TableRow(
+			TableCell{content: "Input", header: true},
+			TableCell{content: fmt.Sprintf("%dx %s", in.Quantity, in.ItemName)},
+		))
+	}
+	for _, t := range r.Tools() {
+		rows = append(rows, TableRow(
+			TableCell{content: "Tool", header: true},
+			TableCell{content: t},
+		))
 	}
 
-	ctx.Player.client.ShowColorizedText("The price has been set on the ledger.", ColorSuccess)
+	ctx.Player.client.ShowText(TextTable(rows...))
 }
 
-func handleBuyCommand(ctx *CommandContext) {
-	mobName := ctx.Args["npc"]
-	itemName := ctx.Args["item"]
+func handleRecipeSetCommand(ctx *CommandContext) {
+	property := strings.ToLower(ctx.Args["property"])
+	value := ctx.Args["value"]
 
-	// Ensure mob is present in the room
-	result := ctx.Character.Room().Here().GetByName(mobName)
-	if result.Type != RegistryTypeMobInstance {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+	if !misc.Contains([]string{"output", "count", "workstation", "duration"}, property) {
+		ctx.Player.client.ShowColorizedText(
+			"You must set either the OUTPUT item, the output COUNT, the WORKSTATION or the DURATION.",
+			ColorError,
+		)
 		return
 	}
-	mobInstance := result.Object.(*MobInstance)
 
-	// Ensure mob is aware of a ledger that contains the item
-	var item *ItemInstance
-	var itemLedger *LedgerEntry
-	for _, ledger := range mobInstance.ItemLedgers() {
-		ledgerEntry := ledger.Contains(itemName)
-		if ledgerEntry != nil {
-			itemLedger = ledgerEntry
-			mobInstance.Inventory().PopulateFromLedger(ledger)
-			if result := mobInstance.Inventory().GetByName(ledgerEntry.ItemName); result.Type == RegistryTypeItemInstance {
-				item = result.Object.(*ItemInstance)
-				break
-			}
-		}
-	}
-	if item == nil || itemLedger == nil || itemLedger.BuyPrice == 0 {
-		ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s does not have that to sell.", mobInstance.Name()), ColorError)
+	r := Armeria.recipeManager.RecipeByName(ctx.Args["recipe_name"])
+	if r == nil {
+		ctx.Player.client.ShowColorizedText("A recipe by that name doesn't exist.", ColorError)
 		return
 	}
 
-	// Ensure character has room in their inventory
-	if ctx.Character.Inventory().Count() >= ctx.Character.Inventory().MaxSize() {
-		ctx.Player.client.ShowColorizedText(CommonInventoryFilled, ColorError)
+	switch property {
+	case "output":
+		item := Armeria.itemManager.ItemByName(value)
+		if item == nil {
+			ctx.Player.client.ShowColorizedText("An item by that name doesn't exist.", ColorError)
+			return
+		}
+		r.SetOutput(item.Name())
+	case "count":
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 1 {
+			ctx.Player.client.ShowColorizedText("The output count must be a whole number above 0.", ColorError)
+			return
+		}
+		r.SetOutputCount(count)
+	case "workstation":
+		r.SetWorkstation(strings.ToLower(value))
+	case "duration":
+		d, err := misc.ParseDuration(value)
+		if err != nil || d < 0 {
+			ctx.Player.client.ShowColorizedText("The duration must be a length of time (ie: 30s or 5m).", ColorError)
+			return
+		}
+		r.SetDuration(d)
+	}
+
+	ctx.Player.client.ShowColorizedText("The recipe has been updated.", ColorSuccess)
+}
+
+func handleRecipeInputCommand(ctx *CommandContext) {
+	r := Armeria.recipeManager.RecipeByName(ctx.Args["recipe_name"])
+	if r == nil {
+		ctx.Player.client.ShowColorizedText("A recipe by that name doesn't exist.", ColorError)
 		return
 	}
 
-	// Remove money from character
-	if !ctx.Character.RemoveMoney(itemLedger.BuyPrice) {
-		ctx.Player.client.ShowColorizedText("You can't afford that.", ColorError)
+	quantity, err := strconv.Atoi(ctx.Args["quantity"])
+	if err != nil || quantity < 0 {
+		ctx.Player.client.ShowColorizedText("The quantity must be a whole number.", ColorError)
 		return
 	}
 
-	// Transfer the item
-	mobInstance.Inventory().Remove(item.ID())
-	if err := ctx.Character.Inventory().Add(item.ID()); err != nil {
-		// Something went wrong, let's destroy the item instance and return the money
-		item.Parent.DeleteInstance(item)
-		ctx.Character.AddMoney(itemLedger.BuyPrice)
-		ctx.Player.client.ShowColorizedText("Something went wrong with the transaction.", ColorError)
+	item := Armeria.itemManager.ItemByName(ctx.Args["item_name"])
+	if item == nil {
+		ctx.Player.client.ShowColorizedText("An item by that name doesn't exist.", ColorError)
 		return
 	}
 
-	ctx.Player.client.SyncMoney()
-	ctx.Player.client.SyncInventory()
-	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
-	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf(
-			"You bought a %s from %s for %s.",
-			item.FormattedName(),
-			mobInstance.FormattedName(),
-			ctx.Character.Colorize(misc.Money.FormatMoney(itemLedger.BuyPrice), ColorMoney),
-		),
-		ColorSuccess,
-	)
+	r.SetInput(item.Name(), quantity)
 
-	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
-		c.Player().client.ShowText(
-			fmt.Sprintf(
-				"%s bought something from %s.",
-				ctx.Character.FormattedName(),
-				mobInstance.FormattedName(),
-			),
-		)
+	if quantity == 0 {
+		ctx.Player.client.ShowColorizedText("The input has been removed from the recipe.", ColorSuccess)
+	} else {
+		ctx.Player.client.ShowColorizedText("The input has been set on the recipe.", ColorSuccess)
 	}
 }
 
-func handleSellCommand(ctx *CommandContext) {
-	mobName := ctx.Args["npc"]
-	itemName := ctx.Args["item"]
-
-	// Ensure mob is present in the room
-	result := ctx.Character.Room().Here().GetByName(mobName)
-	if result.Type != RegistryTypeMobInstance {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+func handleRecipeToolCommand(ctx *CommandContext) {
+	r := Armeria.recipeManager.RecipeByName(ctx.Args["recipe_name"])
+	if r == nil {
+		ctx.Player.client.ShowColorizedText("A recipe by that name doesn't exist.", ColorError)
 		return
 	}
-	mobInstance := result.Object.(*MobInstance)
 
-	// Ensure item exists in the character's inventory
-	var item *ItemInstance
-	if result := ctx.Character.Inventory().GetByAny(itemName); result.Type == RegistryTypeItemInstance {
-		item = result.Object.(*ItemInstance)
-	}
+	item := Armeria.itemManager.ItemByName(ctx.Args["item_name"])
 	if item == nil {
-		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
-		return
-	}
-
-	// Ensure mob is aware of a ledger that contains the item
-	var itemLedger *LedgerEntry
-	for _, ledger := range mobInstance.ItemLedgers() {
-		ledgerEntry := ledger.Contains(item.Name())
-		if ledgerEntry != nil {
-			itemLedger = ledgerEntry
-			break
-		}
-	}
-	if itemLedger == nil || itemLedger.SellPrice == 0 {
-		ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s does not want that item.", mobInstance.Name()), ColorError)
+		ctx.Player.client.ShowColorizedText("An item by that name doesn't exist.", ColorError)
 		return
 	}
 
-	// Add money to the character
-	ctx.Character.AddMoney(itemLedger.SellPrice)
-
-	// Destroy the item
-	ctx.Character.Inventory().Remove(item.ID())
-	item.Parent.DeleteInstance(item)
-
-	ctx.Player.client.SyncMoney()
-	ctx.Player.client.SyncInventory()
-	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
-	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf(
-			"You sold a %s to %s for %s.",
-			item.FormattedName(),
-			mobInstance.FormattedName(),
-			ctx.Character.Colorize(misc.Money.FormatMoney(itemLedger.SellPrice), ColorMoney),
-		),
-		ColorSuccess,
-	)
-
-	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
-		c.Player().client.ShowText(
-			fmt.Sprintf(
-				"%s sold something to %s.",
-				ctx.Character.FormattedName(),
-				mobInstance.FormattedName(),
-			),
-		)
+	if r.ToggleTool(item.Name()) {
+		ctx.Player.client.ShowColorizedText("The tool has been added to the recipe.", ColorSuccess)
+	} else {
+		ctx.Player.client.ShowColorizedText("The tool has been removed from the recipe.", ColorSuccess)
 	}
 }
 
@@ -2429,6 +6612,11 @@ func handleDestroyCommand(ctx *CommandContext) {
 
 	if result := ctx.Character.Inventory().GetByAny(searchString); result.Type == RegistryTypeItemInstance {
 		item := result.Object.(*ItemInstance)
+		if item.HasContents() {
+			ctx.Player.client.ShowColorizedText(CommonContainerNotEmpty, ColorError)
+			return
+		}
+		ctx.Character.Journal().Record(NewInstanceDestroyJournalEntry(item, ctx.Character.Inventory()))
 		ctx.Character.Inventory().Remove(item.ID())
 		item.Delete()
 		ctx.Player.client.ShowColorizedText("The item has been destroyed!", ColorSuccess)
@@ -2436,10 +6624,16 @@ func handleDestroyCommand(ctx *CommandContext) {
 		return
 	} else if result := ctx.Character.Room().Here().GetByAny(searchString); result.Type == RegistryTypeItemInstance {
 		item := result.Object.(*ItemInstance)
+		if item.HasContents() {
+			ctx.Player.client.ShowColorizedText(CommonContainerNotEmpty, ColorError)
+			return
+		}
+		ctx.Character.Journal().Record(NewInstanceDestroyJournalEntry(item, ctx.Character.Room().Here()))
 		ctx.Character.Room().Here().Remove(item.ID())
 		item.Delete()
 	} else if result := ctx.Character.Room().Here().GetByAny(searchString); result.Type == RegistryTypeMobInstance {
 		mob := result.Object.(*MobInstance)
+		ctx.Character.Journal().Record(NewInstanceDestroyJournalEntry(mob, ctx.Character.Room().Here()))
 		ctx.Character.Room().Here().Remove(mob.ID())
 		mob.Delete()
 	} else {
@@ -2475,6 +6669,104 @@ func handleTickersCommand(ctx *CommandContext) {
 	ctx.Player.client.ShowText(TextTable(rows...))
 }
 
+func handleAuditCommand(ctx *CommandContext) {
+	character := ctx.Args["character"]
+	command := ctx.Args["command"]
+	if character == "*" {
+		character = ""
+	}
+	if command == "*" {
+		command = ""
+	}
+
+	var since time.Time
+	if len(ctx.Args["since"]) > 0 {
+		var err error
+		since, err = misc.ParseSince(ctx.Args["since"], time.Now())
+		if err != nil {
+			ctx.Player.client.ShowColorizedText("That's not a valid duration or date.", ColorError)
+			return
+		}
+	}
+
+	entries := Armeria.auditLog.Query(character, command, since, 50)
+	if len(entries) == 0 {
+		ctx.Player.client.ShowColorizedText("There are no audit log entries matching your search.", ColorError)
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Time", header: true},
+		TableCell{content: "Character", header: true},
+		TableCell{content: "Command", header: true},
+		TableCell{content: "Details", header: true},
+	)}
+
+	for _, e := range entries {
+		details := e.Details
+		if len(e.Arguments) > 0 {
+			details = strings.Join(e.Arguments, " ")
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: e.Time.Format("2006-01-02 15:04:05")},
+			TableCell{content: e.Character},
+			TableCell{content: e.Command},
+			TableCell{content: details},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleEconomyCommand(ctx *CommandContext) {
+	created, destroyed := Armeria.economyManager.Totals()
+	wallets, banks, escrow := Armeria.economyManager.Circulating()
+	issued := created - destroyed
+	circulating := wallets + banks + escrow
+
+	summary := []string{
+		TableRow(TableCell{content: "Created", header: true}, TableCell{content: created.String()}),
+		TableRow(TableCell{content: "Destroyed", header: true}, TableCell{content: destroyed.String()}),
+		TableRow(TableCell{content: "Net Issued", header: true}, TableCell{content: issued.String()}),
+		TableRow(TableCell{content: "In Wallets", header: true}, TableCell{content: wallets.String()}),
+		TableRow(TableCell{content: "In Banks", header: true}, TableCell{content: banks.String()}),
+		TableRow(TableCell{content: "In Escrow", header: true}, TableCell{content: escrow.String()}),
+		TableRow(TableCell{content: "Circulating", header: true}, TableCell{content: circulating.String()}),
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Reason", header: true},
+		TableCell{content: "Created", header: true},
+		TableCell{content: "Destroyed", header: true},
+		TableCell{content: "Moved", header: true},
+		TableCell{content: "Transactions", header: true},
+	)}
+
+	for _, r := range Armeria.economyManager.Reasons() {
+		stats := Armeria.economyManager.Stats(r)
+		rows = append(rows, TableRow(
+			TableCell{content: r},
+			TableCell{content: stats.Created.String()},
+			TableCell{content: stats.Destroyed.String()},
+			TableCell{content: stats.Moved.String()},
+			TableCell{content: strconv.Itoa(stats.Transactions)},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(summary...))
+	ctx.Player.client.ShowText(TextTable(rows...))
+
+	if issued != circulating {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"The money circulating differs from the net money issued by %s.",
+				(circulating-issued).String(),
+			),
+			ColorError,
+		)
+	}
+}
+
 func handleSelectCommand(ctx *CommandContext) {
 	mob := ctx.Args["mob"]
 	optionId := ctx.Args["option_id"]
@@ -2560,6 +6852,9 @@ func handleEquipCommand(ctx *CommandContext) {
 	if len(equipSlot) == 0 {
 		ctx.Player.client.ShowColorizedText("You cannot equip that to your character.", ColorError)
 		return
+	} else if item.Broken() {
+		ctx.Player.client.ShowColorizedText("That is broken, and must be repaired before you can equip it.", ColorError)
+		return
 	}
 
 	atSlot := ctx.Character.Equipment().AtSlotName(EquipmentSlot(equipSlot))
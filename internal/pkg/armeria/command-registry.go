@@ -12,13 +12,13 @@ func RegisterGameCommands() {
 		},
 		{
 			Name: "login",
-			Help: "Log your character into the game world.",
+			Help: "Log in to your account.",
 			Permissions: &CommandPermissions{
 				RequireNoCharacter: true,
 			},
 			Arguments: []*CommandArgument{
 				{
-					Name: "character",
+					Name: "username",
 				},
 				{
 					Name:  "password",
@@ -42,6 +42,20 @@ func RegisterGameCommands() {
 			},
 			Handler: handleLoginCommand,
 		},
+		{
+			Name: "play",
+			Help: "Enter the game world as one of the characters on your account.",
+			Permissions: &CommandPermissions{
+				RequireNoCharacter: true,
+				RequireAccount:     true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "character",
+				},
+			},
+			Handler: handlePlayCommand,
+		},
 		{
 			Name:   "create",
 			Help:   "Create a new character.",
@@ -49,6 +63,15 @@ func RegisterGameCommands() {
 			Permissions: &CommandPermissions{
 				RequireNoCharacter: true,
 			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "answer",
+					Optional:         true,
+					IncludeRemaining: true,
+					NoLog:            true,
+					Help:             "Your answer to the current step of character creation.",
+				},
+			},
 			Handler: handleCreateCommand,
 		},
 		{
@@ -126,8 +149,9 @@ func RegisterGameCommands() {
 			Handler: handleDestroyCommand,
 		},
 		{
-			Name: "say",
-			Help: "Say something to everyone in your current room.",
+			Name:   "say",
+			Help:   "Say something to everyone in your current room.",
+			Speech: true,
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -140,8 +164,9 @@ func RegisterGameCommands() {
 			Handler: handleSayCommand,
 		},
 		{
-			Name: "move",
-			Help: "Move your character into a connecting room.",
+			Name:     "move",
+			Help:     "Move your character into a connecting room.",
+			Movement: true,
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -291,6 +316,33 @@ func RegisterGameCommands() {
 					},
 					Handler: handleCharacterCreateCommand,
 				},
+				{
+					Name:    "pending",
+					Help:    "List the characters, created by players, that are waiting to be approved.",
+					Handler: handleCharacterPendingCommand,
+				},
+				{
+					Name: "approve",
+					Help: "Approve a character created by a player, so they can log in.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+							Help: "The name of the character to approve.",
+						},
+					},
+					Handler: handleCharacterApproveCommand,
+				},
+				{
+					Name: "reject",
+					Help: "Reject a character created by a player, deleting it.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+							Help: "The name of the character to reject.",
+						},
+					},
+					Handler: handleCharacterRejectCommand,
+				},
 			},
 		},
 		{
@@ -314,6 +366,7 @@ func RegisterGameCommands() {
 			Name:     "whisper",
 			AltNames: []string{"w"},
 			Help:     "Send a private message to an online character.",
+			Speech:   true,
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -332,6 +385,7 @@ func RegisterGameCommands() {
 			Name:     "reply",
 			AltNames: []string{"r"},
 			Help:     "Reply to the last whisper you received.",
+			Speech:   true,
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -343,6 +397,58 @@ func RegisterGameCommands() {
 			},
 			Handler: handleReplyCommand,
 		},
+		{
+			Name: "whispers",
+			Help: "Show the recent whispers between you and another character.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "character",
+				},
+				{
+					Name:     "lines",
+					Optional: true,
+					Help:     "How many messages to show.",
+				},
+			},
+			Handler: handleWhispersCommand,
+		},
+		{
+			Name: "ignore",
+			Help: "Stop receiving messages from another character.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name: "add",
+					Help: "Ignore a character.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleIgnoreCommand,
+				},
+				{
+					Name: "remove",
+					Help: "Stop ignoring a character.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleIgnoreCommand,
+				},
+				{
+					Name:    "list",
+					Help:    "List the characters you are ignoring.",
+					Handler: handleIgnoreListCommand,
+				},
+			},
+		},
 		{
 			Name: "who",
 			Help: "Display a list of all characters who are currently online.",
@@ -515,6 +621,33 @@ func RegisterGameCommands() {
 					},
 					Handler: handleAreaEditCommand,
 				},
+				{
+					Name: "export",
+					Help: "Export an area, and everything it references, to a bundle file.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "area",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleAreaExportCommand,
+				},
+				{
+					Name: "import",
+					Help: "Import an area from a bundle file, optionally using a new area name.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "file",
+						},
+						{
+							Name:             "name",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleAreaImportCommand,
+				},
 			},
 		},
 		{
@@ -655,6 +788,33 @@ func RegisterGameCommands() {
 			},
 			Handler: handleWipeCommand,
 		},
+		{
+			Name: "undo",
+			Help: "Undo your most recent change made using a builder command.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
+			},
+			Handler: handleUndoCommand,
+		},
+		{
+			Name: "redo",
+			Help: "Redo the change you most recently undid.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
+			},
+			Handler: handleRedoCommand,
+		},
+		{
+			Name: "journal",
+			Help: "List the changes you've made using builder commands that can be undone.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
+			},
+			Handler: handleJournalCommand,
+		},
 		{
 			Name: "ghost",
 			Help: "Bypass movement restrictions while moving.",
@@ -666,7 +826,7 @@ func RegisterGameCommands() {
 		},
 		{
 			Name: "password",
-			Help: "Set a new password for your character.",
+			Help: "Set a new password for your account.",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -682,6 +842,7 @@ func RegisterGameCommands() {
 			Name:     "teleport",
 			AltNames: []string{"tp"},
 			Help:     "Teleport to the specified character or room.",
+			Movement: true,
 			Permissions: &CommandPermissions{
 				RequireCharacter:  true,
 				RequirePermission: "CAN_TELEPORT",
@@ -737,20 +898,138 @@ func RegisterGameCommands() {
 				},
 			},
 		},
+		{
+			Name:     "selection",
+			AltNames: []string{"sel"},
+			Help:     "Select multiple rooms and edit them in bulk.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
+			},
+			Subcommands: []*Command{
+				{
+					Name: "box",
+					Help: "Select the rooms within a box of coordinates in the current, or specified, area.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "from",
+							Help: "The coordinates of one corner of the box, as x,y,z.",
+						},
+						{
+							Name: "to",
+							Help: "The coordinates of the opposite corner of the box, as x,y,z.",
+						},
+						{
+							Name:             "area",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleSelectionBoxCommand,
+				},
+				{
+					Name: "match",
+					Help: "Select the rooms in the current area with a matching attribute. Leave value empty to match unset attributes.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "property",
+						},
+						{
+							Name:             "value",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleSelectionMatchCommand,
+				},
+				{
+					Name:    "show",
+					Help:    "Show the rooms that are selected.",
+					Handler: handleSelectionShowCommand,
+				},
+				{
+					Name:    "clear",
+					Help:    "Clear the selection.",
+					Handler: handleSelectionClearCommand,
+				},
+				{
+					Name: "set",
+					Help: "Set an attribute on every selected room. Leave value empty to revert to default.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "property",
+						},
+						{
+							Name:             "value",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleSelectionSetCommand,
+				},
+				{
+					Name:    "paste",
+					Help:    "Paste the room attributes on your clipboard onto every selected room.",
+					Handler: handleSelectionPasteCommand,
+				},
+				{
+					Name:    "destroy",
+					Help:    "Destroy every selected room.",
+					Handler: handleSelectionDestroyCommand,
+				},
+				{
+					Name: "shift",
+					Help: "Move every selected room by an offset.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "offset",
+							Help: "The offset to move the rooms by, as x,y,z.",
+						},
+					},
+					Handler: handleSelectionShiftCommand,
+				},
+				{
+					Name:    "confirm",
+					Help:    "Apply the previewed change to the selected rooms.",
+					Handler: handleSelectionConfirmCommand,
+				},
+				{
+					Name:    "cancel",
+					Help:    "Discard the previewed change.",
+					Handler: handleSelectionCancelCommand,
+				},
+			},
+		},
 		{
 			Name: "get",
-			Help: "Grab an item from the ground.",
+			Help: "Grab an item from the ground, or take an item out of a container (ie: /get apple from bag).",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
 			Arguments: []*CommandArgument{
 				{
 					Name:             "item",
+					Help:             "The item to pick up, optionally preceded by how many (ie: 5 apple).",
 					IncludeRemaining: true,
 				},
 			},
 			Handler: handleGetCommand,
 		},
+		{
+			Name: "put",
+			Help: "Put an item from your inventory into a container (ie: /put 5 apple in bag).",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "item",
+					Help:             "The item, optionally preceded by how many, followed by 'in' and the container.",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handlePutCommand,
+		},
 		{
 			Name: "drop",
 			Help: "Drop an item onto the ground.",
@@ -760,6 +1039,7 @@ func RegisterGameCommands() {
 			Arguments: []*CommandArgument{
 				{
 					Name:             "item",
+					Help:             "The item to drop, optionally preceded by how many (ie: 5 apple).",
 					IncludeRemaining: true,
 				},
 			},
@@ -783,280 +1063,1398 @@ func RegisterGameCommands() {
 		},
 		{
 			Name: "autologin",
-			Help: "Toggle auto-login for your character.",
+			Help: "Toggle auto-login for your character on this device.",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "device",
+					Optional:         true,
+					IncludeRemaining: true,
+					Help:             "A label for this device, shown in your list of sessions.",
+				},
+			},
 			Handler: handleAutoLoginCommand,
 		},
 		{
-			Name: "channel",
-			Help: "Join, leave or list talking channels you can participate in.",
+			Name: "account",
+			Help: "Manage player accounts.",
 			Permissions: &CommandPermissions{
-				RequireCharacter: true,
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
 			},
 			Subcommands: []*Command{
 				{
-					Name:    "list",
-					Help:    "List channels you can join and participate in.",
-					Handler: handleChannelListCommand,
+					Name: "list",
+					Help: "List the accounts, optionally using a filter.",
+					Arguments: []*CommandArgument{
+						{
+							Name:     "filter",
+							Optional: true,
+						},
+					},
+					Handler: handleAccountListCommand,
 				},
 				{
-					Name: "join",
-					Help: "Join a channel.",
+					Name: "info",
+					Help: "Show the characters, permissions and ban status of an account.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "channel",
+							Name: "account",
+							Help: "The username of the account, or the name of one of its characters.",
 						},
 					},
-					Handler: handleChannelJoinCommand,
+					Handler: handleAccountInfoCommand,
 				},
 				{
-					Name: "leave",
-					Help: "Leave a channel.",
+					Name: "permissions",
+					Help: "Set the permissions granted to every character on an account. Leave empty to remove them all.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "channel",
+							Name: "account",
+							Help: "The username of the account, or the name of one of its characters.",
+						},
+						{
+							Name:             "permissions",
+							Optional:         true,
+							IncludeRemaining: true,
+							Help:             "The space-separated permissions to grant.",
 						},
 					},
-					Handler: handleChannelLeaveCommand,
+					Handler: handleAccountPermissionsCommand,
 				},
 				{
-					Name: "say",
-					Help: "Say someting to a channel.",
+					Name: "ban",
+					Help: "Ban an account, disconnecting its characters.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "channel",
+							Name: "account",
+							Help: "The username of the account, or the name of one of its characters.",
 						},
 						{
-							Name:             "text",
+							Name: "duration",
+							Help: "How long to ban the account for (ie: 12h or 7d), or permanent.",
+						},
+						{
+							Name:             "reason",
+							Optional:         true,
 							IncludeRemaining: true,
+							Help:             "Why the account is being banned.",
 						},
 					},
-					Handler: handleChannelSayCommand,
+					Handler: handleAccountBanCommand,
+				},
+				{
+					Name: "unban",
+					Help: "Lift the ban on an account.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "account",
+							Help: "The username of the account, or the name of one of its characters.",
+						},
+					},
+					Handler: handleAccountUnbanCommand,
 				},
 			},
 		},
 		{
-			Name:     "settings",
-			AltNames: []string{"setting", "set"},
-			Help:     "View or change settings for your character.",
+			Name: "unlock",
+			Help: "Lift the lockout on a character, or an address, after too many failed logins.",
 			Permissions: &CommandPermissions{
-				RequireCharacter: true,
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
 			},
 			Arguments: []*CommandArgument{
 				{
-					Name:     "name",
-					Optional: true,
-				},
-				{
-					Name:     "value",
-					Optional: true,
+					Name: "target",
+					Help: "The name of the character, or the address, to unlock.",
 				},
 			},
-			Handler: handleSettingsCommand,
+			Handler: handleUnlockCommand,
 		},
 		{
-			Name:     "bug",
-			AltNames: []string{"idea"},
-			Help:     "Report a bug or suggest an idea.",
+			Name: "mute",
+			Help: "Stop a character from talking to other characters.",
 			Permissions: &CommandPermissions{
-				RequireCharacter: true,
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
 			},
 			Arguments: []*CommandArgument{
 				{
-					Name:             "bug",
-					Optional:         false,
-					IncludeRemaining: true,
+					Name: "character",
 				},
-			},
-			Handler: handleBugCommand,
+				{
+					Name: "duration",
+					Help: "How long to mute the character for (ie: 30m or 1d), or permanent.",
+				},
+				{
+					Name:             "reason",
+					Optional:         true,
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleMuteCommand,
+		},
+		{
+			Name: "unmute",
+			Help: "Let a muted character talk again.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "character",
+				},
+			},
+			Handler: handleUnmuteCommand,
+		},
+		{
+			Name: "kick",
+			Help: "Disconnect a character from the game.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "character",
+				},
+				{
+					Name:             "reason",
+					Optional:         true,
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleKickCommand,
+		},
+		{
+			Name: "ban",
+			Help: "Stop a character, or anyone from an address, from logging in.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "target",
+					Help: "The name of the character, or the address, to ban.",
+				},
+				{
+					Name: "duration",
+					Help: "How long to ban them for (ie: 12h or 7d), or permanent.",
+				},
+				{
+					Name:             "reason",
+					Optional:         true,
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleBanCommand,
+		},
+		{
+			Name: "unban",
+			Help: "Lift the ban on a character or an address.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "target",
+					Help: "The name of the character, or the address, to unban.",
+				},
+			},
+			Handler: handleUnbanCommand,
+		},
+		{
+			Name: "jail",
+			Help: "Send a character to the jail room, where they can't leave until they are released.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "character",
+				},
+				{
+					Name: "duration",
+					Help: "How long to jail the character for (ie: 1h or 2d), or permanent.",
+				},
+				{
+					Name:             "reason",
+					Optional:         true,
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleJailCommand,
+		},
+		{
+			Name: "release",
+			Help: "Release a character from jail, returning them to where they were.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "character",
+				},
+			},
+			Handler: handleReleaseCommand,
+		},
+		{
+			Name: "moderation",
+			Help: "Review the actions taken by moderators.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Subcommands: []*Command{
+				{
+					Name: "list",
+					Help: "List the mutes, bans and jail sentences in effect.",
+					Arguments: []*CommandArgument{
+						{
+							Name:     "all",
+							Optional: true,
+							Help:     "Use all to include kicks and sanctions that have ended.",
+						},
+					},
+					Handler: handleModerationListCommand,
+				},
+			},
+		},
+		{
+			Name: "sessions",
+			Help: "Manage the auto-login sessions for your character.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "list",
+					Help:    "List your auto-login sessions.",
+					Handler: handleSessionsListCommand,
+				},
+				{
+					Name: "revoke",
+					Help: "Revoke one of your auto-login sessions, or all of them.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+							Help: "The id of the session to revoke, or all.",
+						},
+					},
+					Handler: handleSessionsRevokeCommand,
+				},
+			},
+		},
+		{
+			Name: "channel",
+			Help: "Join, leave, list or create talking channels you can participate in.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "list",
+					Help:    "List channels you can join and participate in.",
+					Handler: handleChannelListCommand,
+				},
+				{
+					Name: "join",
+					Help: "Join a channel.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name:     "password",
+							Optional: true,
+							NoLog:    true,
+							Help:     "The password, if the channel needs one.",
+						},
+					},
+					Handler: handleChannelJoinCommand,
+				},
+				{
+					Name: "leave",
+					Help: "Leave a channel.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+					},
+					Handler: handleChannelLeaveCommand,
+				},
+				{
+					Name:   "say",
+					Help:   "Say someting to a channel.",
+					Speech: true,
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name:             "text",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleChannelSayCommand,
+				},
+				{
+					Name: "history",
+					Help: "Show the recent messages sent to a channel you've joined.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name:     "lines",
+							Optional: true,
+							Help:     "How many messages to show.",
+						},
+					},
+					Handler: handleChannelHistoryCommand,
+				},
+				{
+					Name: "create",
+					Help: "Create your own channel, which you own and moderate.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name:     "password",
+							Optional: true,
+							NoLog:    true,
+							Help:     "A password that others need to join the channel.",
+						},
+					},
+					Handler: handleChannelCreateCommand,
+				},
+				{
+					Name: "delete",
+					Help: "Delete a channel you own.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+					},
+					Handler: handleChannelDeleteCommand,
+				},
+				{
+					Name: "info",
+					Help: "Show the topic, owner, moderators and online members of a channel.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+					},
+					Handler: handleChannelInfoCommand,
+				},
+				{
+					Name: "topic",
+					Help: "Set the topic of a channel you moderate. Leave empty to clear it.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name:             "topic",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleChannelTopicCommand,
+				},
+				{
+					Name: "invite",
+					Help: "Invite a character to a channel you moderate.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleChannelInviteCommand,
+				},
+				{
+					Name: "private",
+					Help: "Make a channel you own invite-only, or open it to anyone.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name: "enabled",
+							Help: "Either on or off.",
+						},
+					},
+					Handler: handleChannelPrivateCommand,
+				},
+				{
+					Name: "password",
+					Help: "Set the password needed to join a channel you own. Leave empty to remove it.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name:     "password",
+							Optional: true,
+							NoLog:    true,
+						},
+					},
+					Handler: handleChannelPasswordCommand,
+				},
+				{
+					Name: "mod",
+					Help: "Make a character a moderator of a channel you own.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleChannelModCommand,
+				},
+				{
+					Name: "unmod",
+					Help: "Remove a moderator from a channel you own.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleChannelModCommand,
+				},
+				{
+					Name: "ban",
+					Help: "Ban a character from a channel you moderate.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleChannelBanCommand,
+				},
+				{
+					Name: "unban",
+					Help: "Lift a character's ban from a channel you moderate.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleChannelBanCommand,
+				},
+			},
+		},
+		{
+			Name: "guild",
+			Help: "Create, join and manage guilds, and talk with your guild.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "list",
+					Help:    "List every guild.",
+					Handler: handleGuildListCommand,
+				},
+				{
+					Name: "info",
+					Help: "Show the ranks and members of your guild, or another guild.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "guild",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleGuildInfoCommand,
+				},
+				{
+					Name: "create",
+					Help: "Create a new guild and become its leader.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "name",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleGuildCreateCommand,
+				},
+				{
+					Name:    "disband",
+					Help:    "Disband the guild you lead.",
+					Handler: handleGuildDisbandCommand,
+				},
+				{
+					Name: "invite",
+					Help: "Invite a character to join your guild.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleGuildInviteCommand,
+				},
+				{
+					Name: "accept",
+					Help: "Accept an invitation to join a guild.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "guild",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleGuildAcceptCommand,
+				},
+				{
+					Name:    "leave",
+					Help:    "Leave your guild.",
+					Handler: handleGuildLeaveCommand,
+				},
+				{
+					Name: "kick",
+					Help: "Remove a lower-ranked member from your guild.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleGuildKickCommand,
+				},
+				{
+					Name: "rank",
+					Help: "Change the rank of a lower-ranked member of your guild.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+						{
+							Name: "rank",
+						},
+					},
+					Handler: handleGuildRankCommand,
+				},
+				{
+					Name: "permission",
+					Help: "Change what a rank in your guild is allowed to do.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "rank",
+						},
+						{
+							Name: "permission",
+							Help: "One of: invite, kick, promote, withdraw or manage.",
+						},
+						{
+							Name: "enabled",
+							Help: "Use on or off.",
+						},
+					},
+					Handler: handleGuildPermissionCommand,
+				},
+				{
+					Name:   "say",
+					Speech: true,
+					Help:   "Talk to the other members of your guild.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "text",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleGuildSayCommand,
+				},
+				{
+					Name: "history",
+					Help: "Show what was recently said to your guild.",
+					Arguments: []*CommandArgument{
+						{
+							Name:     "lines",
+							Optional: true,
+							Help:     "How many messages to show.",
+						},
+					},
+					Handler: handleGuildHistoryCommand,
+				},
+				{
+					Name:    "bank",
+					Help:    "Display the balance and recent transactions of your guild bank.",
+					Handler: handleGuildBankCommand,
+				},
+				{
+					Name: "deposit",
+					Help: "Deposit money into your guild bank with a banker.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "amount",
+							Help: "The amount of money to deposit.",
+						},
+					},
+					Handler: handleGuildDepositCommand,
+				},
+				{
+					Name: "withdraw",
+					Help: "Withdraw money from your guild bank with a banker.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "amount",
+							Help: "The amount of money to withdraw.",
+						},
+					},
+					Handler: handleGuildWithdrawCommand,
+				},
+			},
+		},
+		{
+			Name: "party",
+			Help: "Form a party with other characters, and talk with your party.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "list",
+					Help:    "List the members of your party.",
+					Handler: handlePartyListCommand,
+				},
+				{
+					Name: "invite",
+					Help: "Invite a character to join your party, starting a party if you aren't in one.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+					},
+					Handler: handlePartyInviteCommand,
+				},
+				{
+					Name: "accept",
+					Help: "Accept an invitation to join a character's party.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+							Help: "The leader of the party.",
+						},
+					},
+					Handler: handlePartyAcceptCommand,
+				},
+				{
+					Name:    "leave",
+					Help:    "Leave your party.",
+					Handler: handlePartyLeaveCommand,
+				},
+				{
+					Name: "kick",
+					Help: "Remove a character from the party you lead.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+					},
+					Handler: handlePartyKickCommand,
+				},
+				{
+					Name:   "say",
+					Speech: true,
+					Help:   "Talk to the other members of your party.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "text",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handlePartySayCommand,
+				},
+			},
+		},
+		{
+			Name: "follow",
+			Help: "Follow a member of your party from room to room, or stop following.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "character",
+					Optional: true,
+					Help:     "Leave empty to stop following.",
+				},
+			},
+			Handler: handleFollowCommand,
+		},
+		{
+			Name:     "settings",
+			AltNames: []string{"setting", "set"},
+			Help:     "View or change settings for your character.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "name",
+					Optional: true,
+				},
+				{
+					Name:     "value",
+					Optional: true,
+				},
+			},
+			Handler: handleSettingsCommand,
+		},
+		{
+			Name:     "bug",
+			AltNames: []string{"idea"},
+			Help:     "Report a bug or suggest an idea.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "bug",
+					Optional:         false,
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleBugCommand,
+		},
+		{
+			Name: "give",
+			Help: "Give an item to someone or something.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "target",
+				},
+				{
+					Name:             "item",
+					Help:             "The item to give, optionally preceded by how many (ie: 5 apple).",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleGiveCommand,
+		},
+		{
+			Name:   "me",
+			Help:   "Emote something to everyone in your current room.",
+			Speech: true,
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "emote",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleEmoteCommand,
+		},
+		{
+			Name: "ledger",
+			Help: "Manage item ledgers.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
+			},
+			Subcommands: []*Command{
+				{
+					Name: "list",
+					Help: "List the created item ledgers.",
+					Arguments: []*CommandArgument{
+						{
+							Name:     "filter",
+							Optional: true,
+						},
+					},
+					Handler: handleLedgerListCommand,
+				},
+				{
+					Name: "create",
+					Help: "Create a new item ledger.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "name",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleLedgerCreateCommand,
+				},
+				{
+					Name: "rename",
+					Help: "Renames an item ledger.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "ledger_name",
+						},
+						{
+							Name: "new_name",
+						},
+					},
+					Handler: handleLedgerRenameCommand,
+				},
+				{
+					Name: "add",
+					Help: "Add an item to a ledger.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "ledger_name",
+						},
+						{
+							Name:             "item_name",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleLedgerAddCommand,
+				},
+				{
+					Name: "remove",
+					Help: "Remove an item from a ledger.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "ledger_name",
+						},
+						{
+							Name:             "item_name",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleLedgerRemoveCommand,
+				},
+				{
+					Name: "show",
+					Help: "Show the contents of a ledger.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "ledger_name",
+						},
+					},
+					Handler: handleLedgerShowCommand,
+				},
+				{
+					Name: "search",
+					Help: "Search all ledgers for an item.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "item_name",
+							Help: "The name of the item you want to search for.",
+						},
+					},
+					Handler: handleLedgerSearchCommand,
+				},
+				{
+					Name: "set",
+					Help: "Set the prices, stock or pricing behavior of an item on a ledger.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "property",
+							Help: "Set to either 'buy', 'sell', 'stock', 'restock' or 'elasticity'.",
+						},
+						{
+							Name: "ledger_name",
+							Help: "The name of the ledger.",
+						},
+						{
+							Name: "item_name",
+							Help: "The name of the item.",
+						},
+						{
+							Name: "value",
+							Help: "The price, stock level (0 for unlimited), restock interval in minutes, or the fraction " +
+								"prices change by for each unit of supply (0 for fixed prices).",
+						},
+					},
+					Handler: handleLedgerSetCommand,
+				},
+			},
+		},
+		{
+			Name: "buy",
+			Help: "Buy an item from an NPC.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "npc",
+					Help: "The name of the NPC you wish to buy the item from.",
+				},
+				{
+					Name:             "item",
+					Help:             "The name of the item you wish to buy.",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleBuyCommand,
+		},
+		{
+			Name: "sell",
+			Help: "Sell an item to an NPC.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "npc",
+					Help: "The name of the NPC you wish to sell the item to.",
+				},
+				{
+					Name:             "item",
+					Help:             "The name of the item you wish to sell.",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleSellCommand,
+		},
+		{
+			Name: "deposit",
+			Help: "Deposit money into your bank account with a banker.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "amount",
+					Help: "The amount of money to deposit.",
+				},
+			},
+			Handler: handleDepositCommand,
+		},
+		{
+			Name: "withdraw",
+			Help: "Withdraw money from your bank account with a banker.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "amount",
+					Help: "The amount of money to withdraw.",
+				},
+			},
+			Handler: handleWithdrawCommand,
 		},
 		{
-			Name: "give",
-			Help: "Give an item to someone or something.",
+			Name:     "balance",
+			AltNames: []string{"bal"},
+			Help:     "Display the balance and recent transactions of your bank account.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Handler: handleBalanceCommand,
+		},
+		{
+			Name: "repair",
+			Help: "Repair damaged items with a repairer, or list what needs repairing.",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
 			Arguments: []*CommandArgument{
-				{
-					Name: "target",
-				},
 				{
 					Name:             "item",
+					Help:             "The item to repair.",
+					Optional:         true,
 					IncludeRemaining: true,
 				},
 			},
-			Handler: handleGiveCommand,
+			Handler: handleRepairCommand,
 		},
 		{
-			Name: "me",
-			Help: "Emote something to everyone in your current room.",
+			Name: "transfer",
+			Help: "Transfer money from your bank account to another character's bank account.",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
 			Arguments: []*CommandArgument{
 				{
-					Name:             "emote",
-					IncludeRemaining: true,
+					Name: "character",
+					Help: "The name of the character to send the money to.",
+				},
+				{
+					Name: "amount",
+					Help: "The amount of money to transfer.",
 				},
 			},
-			Handler: handleEmoteCommand,
+			Handler: handleTransferCommand,
 		},
 		{
-			Name: "ledger",
-			Help: "Manage item ledgers.",
+			Name: "trade",
+			Help: "Safely trade items and money with another character.",
 			Permissions: &CommandPermissions{
-				RequireCharacter:  true,
-				RequirePermission: "CAN_BUILD",
+				RequireCharacter: true,
 			},
 			Subcommands: []*Command{
 				{
-					Name: "list",
-					Help: "List the created item ledgers.",
+					Name: "with",
+					Help: "Ask a character in the same room to trade, or join a trade they asked you for.",
 					Arguments: []*CommandArgument{
 						{
-							Name:     "filter",
-							Optional: true,
+							Name:             "character",
+							IncludeRemaining: true,
 						},
 					},
-					Handler: handleLedgerListCommand,
+					Handler: handleTradeWithCommand,
 				},
 				{
-					Name: "create",
-					Help: "Create a new item ledger.",
+					Name: "add",
+					Help: "Add an item from your inventory to your offer.",
 					Arguments: []*CommandArgument{
 						{
-							Name:             "name",
+							Name:             "item",
 							IncludeRemaining: true,
 						},
 					},
-					Handler: handleLedgerCreateCommand,
+					Handler: handleTradeAddCommand,
 				},
 				{
-					Name: "rename",
-					Help: "Renames an item ledger.",
+					Name: "remove",
+					Help: "Remove an item from your offer.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "ledger_name",
+							Name:             "item",
+							IncludeRemaining: true,
 						},
+					},
+					Handler: handleTradeRemoveCommand,
+				},
+				{
+					Name: "money",
+					Help: "Set the amount of money in your offer.",
+					Arguments: []*CommandArgument{
 						{
-							Name: "new_name",
+							Name: "amount",
 						},
 					},
-					Handler: handleLedgerRenameCommand,
+					Handler: handleTradeMoneyCommand,
 				},
 				{
-					Name: "add",
-					Help: "Add an item to a ledger.",
+					Name:    "show",
+					Help:    "Display both offers in the current trade.",
+					Handler: handleTradeShowCommand,
+				},
+				{
+					Name:    "accept",
+					Help:    "Accept the current offers. The trade completes once both characters accept.",
+					Handler: handleTradeAcceptCommand,
+				},
+				{
+					Name:    "cancel",
+					Help:    "Cancel the current trade or trade request.",
+					Handler: handleTradeCancelCommand,
+				},
+			},
+		},
+		{
+			Name: "mail",
+			Help: "Send and receive mail, even when the other character is offline.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "list",
+					Help:    "View the mail in your mailbox.",
+					Handler: handleMailListCommand,
+				},
+				{
+					Name: "read",
+					Help: "Read mail and take its attachments.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "ledger_name",
+							Name: "number",
 						},
+					},
+					Handler: handleMailReadCommand,
+				},
+				{
+					Name: "attach",
+					Help: "Attach an item from your inventory to the next mail you send.",
+					Arguments: []*CommandArgument{
 						{
-							Name:             "item_name",
+							Name:             "item",
 							IncludeRemaining: true,
 						},
 					},
-					Handler: handleLedgerAddCommand,
+					Handler: handleMailAttachCommand,
 				},
 				{
-					Name: "remove",
-					Help: "Remove an item from a ledger.",
+					Name: "money",
+					Help: "Attach money to the next mail you send.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "ledger_name",
+							Name: "amount",
+						},
+					},
+					Handler: handleMailMoneyCommand,
+				},
+				{
+					Name:   "send",
+					Help:   "Send mail, along with any attachments, to a character.",
+					Speech: true,
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
 						},
 						{
-							Name:             "item_name",
+							Name: "subject",
+							Help: "Use quotes for a subject with spaces.",
+						},
+						{
+							Name:             "message",
 							IncludeRemaining: true,
 						},
 					},
-					Handler: handleLedgerRemoveCommand,
+					Handler: handleMailSendCommand,
 				},
 				{
-					Name: "show",
-					Help: "Show the contents of a ledger.",
+					Name: "delete",
+					Help: "Delete mail from your mailbox.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "ledger_name",
+							Name: "number",
 						},
 					},
-					Handler: handleLedgerShowCommand,
+					Handler: handleMailDeleteCommand,
+				},
+			},
+		},
+		{
+			Name: "auction",
+			Help: "Buy and sell items at the auction house.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "list",
+					Help:    "View the items up for auction.",
+					Handler: handleAuctionListCommand,
 				},
 				{
-					Name: "search",
-					Help: "Search all ledgers for an item.",
+					Name: "sell",
+					Help: "List an item from your inventory for auction.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "item_name",
-							Help: "The name of the item you want to search for.",
+							Name: "item",
+						},
+						{
+							Name: "price",
+							Help: "The lowest amount that can be bid.",
+						},
+						{
+							Name:     "duration",
+							Optional: true,
+							Help:     "How long the auction runs for (ie: 12h or 2d). Defaults to 1d.",
+						},
+						{
+							Name:     "buyout",
+							Optional: true,
+							Help:     "The price the item can be bought for immediately.",
 						},
 					},
-					Handler: handleLedgerSearchCommand,
+					Handler: handleAuctionSellCommand,
 				},
 				{
-					Name: "set",
-					Help: "Set the buy or sell price of an item on a ledger.",
+					Name: "bid",
+					Help: "Bid on an item. Your money is held until you are outbid or the auction ends.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "buy_or_sell",
-							Help: "Set to either 'buy' or 'sell'.",
+							Name: "auction",
 						},
 						{
-							Name: "ledger_name",
-							Help: "The name of the ledger.",
+							Name: "amount",
 						},
+					},
+					Handler: handleAuctionBidCommand,
+				},
+				{
+					Name: "buyout",
+					Help: "Buy an item immediately for its buyout price.",
+					Arguments: []*CommandArgument{
 						{
-							Name: "item_name",
-							Help: "The name of the item.",
+							Name: "auction",
 						},
+					},
+					Handler: handleAuctionBuyoutCommand,
+				},
+				{
+					Name: "cancel",
+					Help: "Cancel one of your auctions that doesn't have any bids yet.",
+					Arguments: []*CommandArgument{
 						{
-							Name: "price",
-							Help: "The buy or sell price you want to set.",
+							Name: "auction",
 						},
 					},
-					Handler: handleLedgerSetCommand,
+					Handler: handleAuctionCancelCommand,
 				},
 			},
 		},
 		{
-			Name: "buy",
-			Help: "Buy an item from an NPC.",
+			Name: "craft",
+			Help: "Craft items from recipes, using the workstations nearby.",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
-			Arguments: []*CommandArgument{
+			Subcommands: []*Command{
 				{
-					Name: "npc",
-					Help: "The name of the NPC you wish to buy the item from.",
+					Name:    "list",
+					Help:    "List the recipes that can be crafted here.",
+					Handler: handleCraftListCommand,
 				},
 				{
-					Name:             "item",
-					Help:             "The name of the item you wish to buy.",
-					IncludeRemaining: true,
+					Name: "make",
+					Help: "Start crafting a recipe. You must stay in the room until it is finished.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "recipe",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleCraftMakeCommand,
+				},
+				{
+					Name:    "status",
+					Help:    "Show how far along you are with what you are crafting.",
+					Handler: handleCraftStatusCommand,
+				},
+				{
+					Name:    "cancel",
+					Help:    "Stop crafting. Nothing is used up.",
+					Handler: handleCraftCancelCommand,
 				},
 			},
-			Handler: handleBuyCommand,
 		},
 		{
-			Name: "sell",
-			Help: "Sell an item to an NPC.",
+			Name: "recipe",
+			Help: "Manage crafting recipes.",
 			Permissions: &CommandPermissions{
-				RequireCharacter: true,
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
 			},
-			Arguments: []*CommandArgument{
+			Subcommands: []*Command{
 				{
-					Name: "npc",
-					Help: "The name of the NPC you wish to sell the item to.",
+					Name: "list",
+					Help: "List the created recipes.",
+					Arguments: []*CommandArgument{
+						{
+							Name:     "filter",
+							Optional: true,
+						},
+					},
+					Handler: handleRecipeListCommand,
 				},
 				{
-					Name:             "item",
-					Help:             "The name of the item you wish to sell.",
-					IncludeRemaining: true,
+					Name: "create",
+					Help: "Create a new recipe.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "name",
+						},
+						{
+							Name:             "output",
+							Help:             "The name of the item the recipe creates.",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleRecipeCreateCommand,
+				},
+				{
+					Name: "delete",
+					Help: "Delete a recipe.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "recipe_name",
+						},
+					},
+					Handler: handleRecipeDeleteCommand,
+				},
+				{
+					Name: "show",
+					Help: "Show the details of a recipe.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "recipe_name",
+						},
+					},
+					Handler: handleRecipeShowCommand,
+				},
+				{
+					Name: "set",
+					Help: "Set the output, output count, workstation or duration of a recipe.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "recipe_name",
+							Help: "The name of the recipe.",
+						},
+						{
+							Name: "property",
+							Help: "Set to either 'output', 'count', 'workstation' or 'duration'.",
+						},
+						{
+							Name: "value",
+							Help: "The item name, number of items created, kind of workstation (empty for none), or " +
+								"how long it takes to craft (ie: 30s or 5m).",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleRecipeSetCommand,
+				},
+				{
+					Name: "input",
+					Help: "Set how many of an item are used up by a recipe.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "recipe_name",
+						},
+						{
+							Name: "quantity",
+							Help: "How many are used up (0 to remove the input).",
+						},
+						{
+							Name:             "item_name",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleRecipeInputCommand,
+				},
+				{
+					Name: "tool",
+					Help: "Add or remove an item needed, but not used up, by a recipe.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "recipe_name",
+						},
+						{
+							Name:             "item_name",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleRecipeToolCommand,
 				},
 			},
-			Handler: handleSellCommand,
 		},
 		{
 			Name: "tickers",
@@ -1067,6 +2465,41 @@ func RegisterGameCommands() {
 			},
 			Handler: handleTickersCommand,
 		},
+		{
+			Name: "audit",
+			Help: "Search the audit log of privileged commands and money changes.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "character",
+					Optional: true,
+					Help:     "Only show entries for this character. Use * to match any character.",
+				},
+				{
+					Name:     "command",
+					Optional: true,
+					Help:     "Only show entries for commands starting with this. Use * to match any command.",
+				},
+				{
+					Name:     "since",
+					Optional: true,
+					Help:     "Only show entries since a duration ago (ie: 30m, 12h, 7d) or a date (ie: 2020-01-31).",
+				},
+			},
+			Handler: handleAuditCommand,
+		},
+		{
+			Name: "economy",
+			Help: "View a report of the money created, destroyed and circulating within the game.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Handler: handleEconomyCommand,
+		},
 		{
 			Name:     "equip",
 			Help:     "Display equipment or equip an item.",
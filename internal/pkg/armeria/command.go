@@ -3,6 +3,7 @@ package armeria
 import (
 	"armeria/internal/pkg/misc"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,6 +16,8 @@ type Command struct {
 	AltNames    []string                `json:"altNames"`
 	Help        string                  `json:"help"`
 	Hidden      bool                    `json:"-"`
+	Speech      bool                    `json:"-"`
+	Movement    bool                    `json:"-"`
 	Alias       string                  `json:"alias"`
 	Permissions *CommandPermissions     `json:"permissions"`
 	Arguments   []*CommandArgument      `json:"args"`
@@ -33,6 +36,7 @@ type CommandArgument struct {
 type CommandPermissions struct {
 	RequireNoCharacter bool
 	RequireCharacter   bool
+	RequireAccount     bool
 	RequirePermission  string
 }
 
@@ -63,6 +67,12 @@ func (cmd *Command) CheckPermissions(p *Player) bool {
 		}
 	}
 
+	if cmd.Permissions.RequireAccount {
+		if p.Account() == nil {
+			return false
+		}
+	}
+
 	if len(cmd.Permissions.RequirePermission) > 0 {
 		if !p.Character().HasPermission(cmd.Permissions.RequirePermission) {
 			return false
@@ -148,10 +158,29 @@ func (cmd *Command) ArgumentByName(name string) *CommandArgument {
 	return nil
 }
 
-// LogCtx logs a parent using a command.
-func (cmd *Command) LogCtx(ctx *CommandContext) {
-	handlerDuration := time.Since(ctx.HandlerStart)
+// FullName returns the name of the command, including the name of the parent command (if applicable).
+func (cmd *Command) FullName() string {
+	if cmd.Parent != nil {
+		return cmd.Parent.Name + " " + cmd.Name
+	}
+
+	return cmd.Name
+}
+
+// RequiredPermission returns the permission required to use the command, taking the parent command into account.
+func (cmd *Command) RequiredPermission() string {
+	if cmd.Permissions != nil && len(cmd.Permissions.RequirePermission) > 0 {
+		return cmd.Permissions.RequirePermission
+	} else if cmd.Parent != nil {
+		return cmd.Parent.RequiredPermission()
+	}
+
+	return ""
+}
 
+// LoggableArgs returns the arguments used with the command as "name=value" strings, excluding any arguments
+// that should not be logged.
+func (cmd *Command) LoggableArgs(ctx *CommandContext) []string {
 	var args []string
 	for k, v := range ctx.Args {
 		a := cmd.ArgumentByName(k)
@@ -160,6 +189,17 @@ func (cmd *Command) LogCtx(ctx *CommandContext) {
 		}
 	}
 
+	sort.Strings(args)
+
+	return args
+}
+
+// LogCtx logs a parent using a command.
+func (cmd *Command) LogCtx(ctx *CommandContext) {
+	handlerDuration := time.Since(ctx.HandlerStart)
+
+	args := cmd.LoggableArgs(ctx)
+
 	c := "Anonymous"
 	if ctx.Character != nil {
 		c = ctx.Character.Name()
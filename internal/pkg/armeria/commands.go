@@ -124,9 +124,20 @@ func (m *CommandManager) ProcessCommand(p *Player, command string, playerInitiat
 		return
 	}
 
+	if ctx.Character != nil {
+		if msg := Armeria.moderationManager.CommandBlocked(ctx.Character, cmd); len(msg) > 0 {
+			p.client.ShowColorizedText(msg, ColorError)
+			return
+		}
+	}
+
 	ctx.HandlerStart = time.Now()
 	cmd.Handler(ctx)
 	cmd.LogCtx(ctx)
+
+	if len(cmd.RequiredPermission()) > 0 {
+		Armeria.auditLog.RecordCommand(ctx)
+	}
 }
 
 func (m *CommandManager) CharacterCommandDictionaryJSON(p *Player) string {
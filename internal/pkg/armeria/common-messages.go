@@ -5,4 +5,11 @@ const (
 	CommonItemNotFoundOnCharacter string = "You don't have an item by that name."
 	CommonInvalidDirection        string = "You cannot go that way."
 	CommonInventoryFilled         string = "You have no room in your inventory for that."
+	CommonNoBankCard              string = "You don't have a bank card equipped."
+	CommonNoBankerHere            string = "There is no one here that can handle your banking."
+	CommonInvalidMoneyAmount      string = "You must specify a positive amount of money."
+	CommonNoAuctioneerHere        string = "There is no one here that can handle the auction house."
+	CommonAuctionNotFound         string = "There is no auction with that number."
+	CommonNoRepairerHere          string = "There is no one here that can repair your items."
+	CommonContainerNotEmpty       string = "You must empty that first."
 )
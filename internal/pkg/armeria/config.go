@@ -8,10 +8,20 @@ import (
 )
 
 type config struct {
-	HTTPPort   int    `yaml:"httpPort"`
-	PublicPath string `yaml:"publicPath"`
-	Production bool   `yaml:"production"`
-	DataPath   string `yaml:"dataPath"`
+	HTTPPort   int            `yaml:"httpPort"`
+	PublicPath string         `yaml:"publicPath"`
+	Production bool           `yaml:"production"`
+	DataPath   string         `yaml:"dataPath"`
+	Creation   creationConfig `yaml:"creation"`
+}
+
+// creationConfig configures how players create their own characters.
+type creationConfig struct {
+	StartingArea     string   `yaml:"startingArea"`
+	StartingRoom     string   `yaml:"startingRoom"`
+	StartingPictures []string `yaml:"startingPictures"`
+	PerHour          int      `yaml:"perHour"`
+	RequireApproval  bool     `yaml:"requireApproval"`
 }
 
 func parseConfigFile(filePath string) config {
@@ -2,6 +2,7 @@ package armeria
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -19,6 +20,11 @@ type Coords struct {
 	UnsafeI int `json:"-"`
 }
 
+var (
+	// ErrInvalidCoords is an error for when coordinates are not in the "x,y,z" format.
+	ErrInvalidCoords = errors.New("coordinates must be in the format x,y,z")
+)
+
 // NewCoords creates and returns a new Coords.
 func NewCoords(x int, y int, z int, i int) *Coords {
 	return &Coords{
@@ -50,6 +56,26 @@ func NewCoordsFromString(c string) *Coords {
 	return NewCoords(x, y, z, 0)
 }
 
+// ParseCoords creates and returns a new Coords from an "x,y,z" string. Unlike NewCoordsFromString, an error is
+// returned if the string is not in the correct format or any of the values are not valid numbers.
+func ParseCoords(c string) (*Coords, error) {
+	sections := strings.Split(c, ",")
+	if len(sections) != 3 {
+		return nil, ErrInvalidCoords
+	}
+
+	var xyz [3]int
+	for i, s := range sections {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil, ErrInvalidCoords
+		}
+		xyz[i] = n
+	}
+
+	return NewCoords(xyz[0], xyz[1], xyz[2], 0), nil
+}
+
 // CopyCoords copies the contents of a Coords pointer and returns a fresh Coords pointer.
 func CopyCoords(c *Coords) *Coords {
 	return &Coords{
@@ -0,0 +1,196 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrAlreadyCrafting is an error for when a character starts crafting while already crafting something else.
+	ErrAlreadyCrafting = errors.New("already crafting")
+	// ErrRecipeOutputMissing is an error for when the item a recipe creates no longer exists.
+	ErrRecipeOutputMissing = errors.New("recipe output item does not exist")
+)
+
+// CraftingJob is a Recipe a Character is in the middle of crafting.
+type CraftingJob struct {
+	Recipe    *Recipe
+	Room      *Room
+	Started   time.Time
+	Completes time.Time
+}
+
+// Progress returns how far along the CraftingJob is, as a whole percentage.
+func (j *CraftingJob) Progress() int {
+	total := j.Completes.Sub(j.Started)
+	if total <= 0 {
+		return 100
+	}
+
+	pct := int(time.Since(j.Started) * 100 / total)
+	if pct > 100 {
+		return 100
+	}
+
+	return pct
+}
+
+// Crafting returns the CraftingJob the Character is working on, or nil if they aren't crafting.
+func (c *Character) Crafting() *CraftingJob {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeCrafting
+}
+
+// SetCrafting sets the CraftingJob the Character is working on.
+func (c *Character) SetCrafting(j *CraftingJob) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeCrafting = j
+}
+
+// CraftableRecipes returns the Recipes that are unlocked by the workstations in the Room.
+func CraftableRecipes(room *Room) []*Recipe {
+	var recipes []*Recipe
+	for _, r := range Armeria.recipeManager.Recipes() {
+		if r.Unlocked(room) {
+			recipes = append(recipes, r)
+		}
+	}
+
+	return recipes
+}
+
+// StartCrafting begins crafting a Recipe. The Character must have everything the Recipe needs, which is checked
+// again when crafting completes. A Recipe without a duration is completed immediately.
+func StartCrafting(c *Character, r *Recipe) error {
+	if c.Crafting() != nil {
+		return ErrAlreadyCrafting
+	} else if Armeria.itemManager.ItemByName(r.Output()) == nil {
+		return ErrRecipeOutputMissing
+	}
+
+	if missing := r.Missing(c); len(missing) > 0 {
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+
+	now := time.Now()
+	j := &CraftingJob{
+		Recipe:    r,
+		Room:      c.Room(),
+		Started:   now,
+		Completes: now.Add(r.Duration()),
+	}
+
+	if r.Duration() == 0 {
+		return finishCrafting(c, j)
+	}
+
+	c.SetCrafting(j)
+
+	return nil
+}
+
+// finishCrafting uses up the inputs of the CraftingJob's Recipe and gives the Character the output. If the output
+// doesn't fit in the Character's inventory, it is left in the room.
+func finishCrafting(c *Character, j *CraftingJob) error {
+	r := j.Recipe
+
+	if missing := r.Missing(c); len(missing) > 0 {
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+
+	output := Armeria.itemManager.ItemByName(r.Output())
+	if output == nil {
+		return ErrRecipeOutputMissing
+	}
+
+	for _, in := range r.Inputs() {
+		c.Inventory().TakeItemQuantity(in.ItemName, in.Quantity)
+	}
+
+	for _, t := range r.Tools() {
+		if ii := r.Tool(c, t); ii != nil {
+			c.WearItem(ii, 1)
+		}
+	}
+
+	dropped := false
+	for i := 0; i < r.OutputCount(); i++ {
+		ii := output.CreateInstance()
+		if _, err := c.Inventory().AddItem(ii); err != nil {
+			_, _ = c.Room().Here().AddItem(ii)
+			dropped = true
+		}
+	}
+
+	Armeria.log.Info("recipe crafted",
+		zap.String("character", c.Name()),
+		zap.String("recipe", r.Name()),
+	)
+
+	if c.Online() {
+		name := TextStyle(output.Name(), WithBold())
+		if r.OutputCount() > 1 {
+			name = fmt.Sprintf("%dx %s", r.OutputCount(), name)
+		}
+
+		c.Player().client.ShowColorizedText(fmt.Sprintf("You finished crafting %s.", name), ColorSuccess)
+		if dropped {
+			c.Player().client.ShowText("Your inventory is full, so what you crafted was left on the ground.")
+			for _, char := range c.Room().Here().Characters(true) {
+				char.Player().client.SyncRoomObjects()
+			}
+		}
+		c.Player().client.SyncInventory()
+	}
+
+	return nil
+}
+
+// CancelCrafting stops the Character from crafting, without using up anything.
+func CancelCrafting(c *Character, reason string) {
+	if c.Crafting() == nil {
+		return
+	}
+
+	c.SetCrafting(nil)
+
+	if c.Online() && len(reason) > 0 {
+		c.Player().client.ShowColorizedText(reason, ColorError)
+	}
+}
+
+// CraftingProgress completes the crafting jobs that have finished, and cancels the jobs of characters that have
+// left the room they were crafting in.
+func CraftingProgress() {
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		j := c.Crafting()
+		if j == nil {
+			continue
+		}
+
+		if c.Room() != j.Room {
+			CancelCrafting(c, fmt.Sprintf("You left the room and stopped crafting %s.", j.Recipe.Name()))
+			continue
+		}
+
+		if time.Now().Before(j.Completes) {
+			continue
+		}
+
+		c.SetCrafting(nil)
+		if err := finishCrafting(c, j); err != nil {
+			c.Player().client.ShowColorizedText(
+				fmt.Sprintf("You couldn't finish crafting %s: %s.", j.Recipe.Name(), err),
+				ColorError,
+			)
+		}
+	}
+}
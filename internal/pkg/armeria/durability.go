@@ -0,0 +1,178 @@
+package armeria
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+const (
+	// EquipmentWearChance is the chance, each time a character walks to another room, that each of their equipped
+	// items loses a point of condition.
+	EquipmentWearChance float64 = 0.1
+	// RepairCostRate is the fraction of an item's ledger buy price it costs to repair the item from broken.
+	RepairCostRate float64 = 0.5
+)
+
+// MaxDurability returns the condition of the ItemInstance when it is fully repaired, or 0 if it never wears.
+func (ii *ItemInstance) MaxDurability() int {
+	d, err := strconv.Atoi(ii.Parent.Attribute(AttributeDurability))
+	if err != nil || d < 0 {
+		return 0
+	}
+
+	return d
+}
+
+// HasDurability returns true if the ItemInstance wears with use.
+func (ii *ItemInstance) HasDurability() bool {
+	return ii.MaxDurability() > 0
+}
+
+// Condition returns the current condition of the ItemInstance. An ItemInstance that has never worn is in full
+// condition.
+func (ii *ItemInstance) Condition() int {
+	max := ii.MaxDurability()
+
+	c, err := strconv.Atoi(ii.InstanceAttribute(AttributeCondition))
+	if err != nil || c > max {
+		return max
+	} else if c < 0 {
+		return 0
+	}
+
+	return c
+}
+
+// SetCondition sets the current condition of the ItemInstance, within the bounds of its durability.
+func (ii *ItemInstance) SetCondition(c int) {
+	max := ii.MaxDurability()
+	if c > max {
+		c = max
+	} else if c < 0 {
+		c = 0
+	}
+
+	if c == max {
+		_ = ii.SetAttribute(AttributeCondition, "")
+	} else {
+		_ = ii.SetAttribute(AttributeCondition, strconv.Itoa(c))
+	}
+}
+
+// Broken returns true if the ItemInstance has worn down completely.
+func (ii *ItemInstance) Broken() bool {
+	return ii.HasDurability() && ii.Condition() == 0
+}
+
+// Damaged returns true if the ItemInstance has worn at all.
+func (ii *ItemInstance) Damaged() bool {
+	return ii.HasDurability() && ii.Condition() < ii.MaxDurability()
+}
+
+// ConditionName returns a description of the condition of the ItemInstance.
+func (ii *ItemInstance) ConditionName() string {
+	if !ii.HasDurability() {
+		return "Indestructible"
+	}
+
+	pct := float64(ii.Condition()) / float64(ii.MaxDurability())
+	switch {
+	case pct == 0:
+		return "Broken"
+	case pct < 0.25:
+		return "Badly Damaged"
+	case pct < 0.75:
+		return "Worn"
+	case pct < 1:
+		return "Good"
+	}
+
+	return "Pristine"
+}
+
+// Wear reduces the condition of the ItemInstance. Returns true if the ItemInstance broke as a result.
+func (ii *ItemInstance) Wear(amount int) bool {
+	if !ii.HasDurability() || ii.Broken() || amount <= 0 {
+		return false
+	}
+
+	ii.SetCondition(ii.Condition() - amount)
+
+	return ii.Broken()
+}
+
+// WearItem wears an item the Character has, and lets them know if it broke. A broken item that is equipped is
+// moved back to the inventory when there is room.
+func (c *Character) WearItem(ii *ItemInstance, amount int) {
+	if !ii.Wear(amount) {
+		return
+	}
+
+	unequipped := false
+	if c.Equipment().Contains(ii.ID()) && c.Inventory().Count() < c.Inventory().MaxSize() {
+		c.Equipment().Remove(ii.ID())
+		unequipped = c.Inventory().Add(ii.ID()) == nil
+		if !unequipped {
+			_ = c.Equipment().Add(ii.ID())
+		}
+	}
+
+	if !c.Online() {
+		return
+	}
+
+	msg := fmt.Sprintf("Your %s broke!", ii.FormattedName())
+	if unequipped {
+		msg = fmt.Sprintf("Your %s broke, and you put it away.", ii.FormattedName())
+	}
+	c.Player().client.ShowColorizedText(msg, ColorError)
+	c.Player().client.SyncInventory()
+}
+
+// WearEquipment gives each equipped item with durability a chance to wear from use.
+func (c *Character) WearEquipment() {
+	for _, ii := range c.Equipment().Items() {
+		if ii.HasDurability() && rand.Float64() < EquipmentWearChance {
+			c.WearItem(ii, 1)
+		}
+	}
+}
+
+// Repairer returns true if the mob can repair items for characters in the same room.
+func (mi *MobInstance) Repairer() bool {
+	mi.RLock()
+	defer mi.RUnlock()
+
+	return mi.UnsafeRepairer
+}
+
+// SetRepairer sets whether the mob can repair items for characters in the same room.
+func (mi *MobInstance) SetRepairer(repairer bool) {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.UnsafeRepairer = repairer
+}
+
+// RepairCost returns what the mob charges to fully repair an item. The cost is based on the buy price of the item
+// on the mob's ledgers, so the mob can only repair items that are on one of its ledgers.
+func (mi *MobInstance) RepairCost(ii *ItemInstance) (Money, bool) {
+	for _, ledger := range mi.ItemLedgers() {
+		entry := ledger.Contains(ii.Name())
+		if entry == nil || entry.BuyPrice <= 0 {
+			continue
+		}
+
+		missing := float64(ii.MaxDurability()-ii.Condition()) / float64(ii.MaxDurability())
+		cost := Money(math.Ceil(float64(entry.BuyPrice) * RepairCostRate * missing))
+		if cost < 1 {
+			cost = 1
+		}
+
+		return cost, true
+	}
+
+	return 0, false
+}
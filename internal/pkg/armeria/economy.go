@@ -0,0 +1,345 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Reasons that money can move.
+const (
+	MoneyReasonShopBuy         string = "shop-buy"
+	MoneyReasonShopSell        string = "shop-sell"
+	MoneyReasonBankDeposit     string = "bank-deposit"
+	MoneyReasonBankWithdrawal  string = "bank-withdrawal"
+	MoneyReasonBankTransfer    string = "bank-transfer"
+	MoneyReasonTrade           string = "trade"
+	MoneyReasonAuctionBid      string = "auction-bid"
+	MoneyReasonAuctionRefund   string = "auction-refund"
+	MoneyReasonAuctionSale     string = "auction-sale"
+	MoneyReasonGuildDeposit    string = "guild-deposit"
+	MoneyReasonGuildWithdrawal string = "guild-withdrawal"
+	MoneyReasonMail            string = "mail"
+	MoneyReasonRepair          string = "repair"
+	MoneyReasonAdmin           string = "admin"
+	MoneyReasonScript          string = "script"
+	MoneyReasonMigration       string = "migration"
+	moneyWorldHolderName       string = "the world"
+)
+
+// MoneyHolder is anything that can hold money, such as a Character or a BankAccount.
+type MoneyHolder interface {
+	MoneyHolderName() string
+	Money() Money
+	setMoney(Money)
+}
+
+// moneyRecorder is implemented by a MoneyHolder that keeps its own history of money movements.
+type moneyRecorder interface {
+	recordMoney(m *MoneyMovement, balance Money)
+}
+
+// moneyWorld is the source of money that enters the game and the sink for money that leaves it.
+type moneyWorld struct{}
+
+func (w *moneyWorld) MoneyHolderName() string { return moneyWorldHolderName }
+func (w *moneyWorld) Money() Money            { return 0 }
+func (w *moneyWorld) setMoney(Money)          {}
+
+// MoneyWorld creates money when it is the source of a movement, and destroys money when it is the sink.
+var MoneyWorld MoneyHolder = &moneyWorld{}
+
+// MoneyMovement is a single movement of money from a source to a sink.
+type MoneyMovement struct {
+	From   MoneyHolder
+	To     MoneyHolder
+	Amount Money
+	Reason string
+}
+
+// MoneyTransaction is a group of money movements that are applied all together, or not at all.
+type MoneyTransaction struct {
+	Movements []*MoneyMovement
+}
+
+// EconomyStats are the totals for money moved for a single reason.
+type EconomyStats struct {
+	Created      Money `json:"created"`
+	Destroyed    Money `json:"destroyed"`
+	Moved        Money `json:"moved"`
+	Transactions int   `json:"transactions"`
+}
+
+// EconomyManager applies money transactions and keeps a running ledger of the money created and destroyed
+// within the game, broken down by reason.
+type EconomyManager struct {
+	sync.Mutex
+	dataFile      string
+	UnsafeReasons map[string]*EconomyStats `json:"reasons"`
+}
+
+var (
+	// ErrInvalidMoneyAmount is an error for when money is moved in an amount that isn't positive.
+	ErrInvalidMoneyAmount = errors.New("amount must be positive")
+)
+
+// NewEconomyManager creates a new EconomyManager.
+func NewEconomyManager() *EconomyManager {
+	m := &EconomyManager{
+		dataFile: fmt.Sprintf("%s/economy.json", Armeria.dataPath),
+	}
+
+	m.LoadEconomy()
+
+	return m
+}
+
+// LoadEconomy loads the economy ledger from disk into memory.
+func (m *EconomyManager) LoadEconomy() {
+	m.Lock()
+	defer m.Unlock()
+
+	economyFile, err := os.Open(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer economyFile.Close()
+
+	jsonParser := json.NewDecoder(economyFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	if m.UnsafeReasons == nil {
+		m.UnsafeReasons = make(map[string]*EconomyStats)
+	}
+
+	Armeria.log.Info("economy loaded",
+		zap.Int("reasons", len(m.UnsafeReasons)),
+	)
+}
+
+// SaveEconomy writes the in-memory economy ledger to disk.
+func (m *EconomyManager) SaveEconomy() {
+	m.Lock()
+	defer m.Unlock()
+
+	economyFile, err := os.Create(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to create data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer economyFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := economyFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = economyFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Commit validates and applies every movement within a MoneyTransaction. If any holder would be left with a
+// negative balance, nothing is applied and ErrInsufficientFunds is returned.
+func (m *EconomyManager) Commit(tx *MoneyTransaction) error {
+	if err := m.apply(tx); err != nil {
+		return err
+	}
+
+	// The audit log is synced to disk with every entry, so it is written once the economy is unlocked.
+	for _, mv := range tx.Movements {
+		Armeria.auditLog.RecordMoney(mv)
+	}
+
+	return nil
+}
+
+// apply validates and applies every movement within a MoneyTransaction, under the lock.
+func (m *EconomyManager) apply(tx *MoneyTransaction) error {
+	m.Lock()
+	defer m.Unlock()
+
+	net := make(map[MoneyHolder]Money)
+	var holders []MoneyHolder
+	for _, mv := range tx.Movements {
+		if mv.Amount <= 0 {
+			return ErrInvalidMoneyAmount
+		}
+		for _, h := range []MoneyHolder{mv.From, mv.To} {
+			if _, ok := net[h]; !ok && h != MoneyWorld {
+				holders = append(holders, h)
+				net[h] = 0
+			}
+		}
+		net[mv.From] -= mv.Amount
+		net[mv.To] += mv.Amount
+	}
+
+	for _, h := range holders {
+		if h.Money()+net[h] < 0 {
+			return ErrInsufficientFunds
+		}
+	}
+
+	for _, h := range holders {
+		h.setMoney(h.Money() + net[h])
+	}
+
+	for _, mv := range tx.Movements {
+		stats := m.UnsafeReasons[mv.Reason]
+		if stats == nil {
+			stats = &EconomyStats{}
+			m.UnsafeReasons[mv.Reason] = stats
+		}
+
+		if mv.From == MoneyWorld && mv.To != MoneyWorld {
+			stats.Created += mv.Amount
+		} else if mv.To == MoneyWorld && mv.From != MoneyWorld {
+			stats.Destroyed += mv.Amount
+		} else {
+			stats.Moved += mv.Amount
+		}
+		stats.Transactions++
+
+		for _, h := range []MoneyHolder{mv.From, mv.To} {
+			if r, ok := h.(moneyRecorder); ok {
+				r.recordMoney(mv, h.Money())
+			}
+		}
+	}
+
+	return nil
+}
+
+// Totals returns the total money created and destroyed for every reason.
+func (m *EconomyManager) Totals() (created Money, destroyed Money) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, stats := range m.UnsafeReasons {
+		created += stats.Created
+		destroyed += stats.Destroyed
+	}
+
+	return created, destroyed
+}
+
+// Reasons returns the names of the reasons money has moved, sorted alphabetically.
+func (m *EconomyManager) Reasons() []string {
+	m.Lock()
+	defer m.Unlock()
+
+	reasons := make([]string, 0, len(m.UnsafeReasons))
+	for r := range m.UnsafeReasons {
+		reasons = append(reasons, r)
+	}
+	sort.Strings(reasons)
+
+	return reasons
+}
+
+// Stats returns a copy of the totals for a single reason.
+func (m *EconomyManager) Stats(reason string) EconomyStats {
+	m.Lock()
+	defer m.Unlock()
+
+	if stats := m.UnsafeReasons[reason]; stats != nil {
+		return *stats
+	}
+
+	return EconomyStats{}
+}
+
+// Circulating returns the money currently held by characters, within bank accounts and in escrow.
+func (m *EconomyManager) Circulating() (wallets Money, banks Money, escrow Money) {
+	for _, c := range Armeria.characterManager.Characters() {
+		wallets += c.Money()
+		for _, mail := range c.Mail() {
+			escrow += mail.Money()
+		}
+	}
+
+	for _, ba := range Armeria.bankManager.Accounts() {
+		banks += ba.Money()
+	}
+
+	for _, g := range Armeria.guildManager.Guilds() {
+		banks += g.Money()
+	}
+
+	escrow += Armeria.auctionManager.Escrow()
+
+	return wallets, banks, escrow
+}
+
+// NewMoneyTransaction creates an empty MoneyTransaction.
+func NewMoneyTransaction() *MoneyTransaction {
+	return &MoneyTransaction{
+		Movements: make([]*MoneyMovement, 0),
+	}
+}
+
+// Move adds a movement of money to the transaction.
+func (tx *MoneyTransaction) Move(from MoneyHolder, to MoneyHolder, amount Money, reason string) *MoneyTransaction {
+	tx.Movements = append(tx.Movements, &MoneyMovement{
+		From:   from,
+		To:     to,
+		Amount: amount,
+		Reason: reason,
+	})
+
+	return tx
+}
+
+// Commit applies the transaction.
+func (tx *MoneyTransaction) Commit() error {
+	return Armeria.economyManager.Commit(tx)
+}
+
+// TransferMoney moves money from one holder to another in a single transaction.
+func TransferMoney(from MoneyHolder, to MoneyHolder, amount Money, reason string) error {
+	return NewMoneyTransaction().Move(from, to, amount, reason).Commit()
+}
+
+// SetMoney sets the money held by a holder by creating or destroying the difference. Anything that sets a balance
+// directly, rather than moving money between holders, goes through here so that the change is accounted for.
+func SetMoney(h MoneyHolder, amount Money, reason string) error {
+	diff := amount - h.Money()
+	if diff > 0 {
+		return TransferMoney(MoneyWorld, h, diff, reason)
+	} else if diff < 0 {
+		return TransferMoney(h, MoneyWorld, -diff, reason)
+	}
+
+	return nil
+}
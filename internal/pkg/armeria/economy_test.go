@@ -0,0 +1,127 @@
+package armeria
+
+import "testing"
+
+func TestEconomyManagerCommit(t *testing.T) {
+	defer setupTestGameState(t)()
+
+	tests := []struct {
+		name    string
+		balance []Money
+		moves   func(h []*BankAccount) *MoneyTransaction
+		err     error
+		want    []Money
+	}{
+		{
+			name:    "single transfer",
+			balance: []Money{500, 0},
+			moves: func(h []*BankAccount) *MoneyTransaction {
+				return NewMoneyTransaction().Move(h[0], h[1], 200, MoneyReasonBankTransfer)
+			},
+			want: []Money{300, 200},
+		},
+		{
+			name:    "incoming money covers a later payment",
+			balance: []Money{0, 100},
+			moves: func(h []*BankAccount) *MoneyTransaction {
+				return NewMoneyTransaction().
+					Move(h[0], h[1], 150, MoneyReasonBankTransfer).
+					Move(h[1], h[0], 200, MoneyReasonBankTransfer)
+			},
+			want: []Money{50, 50},
+		},
+		{
+			name:    "created and destroyed money",
+			balance: []Money{100, 0},
+			moves: func(h []*BankAccount) *MoneyTransaction {
+				return NewMoneyTransaction().
+					Move(MoneyWorld, h[1], 75, MoneyReasonAdmin).
+					Move(h[0], MoneyWorld, 100, MoneyReasonAdmin)
+			},
+			want: []Money{0, 75},
+		},
+		{
+			name:    "insufficient funds applies nothing",
+			balance: []Money{100, 0},
+			moves: func(h []*BankAccount) *MoneyTransaction {
+				return NewMoneyTransaction().
+					Move(MoneyWorld, h[1], 50, MoneyReasonAdmin).
+					Move(h[0], h[1], 101, MoneyReasonBankTransfer)
+			},
+			err:  ErrInsufficientFunds,
+			want: []Money{100, 0},
+		},
+		{
+			name:    "netting cannot hide an overdraft",
+			balance: []Money{10, 0},
+			moves: func(h []*BankAccount) *MoneyTransaction {
+				return NewMoneyTransaction().
+					Move(h[0], h[1], 30, MoneyReasonBankTransfer).
+					Move(h[1], h[0], 10, MoneyReasonBankTransfer)
+			},
+			err:  ErrInsufficientFunds,
+			want: []Money{10, 0},
+		},
+		{
+			name:    "non-positive amount applies nothing",
+			balance: []Money{100, 0},
+			moves: func(h []*BankAccount) *MoneyTransaction {
+				return NewMoneyTransaction().
+					Move(h[0], h[1], 50, MoneyReasonBankTransfer).
+					Move(h[0], h[1], 0, MoneyReasonBankTransfer)
+			},
+			err:  ErrInvalidMoneyAmount,
+			want: []Money{100, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		m := &EconomyManager{UnsafeReasons: make(map[string]*EconomyStats)}
+		holders := []*BankAccount{{UUID: "a"}, {UUID: "b"}}
+		for i, h := range holders {
+			h.setMoney(tt.balance[i])
+		}
+
+		if err := m.Commit(tt.moves(holders)); err != tt.err {
+			t.Errorf("%s: Commit returned %v, want %v", tt.name, err, tt.err)
+		}
+
+		for i, h := range holders {
+			if got := h.Money(); got != tt.want[i] {
+				t.Errorf("%s: holder %d has %d, want %d", tt.name, i, got, tt.want[i])
+			}
+		}
+
+		if tt.err != nil && len(m.UnsafeReasons) > 0 {
+			t.Errorf("%s: a failed transaction was recorded in the economy", tt.name)
+		}
+	}
+}
+
+func TestEconomyManagerCommitStats(t *testing.T) {
+	defer setupTestGameState(t)()
+
+	m := &EconomyManager{UnsafeReasons: make(map[string]*EconomyStats)}
+	a, b := &BankAccount{UUID: "a"}, &BankAccount{UUID: "b"}
+
+	err := m.Commit(NewMoneyTransaction().
+		Move(MoneyWorld, a, 300, MoneyReasonAdmin).
+		Move(a, b, 100, MoneyReasonBankTransfer).
+		Move(b, MoneyWorld, 40, MoneyReasonAdmin))
+	if err != nil {
+		t.Fatalf("Commit returned %v", err)
+	}
+
+	if s := m.Stats(MoneyReasonAdmin); s.Created != 300 || s.Destroyed != 40 || s.Transactions != 2 {
+		t.Errorf("admin stats = %+v, want 300 created, 40 destroyed over 2 transactions", s)
+	}
+	if s := m.Stats(MoneyReasonBankTransfer); s.Moved != 100 || s.Transactions != 1 {
+		t.Errorf("transfer stats = %+v, want 100 moved over 1 transaction", s)
+	}
+	if created, destroyed := m.Totals(); created != 300 || destroyed != 40 {
+		t.Errorf("Totals() = %d, %d, want 300, 40", created, destroyed)
+	}
+	if len(a.Transactions()) != 2 || len(b.Transactions()) != 2 {
+		t.Errorf("account histories have %d and %d transactions, want 2 each", len(a.Transactions()), len(b.Transactions()))
+	}
+}
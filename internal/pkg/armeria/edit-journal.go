@@ -0,0 +1,630 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MaxJournalEntries is the number of changes kept within each Character's edit journal.
+const MaxJournalEntries int = 50
+
+// JournalEntry is a single change made by a builder command that can be undone and redone.
+type JournalEntry struct {
+	Description string
+	Time        time.Time
+	UndoFunc    func() error
+	RedoFunc    func() error
+}
+
+// EditJournal records the changes a Character has made using builder commands.
+type EditJournal struct {
+	sync.Mutex
+	undo []*JournalEntry
+	redo []*JournalEntry
+}
+
+var (
+	// ErrJournalEmpty is an error for when there is nothing in the journal to undo or redo.
+	ErrJournalEmpty = errors.New("nothing to undo or redo")
+	// ErrRoomExists is an error for when a room cannot be restored because another room took its place.
+	ErrRoomExists = errors.New("a room already exists at that location")
+	// ErrRoomNotEmpty is an error for when a room cannot be removed because something is in it.
+	ErrRoomNotEmpty = errors.New("there is something in the room")
+	// ErrInstanceExists is an error for when an instance cannot be restored because it already exists.
+	ErrInstanceExists = errors.New("the instance already exists")
+	// ErrInstanceMissing is an error for when an instance cannot be removed because it no longer exists.
+	ErrInstanceMissing = errors.New("the instance no longer exists")
+	// ErrDefinitionExists is an error for when an item, mob, area or ledger cannot be restored because another one
+	// took its name.
+	ErrDefinitionExists = errors.New("something else already has that name")
+	// ErrDefinitionInUse is an error for when an item or mob cannot be removed because it has instances.
+	ErrDefinitionInUse = errors.New("there are instances of it in the game world")
+)
+
+// NewEditJournal creates and returns a new EditJournal.
+func NewEditJournal() *EditJournal {
+	return &EditJournal{
+		undo: make([]*JournalEntry, 0),
+		redo: make([]*JournalEntry, 0),
+	}
+}
+
+// Record adds a change to the journal. Recording a new change discards anything that could have been redone.
+func (j *EditJournal) Record(e *JournalEntry) {
+	j.Lock()
+	defer j.Unlock()
+
+	e.Time = time.Now()
+	j.undo = append(j.undo, e)
+	if len(j.undo) > MaxJournalEntries {
+		j.undo = j.undo[len(j.undo)-MaxJournalEntries:]
+	}
+	j.redo = make([]*JournalEntry, 0)
+}
+
+// Undo reverses the most recent change. If the change cannot be reversed, it is removed from the journal since
+// the game world no longer matches what was recorded.
+func (j *EditJournal) Undo() (*JournalEntry, error) {
+	j.Lock()
+	defer j.Unlock()
+
+	if len(j.undo) == 0 {
+		return nil, ErrJournalEmpty
+	}
+
+	e := j.undo[len(j.undo)-1]
+	j.undo = j.undo[:len(j.undo)-1]
+
+	if err := e.UndoFunc(); err != nil {
+		return e, err
+	}
+
+	j.redo = append(j.redo, e)
+	return e, nil
+}
+
+// Redo re-applies the most recently undone change.
+func (j *EditJournal) Redo() (*JournalEntry, error) {
+	j.Lock()
+	defer j.Unlock()
+
+	if len(j.redo) == 0 {
+		return nil, ErrJournalEmpty
+	}
+
+	e := j.redo[len(j.redo)-1]
+	j.redo = j.redo[:len(j.redo)-1]
+
+	if err := e.RedoFunc(); err != nil {
+		return e, err
+	}
+
+	j.undo = append(j.undo, e)
+	return e, nil
+}
+
+// Entries returns the changes that can be undone, most recent first.
+func (j *EditJournal) Entries() []*JournalEntry {
+	j.Lock()
+	defer j.Unlock()
+
+	entries := make([]*JournalEntry, 0, len(j.undo))
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		entries = append(entries, j.undo[i])
+	}
+
+	return entries
+}
+
+// journalObjectName returns a description of an object for use within journal entries.
+func journalObjectName(o interface{}) string {
+	switch obj := o.(type) {
+	case *Room:
+		return "room " + obj.LocationString()
+	case *Item:
+		return "item " + obj.Name()
+	case *ItemInstance:
+		return fmt.Sprintf("item instance %s (%s)", obj.Name(), obj.ID())
+	case *Mob:
+		return "mob " + obj.Name()
+	case *MobInstance:
+		return fmt.Sprintf("mob instance %s (%s)", obj.Name(), obj.ID())
+	case *Character:
+		return "character " + obj.Name()
+	case *Area:
+		return "area " + obj.Name()
+	case *Ledger:
+		return "ledger " + obj.Name()
+	}
+
+	return "unknown object"
+}
+
+// journalAttribute returns the value of an attribute as it is stored on the object, without any defaults.
+func journalAttribute(o interface{}, name string) string {
+	switch obj := o.(type) {
+	case *Room:
+		obj.RLock()
+		defer obj.RUnlock()
+		return obj.UnsafeAttributes[name]
+	case *Item:
+		obj.RLock()
+		defer obj.RUnlock()
+		return obj.UnsafeAttributes[name]
+	case *ItemInstance:
+		obj.RLock()
+		defer obj.RUnlock()
+		return obj.UnsafeAttributes[name]
+	case *Mob:
+		obj.RLock()
+		defer obj.RUnlock()
+		return obj.UnsafeAttributes[name]
+	case *MobInstance:
+		obj.RLock()
+		defer obj.RUnlock()
+		return obj.UnsafeAttributes[name]
+	case *Character:
+		obj.RLock()
+		defer obj.RUnlock()
+		return obj.UnsafeAttributes[name]
+	}
+
+	return ""
+}
+
+// journalSetAttribute sets an attribute on an object.
+func journalSetAttribute(o interface{}, name, value string) error {
+	switch obj := o.(type) {
+	case *Room:
+		obj.SetAttribute(name, value)
+	case *Item:
+		obj.SetAttribute(name, value)
+	case *ItemInstance:
+		return obj.SetAttribute(name, value)
+	case *Mob:
+		obj.SetAttribute(name, value)
+	case *MobInstance:
+		return obj.SetAttribute(name, value)
+	case *Character:
+		if name != AttributeMoney {
+			return obj.SetAttribute(name, value)
+		}
+
+		amount, _ := strconv.ParseInt(value, 10, 64)
+		if err := SetMoney(obj, Money(amount), MoneyReasonAdmin); err != nil {
+			return err
+		}
+		if obj.Player() != nil {
+			obj.Player().client.SyncMoney()
+		}
+	}
+
+	return nil
+}
+
+// NewAttributeJournalEntry records an attribute change on a room, item, mob or instance. This must be called
+// before the attribute is changed, so that the previous value can be recorded.
+func NewAttributeJournalEntry(o interface{}, name, value string) *JournalEntry {
+	before := journalAttribute(o, name)
+
+	return &JournalEntry{
+		Description: fmt.Sprintf(
+			"set %s of %s from '%s' to '%s'",
+			name,
+			journalObjectName(o),
+			before,
+			value,
+		),
+		UndoFunc: func() error {
+			return journalSetAttribute(o, name, before)
+		},
+		RedoFunc: func() error {
+			return journalSetAttribute(o, name, value)
+		},
+	}
+}
+
+// NewRoomCreateJournalEntry records the creation of a room.
+func NewRoomCreateJournalEntry(r *Room) *JournalEntry {
+	e := NewRoomDestroyJournalEntry(r)
+	e.Description = "created " + journalObjectName(r)
+	e.UndoFunc, e.RedoFunc = e.RedoFunc, e.UndoFunc
+	return e
+}
+
+// NewRoomDestroyJournalEntry records the destruction of a room. The room itself is kept so that it can be restored
+// with the same uuid and attributes.
+func NewRoomDestroyJournalEntry(r *Room) *JournalEntry {
+	a := r.ParentArea
+
+	return &JournalEntry{
+		Description: "destroyed " + journalObjectName(r),
+		UndoFunc: func() error {
+			if a.RoomAt(r.Coords) != nil {
+				return ErrRoomExists
+			}
+			a.AddRoom(r)
+			return nil
+		},
+		RedoFunc: func() error {
+			if r.Here().Count() > 0 {
+				return ErrRoomNotEmpty
+			}
+			a.RemoveRoom(r)
+			return nil
+		},
+	}
+}
+
+// NewRoomMoveJournalEntry records a room being moved to new coordinates. This must be called before the room is
+// moved.
+func NewRoomMoveJournalEntry(r *Room, to *Coords) *JournalEntry {
+	from := CopyCoords(r.Coords)
+	to = CopyCoords(to)
+
+	move := func(c *Coords) error {
+		if r.ParentArea.RoomAt(c) != nil {
+			return ErrRoomExists
+		}
+		r.Coords.SetFrom(c)
+		return nil
+	}
+
+	return &JournalEntry{
+		Description: fmt.Sprintf("moved %s to %s", journalObjectName(r), to.String()),
+		UndoFunc: func() error {
+			return move(from)
+		},
+		RedoFunc: func() error {
+			return move(to)
+		},
+	}
+}
+
+// NewInstanceSpawnJournalEntry records an item or mob instance being spawned into a container.
+func NewInstanceSpawnJournalEntry(o ContainerObject, oc *ObjectContainer) *JournalEntry {
+	e := NewInstanceDestroyJournalEntry(o, oc)
+	e.Description = "spawned " + journalObjectName(o)
+	e.UndoFunc, e.RedoFunc = e.RedoFunc, e.UndoFunc
+	return e
+}
+
+// NewInstanceDestroyJournalEntry records the destruction of an item or mob instance from a container. This must
+// be called before the instance is destroyed. The items within a destroyed container are restored along with it.
+func NewInstanceDestroyJournalEntry(o ContainerObject, oc *ObjectContainer) *JournalEntry {
+	contents := journalContents(o)
+
+	restore := func() error {
+		if _, rt := Armeria.registry.Get(o.ID()); rt != RegistryTypeUnknown {
+			return ErrInstanceExists
+		}
+
+		switch obj := o.(type) {
+		case *ItemInstance:
+			obj.Parent.AddInstance(obj)
+		case *MobInstance:
+			obj.Parent.AddInstance(obj)
+		}
+
+		if err := oc.Add(o.ID()); err != nil {
+			destroyInstance(o)
+			return err
+		}
+
+		for _, jc := range contents {
+			// contents that were never deleted along with the container are still in place
+			if _, rt := Armeria.registry.Get(jc.item.ID()); rt != RegistryTypeUnknown {
+				continue
+			}
+			jc.item.Parent.AddInstance(jc.item)
+			_ = jc.container.Contents().Add(jc.item.ID())
+		}
+
+		return nil
+	}
+
+	destroy := func() error {
+		if _, rt := Armeria.registry.Get(o.ID()); rt == RegistryTypeUnknown {
+			return ErrInstanceMissing
+		}
+
+		contents = journalContents(o)
+		if current := Armeria.registry.GetObjectContainer(o.ID()); current != nil {
+			current.Remove(o.ID())
+		}
+		destroyInstance(o)
+		return nil
+	}
+
+	return &JournalEntry{
+		Description: "destroyed " + journalObjectName(o),
+		UndoFunc:    restore,
+		RedoFunc:    destroy,
+	}
+}
+
+// destroyInstance deletes an item or mob instance.
+func destroyInstance(o ContainerObject) {
+	switch obj := o.(type) {
+	case *ItemInstance:
+		obj.Delete()
+	case *MobInstance:
+		obj.Delete()
+	}
+}
+
+// journalContent is an item that was within a container item when the container was destroyed.
+type journalContent struct {
+	item      *ItemInstance
+	container *ItemInstance
+}
+
+// journalContents returns the items within a container item, including those within nested containers, in the
+// order that they must be restored.
+func journalContents(o ContainerObject) []*journalContent {
+	ii, ok := o.(*ItemInstance)
+	if !ok || !ii.HasContents() {
+		return nil
+	}
+
+	var contents []*journalContent
+	for _, ci := range ii.Contents().Items() {
+		contents = append(contents, &journalContent{item: ci, container: ii})
+		contents = append(contents, journalContents(ci)...)
+	}
+
+	return contents
+}
+
+// NewShiftJournalEntry records rooms being shifted by an offset.
+func NewShiftJournalEntry(a *Area, rooms []*Room, offset *Coords) *JournalEntry {
+	reverse := NewCoords(-offset.X(), -offset.Y(), -offset.Z(), 0)
+
+	return &JournalEntry{
+		Description: fmt.Sprintf("shifted %d rooms in %s by %s", len(rooms), a.Name(), offset.String()),
+		UndoFunc: func() error {
+			return ShiftRooms(a, rooms, reverse)
+		},
+		RedoFunc: func() error {
+			return ShiftRooms(a, rooms, offset)
+		},
+	}
+}
+
+// NewCompositeJournalEntry groups several changes, made by a single command, into one JournalEntry. The changes
+// are undone in the reverse order that they were made.
+func NewCompositeJournalEntry(description string, entries []*JournalEntry) *JournalEntry {
+	return &JournalEntry{
+		Description: description,
+		UndoFunc: func() error {
+			for i := len(entries) - 1; i >= 0; i-- {
+				if err := entries[i].UndoFunc(); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		RedoFunc: func() error {
+			for _, e := range entries {
+				if err := e.RedoFunc(); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// journalFiles returns the contents of files on disk, keyed by path, so that they can be restored later. Files that
+// don't exist are skipped.
+func journalFiles(paths ...string) map[string][]byte {
+	files := make(map[string][]byte)
+	for _, p := range paths {
+		if b, err := ioutil.ReadFile(p); err == nil {
+			files[p] = b
+		}
+	}
+
+	return files
+}
+
+// restoreJournalFiles writes files recorded by journalFiles back to disk.
+func restoreJournalFiles(files map[string][]byte) error {
+	for p, b := range files {
+		if err := ioutil.WriteFile(p, b, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// objectPicturePath returns the path on disk of an object's picture, or an empty string if it doesn't have one.
+func objectPicturePath(key string) string {
+	if len(key) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s", Armeria.objectImagesPath, key)
+}
+
+// NewItemCreateJournalEntry records the creation of an item.
+func NewItemCreateJournalEntry(i *Item) *JournalEntry {
+	e := NewItemDeleteJournalEntry(i)
+	e.Description = "created " + journalObjectName(i)
+	e.UndoFunc, e.RedoFunc = e.RedoFunc, e.UndoFunc
+	return e
+}
+
+// NewItemDeleteJournalEntry records the deletion of an item. Its picture is kept so that it can be restored.
+func NewItemDeleteJournalEntry(i *Item) *JournalEntry {
+	var files map[string][]byte
+
+	return &JournalEntry{
+		Description: "deleted " + journalObjectName(i),
+		UndoFunc: func() error {
+			if Armeria.itemManager.ItemByName(i.Name()) != nil {
+				return ErrDefinitionExists
+			}
+			Armeria.itemManager.AddItem(i)
+			return restoreJournalFiles(files)
+		},
+		RedoFunc: func() error {
+			if len(i.Instances()) > 0 {
+				return ErrDefinitionInUse
+			}
+			files = journalFiles(objectPicturePath(i.Attribute(AttributePicture)))
+			Armeria.itemManager.RemoveItem(i)
+			return nil
+		},
+	}
+}
+
+// NewMobCreateJournalEntry records the creation of a mob.
+func NewMobCreateJournalEntry(m *Mob) *JournalEntry {
+	e := NewMobDeleteJournalEntry(m)
+	e.Description = "created " + journalObjectName(m)
+	e.UndoFunc, e.RedoFunc = e.RedoFunc, e.UndoFunc
+	return e
+}
+
+// NewMobDeleteJournalEntry records the deletion of a mob. Its script and picture are kept so that they can be
+// restored.
+func NewMobDeleteJournalEntry(m *Mob) *JournalEntry {
+	var files map[string][]byte
+
+	return &JournalEntry{
+		Description: "deleted " + journalObjectName(m),
+		UndoFunc: func() error {
+			if Armeria.mobManager.MobByName(m.Name()) != nil {
+				return ErrDefinitionExists
+			}
+			Armeria.mobManager.AddMob(m)
+			return restoreJournalFiles(files)
+		},
+		RedoFunc: func() error {
+			if len(m.Instances()) > 0 {
+				return ErrDefinitionInUse
+			}
+			files = journalFiles(m.ScriptFile(), objectPicturePath(m.Attribute(AttributePicture)))
+			Armeria.mobManager.RemoveMob(m)
+			return nil
+		},
+	}
+}
+
+// NewAreaCreateJournalEntry records the creation of an area.
+func NewAreaCreateJournalEntry(a *Area) *JournalEntry {
+	return &JournalEntry{
+		Description: "created " + journalObjectName(a),
+		UndoFunc: func() error {
+			for _, r := range a.Rooms() {
+				if r.Here().Count() > 0 {
+					return ErrRoomNotEmpty
+				}
+			}
+			Armeria.worldManager.RemoveArea(a)
+			return nil
+		},
+		RedoFunc: func() error {
+			if Armeria.worldManager.AreaByName(a.Name()) != nil {
+				return ErrDefinitionExists
+			}
+			a.Init()
+			for _, r := range a.Rooms() {
+				r.Init(a)
+			}
+			Armeria.worldManager.AddArea(a)
+			return nil
+		},
+	}
+}
+
+// NewLedgerCreateJournalEntry records the creation of a ledger.
+func NewLedgerCreateJournalEntry(l *Ledger) *JournalEntry {
+	return &JournalEntry{
+		Description: "created " + journalObjectName(l),
+		UndoFunc: func() error {
+			Armeria.ledgerManager.RemoveLedger(l)
+			return nil
+		},
+		RedoFunc: func() error {
+			if Armeria.ledgerManager.LedgerByName(l.Name()) != nil {
+				return ErrDefinitionExists
+			}
+			Armeria.ledgerManager.AddLedger(l)
+			return nil
+		},
+	}
+}
+
+// NewLedgerRenameJournalEntry records a ledger being renamed. This must be called before the ledger is renamed.
+func NewLedgerRenameJournalEntry(l *Ledger, name string) *JournalEntry {
+	before := l.Name()
+
+	rename := func(n string) error {
+		if existing := Armeria.ledgerManager.LedgerByName(n); existing != nil && existing != l {
+			return ErrDefinitionExists
+		}
+		l.SetName(n)
+		return nil
+	}
+
+	return &JournalEntry{
+		Description: fmt.Sprintf("renamed ledger %s to %s", before, name),
+		UndoFunc: func() error {
+			return rename(before)
+		},
+		RedoFunc: func() error {
+			return rename(name)
+		},
+	}
+}
+
+// NewLedgerAddJournalEntry records an entry being added to a ledger.
+func NewLedgerAddJournalEntry(l *Ledger, le *LedgerEntry) *JournalEntry {
+	e := NewLedgerRemoveJournalEntry(l, le)
+	e.Description = fmt.Sprintf("added %s to %s", le.ItemName, journalObjectName(l))
+	e.UndoFunc, e.RedoFunc = e.RedoFunc, e.UndoFunc
+	return e
+}
+
+// NewLedgerRemoveJournalEntry records an entry being removed from a ledger.
+func NewLedgerRemoveJournalEntry(l *Ledger, le *LedgerEntry) *JournalEntry {
+	return &JournalEntry{
+		Description: fmt.Sprintf("removed %s from %s", le.ItemName, journalObjectName(l)),
+		UndoFunc: func() error {
+			if l.Contains(le.ItemName) != nil {
+				return ErrDefinitionExists
+			}
+			l.AddEntry(le)
+			return nil
+		},
+		RedoFunc: func() error {
+			l.RemoveEntry(le)
+			return nil
+		},
+	}
+}
+
+// NewLedgerSetJournalEntry records a change to the prices or stock of a ledger entry. This must be called before
+// the entry is changed.
+func NewLedgerSetJournalEntry(l *Ledger, le *LedgerEntry, updated LedgerEntry) *JournalEntry {
+	before := *le
+
+	return &JournalEntry{
+		Description: fmt.Sprintf("changed %s on %s", le.ItemName, journalObjectName(l)),
+		UndoFunc: func() error {
+			*le = before
+			return nil
+		},
+		RedoFunc: func() error {
+			*le = updated
+			return nil
+		},
+	}
+}
@@ -0,0 +1,373 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GuildPermission is something a guild rank allows its members to do. The leader's rank can do everything.
+type GuildPermission string
+
+const (
+	// GuildPermissionInvite allows a member to invite characters to the guild.
+	GuildPermissionInvite GuildPermission = "invite"
+	// GuildPermissionKick allows a member to remove lower-ranked members from the guild.
+	GuildPermissionKick GuildPermission = "kick"
+	// GuildPermissionPromote allows a member to change the rank of lower-ranked members.
+	GuildPermissionPromote GuildPermission = "promote"
+	// GuildPermissionWithdraw allows a member to withdraw money from the guild bank.
+	GuildPermissionWithdraw GuildPermission = "withdraw"
+	// GuildPermissionManage allows a member to change what the lower ranks are allowed to do.
+	GuildPermissionManage GuildPermission = "manage"
+)
+
+// GuildPermissions is every permission a guild rank can be given.
+var GuildPermissions = []GuildPermission{
+	GuildPermissionInvite,
+	GuildPermissionKick,
+	GuildPermissionPromote,
+	GuildPermissionWithdraw,
+	GuildPermissionManage,
+}
+
+// GuildRank is a rank within a guild, and what the members holding it are allowed to do.
+type GuildRank struct {
+	Name        string            `json:"name"`
+	Permissions []GuildPermission `json:"permissions"`
+}
+
+// GuildMember is a character that belongs to a guild.
+type GuildMember struct {
+	Character string    `json:"character"`
+	Rank      int       `json:"rank"`
+	Joined    time.Time `json:"joined"`
+}
+
+// Guild is an organization of characters. Its ranks are ordered from the leader down, and it has a private
+// channel and a bank that its members can deposit money into.
+type Guild struct {
+	sync.RWMutex
+	UUID               string             `json:"uuid"`
+	UnsafeName         string             `json:"name"`
+	UnsafeRanks        []*GuildRank       `json:"ranks"`
+	UnsafeMembers      []*GuildMember     `json:"members"`
+	UnsafeInvited      []string           `json:"invited"`
+	UnsafeBalance      Money              `json:"balance"`
+	UnsafeTransactions []*BankTransaction `json:"transactions"`
+	Created            time.Time          `json:"created"`
+	channel            *Channel
+}
+
+// DefaultGuildRanks returns the ranks a new guild starts with.
+func DefaultGuildRanks() []*GuildRank {
+	return []*GuildRank{
+		{Name: "Leader", Permissions: []GuildPermission{}},
+		{Name: "Officer", Permissions: []GuildPermission{
+			GuildPermissionInvite,
+			GuildPermissionKick,
+			GuildPermissionPromote,
+			GuildPermissionWithdraw,
+		}},
+		{Name: "Member", Permissions: []GuildPermission{}},
+		{Name: "Recruit", Permissions: []GuildPermission{}},
+	}
+}
+
+// ID returns the uuid of the Guild.
+func (g *Guild) ID() string {
+	return g.UUID
+}
+
+// Name returns the name of the Guild.
+func (g *Guild) Name() string {
+	g.RLock()
+	defer g.RUnlock()
+
+	return g.UnsafeName
+}
+
+// Channel returns the private channel that the Guild's members talk on.
+func (g *Guild) Channel() *Channel {
+	g.Lock()
+	defer g.Unlock()
+
+	if g.channel == nil {
+		g.channel = &Channel{
+			Name:  g.UnsafeName,
+			Color: ColorChannelGuild,
+			guild: g,
+		}
+	}
+
+	return g.channel
+}
+
+// Ranks returns the Guild's ranks, from the leader down.
+func (g *Guild) Ranks() []*GuildRank {
+	g.RLock()
+	defer g.RUnlock()
+
+	return g.UnsafeRanks
+}
+
+// RankByName returns the index of the matching rank, or -1 if there isn't one.
+func (g *Guild) RankByName(name string) int {
+	g.RLock()
+	defer g.RUnlock()
+
+	for i, r := range g.UnsafeRanks {
+		if strings.ToLower(r.Name) == strings.ToLower(name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// LowestRank returns the index of the rank that new members join at.
+func (g *Guild) LowestRank() int {
+	g.RLock()
+	defer g.RUnlock()
+
+	return len(g.UnsafeRanks) - 1
+}
+
+// RankName returns the name of a rank.
+func (g *Guild) RankName(rank int) string {
+	g.RLock()
+	defer g.RUnlock()
+
+	if rank < 0 || rank >= len(g.UnsafeRanks) {
+		return "unknown"
+	}
+
+	return g.UnsafeRanks[rank].Name
+}
+
+// member returns the membership of a Character. The caller must hold the lock.
+func (g *Guild) member(c *Character) *GuildMember {
+	for _, m := range g.UnsafeMembers {
+		if m.Character == c.ID() {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// IsMember returns true if the Character belongs to the Guild.
+func (g *Guild) IsMember(c *Character) bool {
+	g.RLock()
+	defer g.RUnlock()
+
+	return g.member(c) != nil
+}
+
+// Members returns the Characters that belong to the Guild, from the highest rank down.
+func (g *Guild) Members() []*Character {
+	g.RLock()
+	defer g.RUnlock()
+
+	var members []*Character
+	for rank := range g.UnsafeRanks {
+		for _, m := range g.UnsafeMembers {
+			if m.Rank != rank {
+				continue
+			}
+
+			if c := Armeria.characterManager.CharacterById(m.Character); c != nil {
+				members = append(members, c)
+			}
+		}
+	}
+
+	return members
+}
+
+// Leader returns the Character that holds the Guild's top rank.
+func (g *Guild) Leader() *Character {
+	g.RLock()
+	defer g.RUnlock()
+
+	for _, m := range g.UnsafeMembers {
+		if m.Rank == 0 {
+			return Armeria.characterManager.CharacterById(m.Character)
+		}
+	}
+
+	return nil
+}
+
+// Rank returns the index of a member's rank, where 0 is the leader, or -1 if they aren't a member.
+func (g *Guild) Rank(c *Character) int {
+	g.RLock()
+	defer g.RUnlock()
+
+	if m := g.member(c); m != nil {
+		return m.Rank
+	}
+
+	return -1
+}
+
+// Outranks returns true if one member holds a higher rank than another.
+func (g *Guild) Outranks(c *Character, other *Character) bool {
+	rank := g.Rank(c)
+	return rank >= 0 && rank < g.Rank(other)
+}
+
+// Can returns true if a member's rank allows them to do something.
+func (g *Guild) Can(c *Character, p GuildPermission) bool {
+	g.RLock()
+	defer g.RUnlock()
+
+	m := g.member(c)
+	if m == nil {
+		return false
+	} else if m.Rank == 0 {
+		return true
+	}
+
+	for _, rp := range g.UnsafeRanks[m.Rank].Permissions {
+		if rp == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetRankPermission gives a permission to a rank, or takes it away.
+func (g *Guild) SetRankPermission(rank int, p GuildPermission, allowed bool) {
+	g.Lock()
+	defer g.Unlock()
+
+	r := g.UnsafeRanks[rank]
+	for i, rp := range r.Permissions {
+		if rp == p {
+			if !allowed {
+				r.Permissions = append(r.Permissions[:i], r.Permissions[i+1:]...)
+			}
+			return
+		}
+	}
+
+	if allowed {
+		r.Permissions = append(r.Permissions, p)
+	}
+}
+
+// AddMember adds a Character to the Guild at a rank, and forgets any invitation they had.
+func (g *Guild) AddMember(c *Character, rank int) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.UnsafeInvited = setMember(g.UnsafeInvited, c.ID(), false)
+	if g.member(c) != nil {
+		return
+	}
+
+	g.UnsafeMembers = append(g.UnsafeMembers, &GuildMember{
+		Character: c.ID(),
+		Rank:      rank,
+		Joined:    time.Now(),
+	})
+}
+
+// RemoveMember removes a Character from the Guild.
+func (g *Guild) RemoveMember(c *Character) {
+	g.Lock()
+	defer g.Unlock()
+
+	for i, m := range g.UnsafeMembers {
+		if m.Character == c.ID() {
+			g.UnsafeMembers = append(g.UnsafeMembers[:i], g.UnsafeMembers[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetRank changes the rank of a member.
+func (g *Guild) SetRank(c *Character, rank int) {
+	g.Lock()
+	defer g.Unlock()
+
+	if m := g.member(c); m != nil {
+		m.Rank = rank
+	}
+}
+
+// Invite allows a Character to join the Guild.
+func (g *Guild) Invite(c *Character) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.UnsafeInvited = setMember(g.UnsafeInvited, c.ID(), true)
+}
+
+// Invited returns true if the Character has been invited to join the Guild.
+func (g *Guild) Invited(c *Character) bool {
+	g.RLock()
+	defer g.RUnlock()
+
+	return misc.Contains(g.UnsafeInvited, c.ID())
+}
+
+// Broadcast sends a message from the game to every member of the Guild who is online.
+func (g *Guild) Broadcast(text string) {
+	g.Channel().Broadcast(nil, text)
+}
+
+// Money returns the amount of money in the Guild's bank.
+func (g *Guild) Money() Money {
+	g.RLock()
+	defer g.RUnlock()
+
+	return g.UnsafeBalance
+}
+
+// setMoney sets the amount of money in the Guild's bank. This must only be called by the EconomyManager.
+func (g *Guild) setMoney(m Money) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.UnsafeBalance = m
+}
+
+// MoneyHolderName returns the name of the Guild's bank as it appears in transaction histories.
+func (g *Guild) MoneyHolderName() string {
+	return fmt.Sprintf("%s's guild bank", g.Name())
+}
+
+// Transactions returns the transaction history of the Guild's bank, oldest first.
+func (g *Guild) Transactions() []*BankTransaction {
+	g.RLock()
+	defer g.RUnlock()
+
+	return g.UnsafeTransactions
+}
+
+// recordMoney appends a money movement to the Guild bank's transaction history.
+func (g *Guild) recordMoney(m *MoneyMovement, balance Money) {
+	tx := &BankTransaction{
+		Time:    time.Now(),
+		Type:    m.Reason,
+		Amount:  m.Amount,
+		Balance: balance,
+	}
+
+	if m.From == MoneyHolder(g) {
+		tx.Amount = -m.Amount
+		tx.Counterparty = m.To.MoneyHolderName()
+	} else {
+		tx.Counterparty = m.From.MoneyHolderName()
+	}
+
+	g.Lock()
+	defer g.Unlock()
+
+	g.UnsafeTransactions = appendTransaction(g.UnsafeTransactions, tx)
+}
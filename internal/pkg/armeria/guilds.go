@@ -0,0 +1,204 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MinGuildNameLength is the shortest a guild's name can be.
+	MinGuildNameLength int = 3
+	// MaxGuildNameLength is the longest a guild's name can be.
+	MaxGuildNameLength int = 40
+)
+
+var (
+	// ErrGuildNameInvalid is an error for when a guild name has the wrong length or characters.
+	ErrGuildNameInvalid = fmt.Errorf(
+		"guild names must be %d to %d letters, numbers, spaces or punctuation",
+		MinGuildNameLength,
+		MaxGuildNameLength,
+	)
+	// ErrGuildNameTaken is an error for when there is already a guild, or a channel, with the name.
+	ErrGuildNameTaken = errors.New("there is already a guild or channel with that name")
+	// ErrAlreadyInGuild is an error for when a character already belongs to a guild.
+	ErrAlreadyInGuild = errors.New("you already belong to a guild")
+
+	guildNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 ,.'&-]*$`)
+)
+
+// GuildManager keeps track of the guilds.
+type GuildManager struct {
+	sync.RWMutex
+	dataFile     string
+	UnsafeGuilds []*Guild `json:"guilds"`
+}
+
+// NewGuildManager creates a new GuildManager.
+func NewGuildManager() *GuildManager {
+	m := &GuildManager{
+		dataFile: fmt.Sprintf("%s/guilds.json", Armeria.dataPath),
+	}
+
+	m.LoadGuilds()
+
+	return m
+}
+
+// LoadGuilds loads the guilds from disk into memory.
+func (m *GuildManager) LoadGuilds() {
+	m.Lock()
+	defer m.Unlock()
+
+	guildsFile, err := os.Open(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer guildsFile.Close()
+
+	jsonParser := json.NewDecoder(guildsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("guilds loaded",
+		zap.Int("count", len(m.UnsafeGuilds)),
+	)
+}
+
+// SaveGuilds writes the in-memory guilds to disk.
+func (m *GuildManager) SaveGuilds() {
+	m.RLock()
+	defer m.RUnlock()
+
+	guildsFile, err := os.Create(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to create data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer guildsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := guildsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = guildsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Guilds returns all of the guilds.
+func (m *GuildManager) Guilds() []*Guild {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeGuilds
+}
+
+// GuildByName returns the matching Guild.
+func (m *GuildManager) GuildByName(name string) *Guild {
+	for _, g := range m.Guilds() {
+		if strings.ToLower(g.Name()) == strings.ToLower(name) {
+			return g
+		}
+	}
+
+	return nil
+}
+
+// GuildOf returns the Guild a Character belongs to, or nil if they don't belong to one.
+func (m *GuildManager) GuildOf(c *Character) *Guild {
+	for _, g := range m.Guilds() {
+		if g.IsMember(c) {
+			return g
+		}
+	}
+
+	return nil
+}
+
+// CreateGuild creates a new Guild led by a Character.
+func (m *GuildManager) CreateGuild(name string, leader *Character) (*Guild, error) {
+	if len(name) < MinGuildNameLength || len(name) > MaxGuildNameLength || !guildNameRegex.MatchString(name) {
+		return nil, ErrGuildNameInvalid
+	} else if m.GuildByName(name) != nil || ChannelByName(name) != nil {
+		return nil, ErrGuildNameTaken
+	} else if m.GuildOf(leader) != nil {
+		return nil, ErrAlreadyInGuild
+	}
+
+	g := &Guild{
+		UUID:               uuid.New().String(),
+		UnsafeName:         name,
+		UnsafeRanks:        DefaultGuildRanks(),
+		UnsafeMembers:      []*GuildMember{},
+		UnsafeInvited:      []string{},
+		UnsafeTransactions: []*BankTransaction{},
+		Created:            time.Now(),
+	}
+	g.AddMember(leader, 0)
+
+	m.Lock()
+	m.UnsafeGuilds = append(m.UnsafeGuilds, g)
+	m.Unlock()
+
+	Armeria.log.Info("guild created",
+		zap.String("guild", name),
+		zap.String("leader", leader.Name()),
+	)
+
+	return g, nil
+}
+
+// RemoveGuild disbands a Guild and forgets the history of its channel.
+func (m *GuildManager) RemoveGuild(g *Guild) {
+	Armeria.chatHistoryManager.ClearChannel(g.Channel())
+
+	m.Lock()
+	defer m.Unlock()
+
+	for i, existing := range m.UnsafeGuilds {
+		if existing == g {
+			m.UnsafeGuilds = append(m.UnsafeGuilds[:i], m.UnsafeGuilds[i+1:]...)
+			break
+		}
+	}
+
+	Armeria.log.Info("guild disbanded",
+		zap.String("guild", g.Name()),
+	)
+}
@@ -0,0 +1,51 @@
+package armeria
+
+import "armeria/internal/pkg/misc"
+
+// MaxIgnoredCharacters is how many characters a character can ignore at once.
+const MaxIgnoredCharacters int = 50
+
+// Ignores returns true if the Character has chosen to stop receiving messages from another Character. The other
+// Character can be nil, for messages sent by the game itself, which can't be ignored.
+func (c *Character) Ignores(other *Character) bool {
+	if other == nil {
+		return false
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+
+	return misc.Contains(c.UnsafeIgnored, other.ID())
+}
+
+// Ignored returns the Characters that the Character is ignoring.
+func (c *Character) Ignored() []*Character {
+	c.RLock()
+	defer c.RUnlock()
+
+	return charactersByID(c.UnsafeIgnored)
+}
+
+// Ignore stops the Character from receiving messages from another Character.
+func (c *Character) Ignore(other *Character) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeIgnored = setMember(c.UnsafeIgnored, other.ID(), true)
+}
+
+// Unignore lets the Character receive messages from another Character again.
+func (c *Character) Unignore(other *Character) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeIgnored = setMember(c.UnsafeIgnored, other.ID(), false)
+}
+
+// ignoredMessage returns true if a chat message was sent by someone the Character is ignoring.
+func (c *Character) ignoredMessage(msg *ChatMessage) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return len(msg.From) > 0 && misc.Contains(c.UnsafeIgnored, msg.From)
+}
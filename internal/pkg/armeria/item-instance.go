@@ -20,12 +20,19 @@ type ItemInstance struct {
 	sync.RWMutex
 	UUID             string            `json:"uuid"`
 	UnsafeAttributes map[string]string `json:"attributes"`
+	UnsafeQuantity   int               `json:"quantity"`
+	UnsafeContents   *ObjectContainer  `json:"contents"`
 	Parent           *Item             `json:"-"`
 }
 
 // Init is called when the ItemInstance is created or loaded from disk.
 func (ii *ItemInstance) Init() {
 	Armeria.registry.Register(ii, ii.ID(), RegistryTypeItemInstance)
+
+	if ii.UnsafeContents != nil {
+		ii.UnsafeContents.AttachParent(ii, ContainerParentTypeItemInstance)
+		ii.UnsafeContents.Sync()
+	}
 }
 
 // Deinit is called when the ItemInstance is deleted.
@@ -66,6 +73,133 @@ func (ii *ItemInstance) FormattedName() string {
 	)
 }
 
+// FormattedQuantity returns the formatted Item name, preceded by either an article or a quantity (ie: a [Apple]
+// or 5x [Apple]).
+func (ii *ItemInstance) FormattedQuantity(qty int) string {
+	if qty == 1 {
+		return "a " + ii.FormattedName()
+	}
+
+	return fmt.Sprintf("%dx %s", qty, ii.FormattedName())
+}
+
+// Quantity returns the number of items in the stack.
+func (ii *ItemInstance) Quantity() int {
+	ii.RLock()
+	defer ii.RUnlock()
+
+	if ii.UnsafeQuantity < 1 {
+		return 1
+	}
+
+	return ii.UnsafeQuantity
+}
+
+// SetQuantity sets the number of items in the stack.
+func (ii *ItemInstance) SetQuantity(qty int) {
+	ii.Lock()
+	defer ii.Unlock()
+
+	ii.UnsafeQuantity = qty
+}
+
+// MaxStack returns the number of items that can be in a single stack.
+func (ii *ItemInstance) MaxStack() int {
+	if size, err := strconv.Atoi(ii.Parent.Attribute(AttributeStackSize)); err == nil && size > 1 {
+		return size
+	}
+
+	return 1
+}
+
+// CanStackWith returns true if another ItemInstance can be merged into the same stack as this one.
+func (ii *ItemInstance) CanStackWith(other *ItemInstance) bool {
+	if ii == other || ii.Parent != other.Parent || ii.MaxStack() <= 1 || ii.IsContainer() {
+		return false
+	}
+
+	ii.RLock()
+	defer ii.RUnlock()
+	other.RLock()
+	defer other.RUnlock()
+
+	if len(ii.UnsafeAttributes) != len(other.UnsafeAttributes) {
+		return false
+	}
+	for k, v := range ii.UnsafeAttributes {
+		if other.UnsafeAttributes[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Split takes a number of items off the stack. If the whole stack is taken the ItemInstance itself is returned,
+// otherwise a new ItemInstance is created, which isn't in any container.
+func (ii *ItemInstance) Split(qty int) *ItemInstance {
+	have := ii.Quantity()
+	if qty <= 0 || qty >= have {
+		return ii
+	}
+
+	ni := ii.Parent.CreateInstance()
+
+	ii.Lock()
+	for k, v := range ii.UnsafeAttributes {
+		ni.UnsafeAttributes[k] = v
+	}
+	ii.UnsafeQuantity = have - qty
+	ii.Unlock()
+
+	ni.SetQuantity(qty)
+
+	return ni
+}
+
+// IsContainer returns true if the ItemInstance can hold other items.
+func (ii *ItemInstance) IsContainer() bool {
+	return ii.Attribute(AttributeType) == ItemTypeContainer
+}
+
+// Contents returns the container holding the items within the ItemInstance, creating it if needed. The capacity
+// of the container follows the capacity attribute.
+func (ii *ItemInstance) Contents() *ObjectContainer {
+	capacity := ii.AttributeInt(AttributeCapacity)
+
+	ii.Lock()
+	if ii.UnsafeContents == nil {
+		ii.UnsafeContents = NewObjectContainer(capacity)
+		ii.UnsafeContents.AttachParent(ii, ContainerParentTypeItemInstance)
+	}
+	oc := ii.UnsafeContents
+	ii.Unlock()
+
+	if oc.MaxSize() != capacity {
+		oc.SetMaxSize(capacity)
+	}
+
+	return oc
+}
+
+// HasContents returns true if there are any items within the ItemInstance.
+func (ii *ItemInstance) HasContents() bool {
+	ii.RLock()
+	defer ii.RUnlock()
+
+	return ii.UnsafeContents != nil && ii.UnsafeContents.Count() > 0
+}
+
+// PutItem moves a number of items from a stack within another container into the ItemInstance. Containers cannot
+// be put inside other containers. The stack the items ended up in is returned.
+func (ii *ItemInstance) PutItem(item *ItemInstance, qty int, from *ObjectContainer) (*ItemInstance, error) {
+	if item == ii || item.IsContainer() {
+		return nil, ErrContainerNested
+	}
+
+	return from.MoveItem(item, qty, ii.Contents())
+}
+
 // SetAttribute sets a permanent attribute on the ItemInstance.
 func (ii *ItemInstance) SetAttribute(name string, value string) error {
 	ii.Lock()
@@ -216,6 +350,29 @@ func (ii *ItemInstance) TooltipContentJSON() string {
 		qualitiesSlice = append(qualitiesSlice, "Equippable")
 	}
 
+	if ii.MaxStack() > 1 {
+		qualitiesSlice = append(qualitiesSlice, fmt.Sprintf("Quantity: %d / %d", ii.Quantity(), ii.MaxStack()))
+	}
+
+	if ii.HasDurability() {
+		qualitiesSlice = append(
+			qualitiesSlice,
+			fmt.Sprintf("Condition: %d / %d (%s)", ii.Condition(), ii.MaxDurability(), ii.ConditionName()),
+		)
+	}
+
+	if ii.IsContainer() {
+		contents := ii.Contents()
+		qualitiesSlice = append(qualitiesSlice, fmt.Sprintf("Holds %d / %d", contents.Count(), contents.MaxSize()))
+		for _, ci := range contents.Items() {
+			if ci.Quantity() > 1 {
+				qualitiesSlice = append(qualitiesSlice, fmt.Sprintf("&nbsp;&nbsp;%dx %s", ci.Quantity(), ci.Name()))
+			} else {
+				qualitiesSlice = append(qualitiesSlice, fmt.Sprintf("&nbsp;&nbsp;%s", ci.Name()))
+			}
+		}
+	}
+
 	tt := map[string]string{
 		"uuid": ii.ID(),
 		"html": fmt.Sprintf(
@@ -229,8 +386,9 @@ func (ii *ItemInstance) TooltipContentJSON() string {
 			ii.RarityName(),
 			strings.Join(qualitiesSlice, "<br />"),
 		),
-		"rarity":  ii.RarityColor(),
-		"picture": ii.Attribute(AttributePicture),
+		"rarity":   ii.RarityColor(),
+		"picture":  ii.Attribute(AttributePicture),
+		"quantity": strconv.Itoa(ii.Quantity()),
 	}
 
 	ttJSON, err := json.Marshal(tt)
@@ -244,8 +402,19 @@ func (ii *ItemInstance) TooltipContentJSON() string {
 	return string(ttJSON)
 }
 
-// Delete removes the item instance from the game. It should be manually removed from containers
-// first before calling this function!
+// Delete removes the item instance, and anything within it, from the game. It should be manually removed from
+// containers first before calling this function!
 func (ii *ItemInstance) Delete() {
+	ii.RLock()
+	contents := ii.UnsafeContents
+	ii.RUnlock()
+
+	if contents != nil {
+		for _, ci := range contents.Items() {
+			contents.Remove(ci.ID())
+			ci.Delete()
+		}
+	}
+
 	ii.Parent.DeleteInstance(ii)
 }
@@ -0,0 +1,54 @@
+package armeria
+
+import "testing"
+
+// newTestItem creates an Item, without adding it to the ItemManager, that stacks up to stackSize.
+func newTestItem(name string, stackSize string) *Item {
+	return &Item{
+		UnsafeName:       name,
+		UnsafeAttributes: map[string]string{AttributeStackSize: stackSize},
+	}
+}
+
+func TestItemInstanceSplit(t *testing.T) {
+	defer setupTestGameState(t)()
+
+	tests := []struct {
+		have      int
+		split     int
+		wantNew   bool
+		wantSplit int
+		wantLeft  int
+	}{
+		{have: 10, split: 3, wantNew: true, wantSplit: 3, wantLeft: 7},
+		{have: 10, split: 9, wantNew: true, wantSplit: 9, wantLeft: 1},
+		{have: 10, split: 10, wantSplit: 10, wantLeft: 10},
+		{have: 10, split: 15, wantSplit: 10, wantLeft: 10},
+		{have: 10, split: 0, wantSplit: 10, wantLeft: 10},
+		{have: 10, split: -1, wantSplit: 10, wantLeft: 10},
+		{have: 1, split: 1, wantSplit: 1, wantLeft: 1},
+	}
+
+	i := newTestItem("Arrow", "20")
+	for _, tt := range tests {
+		ii := i.CreateInstance()
+		ii.SetQuantity(tt.have)
+		if err := ii.SetAttribute(AttributeCondition, "50"); err != nil {
+			t.Fatalf("error setting condition: %s", err)
+		}
+
+		split := ii.Split(tt.split)
+		if isNew := split != ii; isNew != tt.wantNew {
+			t.Errorf("Split(%d) of %d returned a new instance: %t, want %t", tt.split, tt.have, isNew, tt.wantNew)
+		}
+		if split.Quantity() != tt.wantSplit || ii.Quantity() != tt.wantLeft {
+			t.Errorf(
+				"Split(%d) of %d left %d and split off %d, want %d and %d",
+				tt.split, tt.have, ii.Quantity(), split.Quantity(), tt.wantLeft, tt.wantSplit,
+			)
+		}
+		if tt.wantNew && split.UnsafeAttributes[AttributeCondition] != "50" {
+			t.Errorf("Split(%d) of %d did not copy the instance attributes", tt.split, tt.have)
+		}
+	}
+}
@@ -17,11 +17,13 @@ type Item struct {
 }
 
 const (
-	ItemTypeGeneric    string = "generic"
-	ItemTypeMobSpawner        = "mob-spawner"
-	ItemTypeTrashCan          = "trash-can"
-	ItemTypeBreadcrumb        = "mob-breadcrumb"
-	ItemTypeBankCard          = "bank-card"
+	ItemTypeGeneric     string = "generic"
+	ItemTypeMobSpawner         = "mob-spawner"
+	ItemTypeTrashCan           = "trash-can"
+	ItemTypeBreadcrumb         = "mob-breadcrumb"
+	ItemTypeBankCard           = "bank-card"
+	ItemTypeWorkstation        = "workstation"
+	ItemTypeContainer          = "container"
 
 	ItemRarityCommon   string = "common"
 	ItemRarityUncommon        = "uncommon"
@@ -35,6 +37,8 @@ func ItemTypes() []string {
 		ItemTypeBreadcrumb,
 		ItemTypeTrashCan,
 		ItemTypeBankCard,
+		ItemTypeWorkstation,
+		ItemTypeContainer,
 	}
 }
 
@@ -80,6 +84,17 @@ func (i *Item) CreateInstance() *ItemInstance {
 	return ii
 }
 
+// AddInstance adds an existing ItemInstance to the Item and initializes it.
+func (i *Item) AddInstance(ii *ItemInstance) {
+	i.Lock()
+	defer i.Unlock()
+
+	ii.Parent = i
+	i.UnsafeInstances = append(i.UnsafeInstances, ii)
+
+	ii.Init()
+}
+
 // DeleteInstance uninitializes the ItemInstance, unregisters it from the registrar, and
 // removes it from memory.
 func (i *Item) DeleteInstance(ii *ItemInstance) bool {
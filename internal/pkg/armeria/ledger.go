@@ -6,9 +6,12 @@ import (
 )
 
 type LedgerEntry struct {
-	ItemName  string  `json:"name"`
-	BuyPrice  float64 `json:"buy_price"`
-	SellPrice float64 `json:"sell_price"`
+	ItemName       string  `json:"name"`
+	BuyPrice       Money   `json:"buy_price"`
+	SellPrice      Money   `json:"sell_price"`
+	Stock          int     `json:"stock,omitempty"`
+	RestockMinutes int     `json:"restock_minutes,omitempty"`
+	Elasticity     float64 `json:"elasticity,omitempty"`
 }
 type Ledger struct {
 	sync.RWMutex
@@ -124,3 +124,16 @@ func (m *LedgerManager) AddLedger(l *Ledger) {
 
 	m.UnsafeLedgers = append(m.UnsafeLedgers, l)
 }
+
+// RemoveLedger removes an existing Ledger reference from memory.
+func (m *LedgerManager) RemoveLedger(l *Ledger) {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, existing := range m.UnsafeLedgers {
+		if existing == l {
+			m.UnsafeLedgers = append(m.UnsafeLedgers[:i], m.UnsafeLedgers[i+1:]...)
+			return
+		}
+	}
+}
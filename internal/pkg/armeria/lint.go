@@ -0,0 +1,367 @@
+package armeria
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// LintIssue is a problem with the game data that was found by the linter.
+type LintIssue struct {
+	Object  string
+	Problem string
+}
+
+// String returns the LintIssue as a single line of text.
+func (li *LintIssue) String() string {
+	return fmt.Sprintf("%s: %s", li.Object, li.Problem)
+}
+
+// Lint checks the loaded game data for broken content that would otherwise only be discovered at runtime.
+func Lint() []*LintIssue {
+	var issues []*LintIssue
+
+	issues = append(issues, lintExits()...)
+	issues = append(issues, lintUnreachableRooms()...)
+	issues = append(issues, lintItems()...)
+	issues = append(issues, lintMobs()...)
+	issues = append(issues, lintLedgers()...)
+	issues = append(issues, lintRecipes()...)
+	issues = append(issues, lintAttributes()...)
+
+	return issues
+}
+
+// LintGameData runs the linter from the command-line, printing any issues found. The process exits with a non-zero
+// exit code when there are issues so that it can be used within continuous integration.
+func LintGameData() {
+	issues := Lint()
+	for _, li := range issues {
+		fmt.Println(li.String())
+	}
+
+	if len(issues) > 0 {
+		fmt.Printf("\n%d issue(s) found.\n", len(issues))
+		os.Exit(1)
+	}
+
+	fmt.Println("No issues found.")
+}
+
+// lintExits finds explicit room exits that do not lead anywhere.
+func lintExits() []*LintIssue {
+	var issues []*LintIssue
+
+	for _, a := range Armeria.worldManager.Areas() {
+		for _, r := range a.Rooms() {
+			for _, dir := range []string{NorthDirection, SouthDirection, EastDirection, WestDirection, UpDirection, DownDirection} {
+				exit := r.Attribute(dir)
+				if len(exit) == 0 || exit[0:1] == "!" {
+					continue
+				}
+
+				sections := strings.Split(exit, ",")
+				if len(sections) != 3 && len(sections) != 4 {
+					issues = append(issues, &LintIssue{
+						Object:  "room " + r.LocationString(),
+						Problem: fmt.Sprintf("%s exit '%s' is not a valid exit", dir, exit),
+					})
+				} else if len(sections) == 4 && Armeria.worldManager.AreaByName(sections[0]) == nil {
+					issues = append(issues, &LintIssue{
+						Object:  "room " + r.LocationString(),
+						Problem: fmt.Sprintf("%s exit '%s' points to an area that does not exist", dir, exit),
+					})
+				} else if r.ConnectedRoom(dir) == nil {
+					issues = append(issues, &LintIssue{
+						Object:  "room " + r.LocationString(),
+						Problem: fmt.Sprintf("%s exit '%s' points to a room that does not exist", dir, exit),
+					})
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// lintUnreachableRooms finds rooms that cannot be walked to from their area's first room, or from the starting
+// room when it is in the same area.
+func lintUnreachableRooms() []*LintIssue {
+	var issues []*LintIssue
+
+	var start *Room
+	if Armeria.creationManager != nil {
+		start, _ = Armeria.creationManager.StartingRoom()
+	}
+
+	for _, a := range Armeria.worldManager.Areas() {
+		rooms := a.Rooms()
+		if len(rooms) == 0 {
+			continue
+		}
+
+		visited := map[*Room]bool{rooms[0]: true}
+		queue := []*Room{rooms[0]}
+		if start != nil && start.ParentArea == a && !visited[start] {
+			visited[start] = true
+			queue = append(queue, start)
+		}
+
+		for len(queue) > 0 {
+			r := queue[0]
+			queue = queue[1:]
+			for _, dir := range []string{NorthDirection, SouthDirection, EastDirection, WestDirection, UpDirection, DownDirection} {
+				if cr := r.ConnectedRoom(dir); cr != nil && cr.ParentArea == a && !visited[cr] {
+					visited[cr] = true
+					queue = append(queue, cr)
+				}
+			}
+		}
+
+		for _, r := range rooms {
+			if !visited[r] {
+				issues = append(issues, &LintIssue{
+					Object:  "room " + r.LocationString(),
+					Problem: "room cannot be reached from the rest of its area",
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// lintItems finds mob spawners that point to mobs that do not exist.
+func lintItems() []*LintIssue {
+	var issues []*LintIssue
+
+	for _, i := range Armeria.itemManager.ItemsByAttribute(AttributeType, ItemTypeMobSpawner) {
+		for _, ii := range i.Instances() {
+			mobName := ii.Attribute(AttributeSpawnMob)
+			if Armeria.mobManager.MobByName(mobName) == nil {
+				issues = append(issues, &LintIssue{
+					Object:  fmt.Sprintf("item instance %s (%s)", ii.ID(), i.Name()),
+					Problem: fmt.Sprintf("spawner points to mob '%s' which does not exist", mobName),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// lintMobs finds mobs that follow breadcrumbs that do not exist, and mob scripts with syntax errors.
+func lintMobs() []*LintIssue {
+	var issues []*LintIssue
+
+	for _, m := range Armeria.mobManager.Mobs() {
+		crumb := m.Attribute(AttributeFollowCrumb)
+		if len(crumb) > 0 && Armeria.itemManager.ItemByName(crumb) == nil {
+			issues = append(issues, &LintIssue{
+				Object:  "mob " + m.Name(),
+				Problem: fmt.Sprintf("follows breadcrumb '%s' which does not exist", crumb),
+			})
+		}
+
+		if err := lintScript(m.Script(), m.ScriptFile()); err != nil {
+			issues = append(issues, &LintIssue{
+				Object:  "mob " + m.Name(),
+				Problem: fmt.Sprintf("script has errors: %s", err),
+			})
+		}
+	}
+
+	return issues
+}
+
+// lintScript parses and compiles a Lua script without running it.
+func lintScript(script, name string) error {
+	chunk, err := parse.Parse(strings.NewReader(script), name)
+	if err != nil {
+		return err
+	}
+
+	_, err = lua.Compile(chunk, name)
+	return err
+}
+
+// lintLedgers finds ledger entries for items that do not exist.
+func lintLedgers() []*LintIssue {
+	var issues []*LintIssue
+
+	for _, l := range Armeria.ledgerManager.Ledgers() {
+		for _, entry := range l.Entries() {
+			if Armeria.itemManager.ItemByName(entry.ItemName) == nil {
+				issues = append(issues, &LintIssue{
+					Object:  "ledger " + l.Name(),
+					Problem: fmt.Sprintf("entry references item '%s' which does not exist", entry.ItemName),
+				})
+			}
+			if entry.Stock < 0 || entry.RestockMinutes < 0 || entry.Elasticity < 0 || entry.Elasticity >= 1 {
+				issues = append(issues, &LintIssue{
+					Object:  "ledger " + l.Name(),
+					Problem: fmt.Sprintf("entry '%s' has an invalid stock, restock interval or elasticity", entry.ItemName),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// lintRecipes finds recipes that reference items or workstations that do not exist.
+func lintRecipes() []*LintIssue {
+	var issues []*LintIssue
+
+	workstations := make(map[string]bool)
+	for _, i := range Armeria.itemManager.Items() {
+		if i.Attribute(AttributeType) == ItemTypeWorkstation {
+			workstations[strings.ToLower(i.Attribute(AttributeWorkstation))] = true
+		}
+	}
+
+	for _, r := range Armeria.recipeManager.Recipes() {
+		names := []string{r.Output()}
+		names = append(names, r.Tools()...)
+		for _, in := range r.Inputs() {
+			names = append(names, in.ItemName)
+			if in.Quantity < 1 {
+				issues = append(issues, &LintIssue{
+					Object:  "recipe " + r.Name(),
+					Problem: fmt.Sprintf("input '%s' has an invalid quantity", in.ItemName),
+				})
+			}
+		}
+
+		for _, name := range names {
+			if Armeria.itemManager.ItemByName(name) == nil {
+				issues = append(issues, &LintIssue{
+					Object:  "recipe " + r.Name(),
+					Problem: fmt.Sprintf("references item '%s' which does not exist", name),
+				})
+			}
+		}
+
+		if len(r.Workstation()) > 0 && !workstations[strings.ToLower(r.Workstation())] {
+			issues = append(issues, &LintIssue{
+				Object:  "recipe " + r.Name(),
+				Problem: fmt.Sprintf("requires workstation '%s' which no item provides", r.Workstation()),
+			})
+		}
+	}
+
+	return issues
+}
+
+// lintAttributeMap finds unknown attributes and attribute values that do not pass validation.
+func lintAttributeMap(object string, ot ObjectType, attrs map[string]string) []*LintIssue {
+	var issues []*LintIssue
+
+	valid := make(map[string]bool)
+	for _, attr := range AttributeList(ot) {
+		valid[attr] = true
+	}
+
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		val := attrs[name]
+		if !valid[name] {
+			issues = append(issues, &LintIssue{
+				Object:  object,
+				Problem: fmt.Sprintf("unknown attribute '%s'", name),
+			})
+			continue
+		}
+
+		if len(val) == 0 {
+			continue
+		}
+
+		if result := AttributeValidate(ot, name, val); !result.Result {
+			var errs []string
+			for _, e := range result.Errors {
+				if len(e) > 0 {
+					errs = append(errs, e)
+				}
+			}
+			sort.Strings(errs)
+			issues = append(issues, &LintIssue{
+				Object:  object,
+				Problem: fmt.Sprintf("attribute '%s' value '%s' is invalid: %s", name, val, strings.Join(errs, ", ")),
+			})
+		}
+	}
+
+	return issues
+}
+
+// lintAttributes checks the attributes of every object in the game.
+func lintAttributes() []*LintIssue {
+	var issues []*LintIssue
+
+	for _, c := range Armeria.characterManager.Characters() {
+		c.RLock()
+		attrs := copyAttributes(c.UnsafeAttributes)
+		c.RUnlock()
+		issues = append(issues, lintAttributeMap("character "+c.Name(), ObjectTypeCharacter, attrs)...)
+	}
+
+	for _, a := range Armeria.worldManager.Areas() {
+		a.RLock()
+		attrs := copyAttributes(a.UnsafeAttributes)
+		a.RUnlock()
+		issues = append(issues, lintAttributeMap("area "+a.Name(), ObjectTypeArea, attrs)...)
+
+		for _, r := range a.Rooms() {
+			r.RLock()
+			attrs := copyAttributes(r.UnsafeAttributes)
+			r.RUnlock()
+			issues = append(issues, lintAttributeMap("room "+r.LocationString(), ObjectTypeRoom, attrs)...)
+		}
+	}
+
+	for _, i := range Armeria.itemManager.Items() {
+		i.RLock()
+		attrs := copyAttributes(i.UnsafeAttributes)
+		i.RUnlock()
+		issues = append(issues, lintAttributeMap("item "+i.Name(), ObjectTypeItem, attrs)...)
+
+		for _, ii := range i.Instances() {
+			ii.RLock()
+			attrs := copyAttributes(ii.UnsafeAttributes)
+			ii.RUnlock()
+			issues = append(
+				issues,
+				lintAttributeMap(fmt.Sprintf("item instance %s (%s)", ii.ID(), i.Name()), ObjectTypeItemInstance, attrs)...,
+			)
+		}
+	}
+
+	for _, m := range Armeria.mobManager.Mobs() {
+		m.RLock()
+		attrs := copyAttributes(m.UnsafeAttributes)
+		m.RUnlock()
+		issues = append(issues, lintAttributeMap("mob "+m.Name(), ObjectTypeMob, attrs)...)
+
+		for _, mi := range m.Instances() {
+			mi.RLock()
+			attrs := copyAttributes(mi.UnsafeAttributes)
+			mi.RUnlock()
+			issues = append(
+				issues,
+				lintAttributeMap(fmt.Sprintf("mob instance %s (%s)", mi.ID(), m.Name()), ObjectTypeMobInstance, attrs)...,
+			)
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,161 @@
+package armeria
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// LoginBackoffBase is how long someone must wait after their second failed login, doubling with each failure
+	// after that.
+	LoginBackoffBase = time.Second
+	// LoginBackoffMax is the longest someone must wait between failed logins, before being locked out.
+	LoginBackoffMax = time.Minute
+	// LoginLockoutThreshold is how many failed logins, in a row, lock out a character or address.
+	LoginLockoutThreshold int = 10
+	// LoginLockoutDuration is how long a character or address is locked out for.
+	LoginLockoutDuration = 15 * time.Minute
+	// LoginFailureWindow is how long failed logins are remembered for, since the last one.
+	LoginFailureWindow = time.Hour
+)
+
+// loginAttempts tracks the failed logins for a character or an address.
+type loginAttempts struct {
+	Failures    int
+	LastFailure time.Time
+	RetryAfter  time.Time
+	LockedUntil time.Time
+}
+
+// LoginGuard throttles failed logins, by character and by the address they come from, to slow down anyone
+// guessing passwords.
+type LoginGuard struct {
+	sync.Mutex
+	attempts map[string]*loginAttempts
+}
+
+// NewLoginGuard creates a new LoginGuard.
+func NewLoginGuard() *LoginGuard {
+	return &LoginGuard{
+		attempts: make(map[string]*loginAttempts),
+	}
+}
+
+// loginKeys returns the keys that failed logins are tracked under for an address and character name.
+func loginKeys(ip, name string) []string {
+	keys := []string{"ip:" + ip}
+	if len(name) > 0 {
+		keys = append(keys, "char:"+strings.ToLower(name))
+	}
+
+	return keys
+}
+
+// get returns the failed logins tracked under a key, forgetting them once they are old enough. The caller must
+// hold the lock.
+func (g *LoginGuard) get(key string) *loginAttempts {
+	a := g.attempts[key]
+	if a != nil && time.Now().After(a.LockedUntil) && time.Since(a.LastFailure) > LoginFailureWindow {
+		delete(g.attempts, key)
+		return nil
+	}
+
+	return a
+}
+
+// Check returns an error, to show to the player, if logins from the address or to the character are locked out
+// or must wait before trying again.
+func (g *LoginGuard) Check(ip, name string) error {
+	g.Lock()
+	defer g.Unlock()
+
+	now := time.Now()
+	for _, key := range loginKeys(ip, name) {
+		a := g.get(key)
+		if a == nil {
+			continue
+		}
+
+		if now.Before(a.LockedUntil) {
+			return fmt.Errorf(
+				"there have been too many failed login attempts, try again in %s",
+				a.LockedUntil.Sub(now).Round(time.Second),
+			)
+		} else if now.Before(a.RetryAfter) {
+			return fmt.Errorf("please wait %s before trying again", a.RetryAfter.Sub(now).Round(time.Second))
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed login from the address to the character, which may be empty if the character
+// doesn't exist. Returns true if the character, or the address, is now locked out.
+func (g *LoginGuard) RecordFailure(ip, name string) bool {
+	g.Lock()
+	defer g.Unlock()
+
+	now := time.Now()
+	locked := false
+	for _, key := range loginKeys(ip, name) {
+		a := g.get(key)
+		if a == nil {
+			a = &loginAttempts{}
+			g.attempts[key] = a
+		}
+
+		a.Failures++
+		a.LastFailure = now
+
+		if a.Failures >= LoginLockoutThreshold {
+			a.Failures = 0
+			a.LockedUntil = now.Add(LoginLockoutDuration)
+			locked = true
+		} else if a.Failures > 1 {
+			backoff := LoginBackoffBase << uint(a.Failures-2)
+			if backoff > LoginBackoffMax {
+				backoff = LoginBackoffMax
+			}
+			a.RetryAfter = now.Add(backoff)
+		}
+	}
+
+	if locked {
+		Armeria.log.Info("login locked out",
+			zap.String("ip", ip),
+			zap.String("character", name),
+		)
+	}
+
+	return locked
+}
+
+// RecordSuccess forgets the failed logins to the character or account. The failed logins from the address are
+// kept until they expire, so that logging into one account can't be used to keep guessing the passwords of others.
+func (g *LoginGuard) RecordSuccess(name string) {
+	g.Lock()
+	defer g.Unlock()
+
+	delete(g.attempts, "char:"+strings.ToLower(name))
+}
+
+// Clear forgets the failed logins for a character, or an address, lifting any lockout. Returns false if nothing
+// was being tracked.
+func (g *LoginGuard) Clear(characterOrIP string) bool {
+	g.Lock()
+	defer g.Unlock()
+
+	removed := false
+	for _, key := range []string{"char:" + strings.ToLower(characterOrIP), "ip:" + characterOrIP} {
+		if _, ok := g.attempts[key]; ok {
+			delete(g.attempts, key)
+			removed = true
+		}
+	}
+
+	return removed
+}
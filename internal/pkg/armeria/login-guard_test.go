@@ -0,0 +1,138 @@
+package armeria
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginGuardBackoff(t *testing.T) {
+	defer setupTestGameState(t)()
+
+	tests := []struct {
+		failures    int
+		wantBackoff time.Duration
+		wantLocked  bool
+	}{
+		{failures: 1, wantBackoff: 0},
+		{failures: 2, wantBackoff: time.Second},
+		{failures: 3, wantBackoff: 2 * time.Second},
+		{failures: 4, wantBackoff: 4 * time.Second},
+		{failures: 7, wantBackoff: 32 * time.Second},
+		{failures: 8, wantBackoff: LoginBackoffMax},
+		{failures: 9, wantBackoff: LoginBackoffMax},
+		{failures: LoginLockoutThreshold, wantLocked: true},
+	}
+
+	for _, tt := range tests {
+		g := NewLoginGuard()
+
+		locked := false
+		for i := 0; i < tt.failures; i++ {
+			locked = g.RecordFailure("1.2.3.4", "Abel")
+		}
+		if locked != tt.wantLocked {
+			t.Errorf("%d failures: locked out %t, want %t", tt.failures, locked, tt.wantLocked)
+		}
+
+		for _, key := range loginKeys("1.2.3.4", "Abel") {
+			a := g.attempts[key]
+			if tt.wantLocked {
+				if got := a.LockedUntil.Sub(a.LastFailure); got != LoginLockoutDuration {
+					t.Errorf("%d failures: %s locked out for %s, want %s", tt.failures, key, got, LoginLockoutDuration)
+				}
+				continue
+			}
+
+			var got time.Duration
+			if !a.RetryAfter.IsZero() {
+				got = a.RetryAfter.Sub(a.LastFailure)
+			}
+			if got != tt.wantBackoff {
+				t.Errorf("%d failures: %s must wait %s, want %s", tt.failures, key, got, tt.wantBackoff)
+			}
+		}
+
+		if err := g.Check("1.2.3.4", "Abel"); (err != nil) != (tt.wantBackoff > 0 || tt.wantLocked) {
+			t.Errorf("%d failures: Check returned %v", tt.failures, err)
+		}
+	}
+}
+
+func TestLoginGuardLockout(t *testing.T) {
+	defer setupTestGameState(t)()
+
+	lockOut := func(g *LoginGuard) {
+		for i := 0; i < LoginLockoutThreshold; i++ {
+			g.RecordFailure("1.2.3.4", "Abel")
+		}
+	}
+
+	tests := []struct {
+		name    string
+		after   func(g *LoginGuard)
+		ip      string
+		char    string
+		wantErr bool
+	}{
+		{name: "same character and address", ip: "1.2.3.4", char: "Abel", wantErr: true},
+		{name: "character from another address", ip: "5.6.7.8", char: "abel", wantErr: true},
+		{name: "another character from the address", ip: "1.2.3.4", char: "Alexa", wantErr: true},
+		{name: "unrelated login", ip: "5.6.7.8", char: "Alexa"},
+		{
+			name:  "cleared character",
+			after: func(g *LoginGuard) { g.Clear("Abel") },
+			ip:    "5.6.7.8",
+			char:  "Abel",
+		},
+		{
+			name:    "cleared character, locked address",
+			after:   func(g *LoginGuard) { g.Clear("Abel") },
+			ip:      "1.2.3.4",
+			char:    "Abel",
+			wantErr: true,
+		},
+		{
+			name:  "successful login",
+			after: func(g *LoginGuard) { g.RecordSuccess("Abel") },
+			ip:    "5.6.7.8",
+			char:  "Abel",
+		},
+		{
+			name:    "successful login, locked address",
+			after:   func(g *LoginGuard) { g.RecordSuccess("Abel") },
+			ip:      "1.2.3.4",
+			char:    "Abel",
+			wantErr: true,
+		},
+		{
+			name:    "successful login to another account from the address",
+			after:   func(g *LoginGuard) { g.RecordSuccess("Alexa") },
+			ip:      "1.2.3.4",
+			char:    "Alexa",
+			wantErr: true,
+		},
+		{
+			name: "lockout expired",
+			after: func(g *LoginGuard) {
+				for _, a := range g.attempts {
+					a.LockedUntil = time.Now().Add(-time.Minute)
+					a.LastFailure = time.Now().Add(-LoginFailureWindow - time.Minute)
+				}
+			},
+			ip:   "1.2.3.4",
+			char: "Abel",
+		},
+	}
+
+	for _, tt := range tests {
+		g := NewLoginGuard()
+		lockOut(g)
+		if tt.after != nil {
+			tt.after(g)
+		}
+
+		if err := g.Check(tt.ip, tt.char); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Check returned %v, want an error: %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxMailboxSize is the most mail a character can have before other characters can no longer send them mail.
+	MaxMailboxSize int = 50
+	// MaxMailAttachments is the most items that can be attached to a single mail.
+	MaxMailAttachments int = 5
+)
+
+var (
+	// ErrMailboxFull is an error for when mail is sent to a character whose mailbox is full.
+	ErrMailboxFull = errors.New("mailbox is full")
+	// ErrMailItemMissing is an error for when an attached item is no longer in the sender's inventory.
+	ErrMailItemMissing = errors.New("attached item is no longer in inventory")
+)
+
+// Mail is a message delivered to a Character's mailbox. Items and money attached to the mail are held in escrow
+// until the recipient reads it.
+type Mail struct {
+	sync.RWMutex
+	UUID              string           `json:"uuid"`
+	UnsafeFrom        string           `json:"from"`
+	UnsafeSubject     string           `json:"subject"`
+	UnsafeBody        string           `json:"body"`
+	UnsafeSent        time.Time        `json:"sent"`
+	UnsafeRead        bool             `json:"read"`
+	UnsafeAttachments *ObjectContainer `json:"attachments"`
+	UnsafeMoney       Money            `json:"money"`
+}
+
+// MailDraft holds the attachments a Character has chosen for the next mail they send.
+type MailDraft struct {
+	Items []*ItemInstance
+	Money Money
+}
+
+// NewMail creates a new Mail without any attachments.
+func NewMail(from string, subject string, body string) *Mail {
+	m := &Mail{
+		UUID:          uuid.New().String(),
+		UnsafeFrom:    from,
+		UnsafeSubject: subject,
+		UnsafeBody:    body,
+		UnsafeSent:    time.Now(),
+	}
+
+	m.Init()
+
+	return m
+}
+
+// Init is called when the Mail is created or loaded from disk.
+func (m *Mail) Init() {
+	if m.UnsafeAttachments == nil {
+		m.UnsafeAttachments = NewObjectContainer(0)
+	}
+	m.UnsafeAttachments.AttachParent(m, ContainerParentTypeEscrow)
+	m.UnsafeAttachments.Sync()
+}
+
+// ID returns the uuid of the Mail.
+func (m *Mail) ID() string {
+	return m.UUID
+}
+
+// From returns the name of whoever sent the Mail.
+func (m *Mail) From() string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeFrom
+}
+
+// Subject returns the subject of the Mail.
+func (m *Mail) Subject() string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeSubject
+}
+
+// Body returns the message within the Mail.
+func (m *Mail) Body() string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeBody
+}
+
+// Sent returns the time the Mail was sent.
+func (m *Mail) Sent() time.Time {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeSent
+}
+
+// Read returns true if the recipient has read the Mail.
+func (m *Mail) Read() bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeRead
+}
+
+// SetRead sets whether the recipient has read the Mail.
+func (m *Mail) SetRead(read bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeRead = read
+}
+
+// Attachments returns the container holding the items attached to the Mail.
+func (m *Mail) Attachments() *ObjectContainer {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeAttachments
+}
+
+// HasAttachments returns true if there are items or money attached to the Mail.
+func (m *Mail) HasAttachments() bool {
+	return m.Attachments().Count() > 0 || m.Money() > 0
+}
+
+// Money returns the amount of money attached to the Mail.
+func (m *Mail) Money() Money {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeMoney
+}
+
+// setMoney sets the amount of money attached to the Mail. This must only be called by the EconomyManager.
+func (m *Mail) setMoney(money Money) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeMoney = money
+}
+
+// MoneyHolderName returns the name of the Mail as it appears in transaction histories.
+func (m *Mail) MoneyHolderName() string {
+	return fmt.Sprintf("mail from %s", m.From())
+}
+
+// SendMail sends mail from one Character to another, moving the drafted attachments out of the sender's inventory
+// and wallet and into escrow.
+func SendMail(from *Character, to *Character, subject string, body string, draft *MailDraft) (*Mail, error) {
+	if len(to.Mail()) >= MaxMailboxSize {
+		return nil, ErrMailboxFull
+	}
+
+	m := NewMail(from.Name(), subject, body)
+
+	if draft != nil {
+		for _, ii := range draft.Items {
+			if !from.Inventory().Contains(ii.ID()) {
+				m.returnAttachments(from)
+				return nil, ErrMailItemMissing
+			}
+			from.Inventory().Remove(ii.ID())
+			_ = m.Attachments().Add(ii.ID())
+		}
+
+		if draft.Money > 0 {
+			if err := TransferMoney(from, m, draft.Money, MoneyReasonMail); err != nil {
+				m.returnAttachments(from)
+				return nil, err
+			}
+		}
+	}
+
+	to.ReceiveMail(m)
+
+	return m, nil
+}
+
+// returnAttachments moves the items attached to an unsent Mail back into the sender's inventory.
+func (m *Mail) returnAttachments(from *Character) {
+	for _, ii := range m.Attachments().Items() {
+		m.Attachments().Remove(ii.ID())
+		_ = from.Inventory().Add(ii.ID())
+	}
+}
+
+// DeliverItem moves an item instance out of a container and into a Character's inventory. If the Character is
+// offline, or doesn't have room, the item is sent to their mailbox instead.
+func DeliverItem(c *Character, ii *ItemInstance, from *ObjectContainer, sender string, subject string) {
+	from.Remove(ii.ID())
+
+	if c.Online() {
+		if _, err := c.Inventory().AddItem(ii); err == nil {
+			c.Player().client.SyncInventory()
+			return
+		}
+	}
+
+	m := NewMail(sender, subject, "")
+	_ = m.Attachments().Add(ii.ID())
+	c.ReceiveMail(m)
+
+	if c.Online() {
+		c.Player().client.ShowText(
+			fmt.Sprintf("You have no room in your inventory, so a %s was sent to your mailbox.", ii.FormattedName()),
+		)
+	}
+}
+
+// TakeAttachments moves the attachments of a Mail into the Character's inventory and wallet. Items that don't fit
+// are left attached. The names of the items and the amount of money taken are returned.
+func (c *Character) TakeAttachments(m *Mail) ([]string, Money) {
+	var taken []string
+	for _, ii := range m.Attachments().Items() {
+		qty := ii.Quantity()
+		stack, err := m.Attachments().MoveItem(ii, qty, c.Inventory())
+		if err != nil {
+			break
+		}
+		taken = append(taken, stack.FormattedQuantity(qty))
+	}
+
+	money := m.Money()
+	if money > 0 {
+		if err := TransferMoney(m, c, money, MoneyReasonMail); err != nil {
+			money = 0
+		}
+	}
+
+	return taken, money
+}
+
+// UnreadMail returns the number of Mail in the Character's mailbox that they haven't read.
+func (c *Character) UnreadMail() int {
+	unread := 0
+	for _, m := range c.Mail() {
+		if !m.Read() {
+			unread++
+		}
+	}
+
+	return unread
+}
@@ -7,12 +7,14 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
+
 	"go.uber.org/zap"
 )
 
 // SchemaVersion defines the current version of the schema. If the file system is using an older version, a
 // migration will be performed.
-const SchemaVersion int = 6
+const SchemaVersion int = 16
 
 // schemaVersionOnDisk reads the schema version from disk and returns it as an int.
 func schemaVersionOnDisk() int {
@@ -61,6 +63,21 @@ func verifySchemaVersion() {
 	}
 }
 
+// renameStaleAttribute moves the value of an attribute saved under an old name to its current name, unless the
+// current name is already set, and removes the old name.
+func renameStaleAttribute(attributes map[string]string, stale string, current string) {
+	val, exists := attributes[stale]
+	if !exists {
+		return
+	}
+
+	if _, set := attributes[current]; !set && len(val) > 0 {
+		attributes[current] = val
+	}
+
+	delete(attributes, stale)
+}
+
 // migrateCharacters handles migrations for characters.
 func migrateCharacters(to int) {
 	s := struct {
@@ -85,6 +102,21 @@ func migrateCharacters(to int) {
 		case 3:
 			// set UnsafeSettings to an initialized map
 			c.UnsafeSettings = map[string]string{}
+		case 8:
+			// convert money from a decimal amount to minor units
+			if money, exists := c.UnsafeAttributes[AttributeMoney]; exists {
+				f, err := strconv.ParseFloat(money, 64)
+				if err != nil {
+					Armeria.log.Fatal("error parsing character money",
+						zap.String("name", c.UnsafeName),
+						zap.Error(err),
+					)
+				}
+				c.UnsafeAttributes[AttributeMoney] = strconv.FormatInt(int64(MoneyFromFloat(f)), 10)
+			}
+		case 16:
+			// remove the unused role attribute
+			delete(c.UnsafeAttributes, "role")
 		}
 
 		Armeria.log.Info("character migration successful",
@@ -120,11 +152,19 @@ func migrateMobs(to int) {
 	}
 
 	for _, m := range s.Mobs {
+		switch to {
+		case 16:
+			// replace attributes saved before their names were camel-cased
+			renameStaleAttribute(m.UnsafeAttributes, "spawnsfx", AttributeSpawnSFX)
+		}
+
 		for _, mi := range m.Instances() {
 			switch to {
 			case 4:
 				// set UnsafeInventory to an initialized inventory
 				mi.UnsafeInventory = NewObjectContainer(0)
+			case 16:
+				renameStaleAttribute(mi.UnsafeAttributes, "spawnsfx", AttributeSpawnSFX)
 			}
 		}
 
@@ -166,6 +206,11 @@ func migrateItems(to int) {
 			if _, exists := i.UnsafeAttributes["rarity"]; exists {
 				i.UnsafeAttributes["rarity"] = "common"
 			}
+		case 16:
+			// replace attributes saved before their names were camel-cased, and the unused spawn attribute
+			renameStaleAttribute(i.UnsafeAttributes, "spawnmob", AttributeSpawnMob)
+			renameStaleAttribute(i.UnsafeAttributes, "spawnlimit", AttributeSpawnLimit)
+			delete(i.UnsafeAttributes, "spawn")
 		}
 
 		for _, ii := range i.Instances() {
@@ -174,6 +219,10 @@ func migrateItems(to int) {
 				if _, exists := ii.UnsafeAttributes["rarity"]; exists {
 					ii.UnsafeAttributes["rarity"] = "common"
 				}
+			case 16:
+				renameStaleAttribute(ii.UnsafeAttributes, "spawnmob", AttributeSpawnMob)
+				renameStaleAttribute(ii.UnsafeAttributes, "spawnlimit", AttributeSpawnLimit)
+				delete(ii.UnsafeAttributes, "spawn")
 			}
 		}
 
@@ -202,6 +251,321 @@ func migrateLedgers(to int) {
 		}
 		lm.SaveLedgers()
 		Armeria.log.Info("initial ledger created successfully")
+	} else if to == 8 {
+		// convert prices from decimal amounts to minor units
+		s := struct {
+			Ledgers []struct {
+				Name    string `json:"name"`
+				Entries []struct {
+					ItemName       string  `json:"name"`
+					BuyPrice       float64 `json:"buy_price"`
+					SellPrice      float64 `json:"sell_price"`
+					Stock          int     `json:"stock"`
+					RestockMinutes int     `json:"restock_minutes"`
+					Elasticity     float64 `json:"elasticity"`
+				} `json:"entries"`
+			} `json:"ledgers"`
+		}{}
+
+		b, err := ioutil.ReadFile(Armeria.dataPath + "/ledgers.json")
+		if err != nil {
+			Armeria.log.Fatal("error reading ledgers.json", zap.Error(err))
+		}
+
+		err = json.Unmarshal(b, &s)
+		if err != nil {
+			Armeria.log.Fatal("error unmarshalling ledgers.json", zap.Error(err))
+		}
+
+		lm := &LedgerManager{
+			dataFile:      fmt.Sprintf("%s/ledgers.json", Armeria.dataPath),
+			UnsafeLedgers: []*Ledger{},
+		}
+
+		for _, l := range s.Ledgers {
+			ledger := &Ledger{UnsafeName: l.Name}
+			for _, e := range l.Entries {
+				ledger.UnsafeEntries = append(ledger.UnsafeEntries, &LedgerEntry{
+					ItemName:       e.ItemName,
+					BuyPrice:       MoneyFromFloat(e.BuyPrice),
+					SellPrice:      MoneyFromFloat(e.SellPrice),
+					Stock:          e.Stock,
+					RestockMinutes: e.RestockMinutes,
+					Elasticity:     e.Elasticity,
+				})
+			}
+			lm.UnsafeLedgers = append(lm.UnsafeLedgers, ledger)
+
+			Armeria.log.Info("ledger migration successful",
+				zap.String("name", l.Name),
+			)
+		}
+
+		lm.SaveLedgers()
+	}
+}
+
+// migrateBankAccounts handles migrations for bank accounts.
+func migrateBankAccounts(to int) {
+	if to == 7 {
+		bm := &BankManager{
+			dataFile:       fmt.Sprintf("%s/bank-accounts.json", Armeria.dataPath),
+			UnsafeAccounts: []*BankAccount{},
+		}
+		bm.SaveAccounts()
+		Armeria.log.Info("initial bank accounts created successfully")
+	} else if to == 8 {
+		// convert balances from decimal amounts to minor units, and transactions to signed amounts
+		s := struct {
+			Accounts []struct {
+				UUID         string  `json:"uuid"`
+				CardUUID     string  `json:"cardUuid"`
+				OwnerUUID    string  `json:"ownerUuid"`
+				Balance      float64 `json:"balance"`
+				Transactions []struct {
+					Time         time.Time `json:"time"`
+					Type         string    `json:"type"`
+					Amount       float64   `json:"amount"`
+					Balance      float64   `json:"balance"`
+					Character    string    `json:"character"`
+					Counterparty string    `json:"counterparty"`
+				} `json:"transactions"`
+			} `json:"accounts"`
+		}{}
+
+		b, err := ioutil.ReadFile(Armeria.dataPath + "/bank-accounts.json")
+		if err != nil {
+			Armeria.log.Fatal("error reading bank-accounts.json", zap.Error(err))
+		}
+
+		err = json.Unmarshal(b, &s)
+		if err != nil {
+			Armeria.log.Fatal("error unmarshalling bank-accounts.json", zap.Error(err))
+		}
+
+		bm := &BankManager{
+			dataFile:       fmt.Sprintf("%s/bank-accounts.json", Armeria.dataPath),
+			UnsafeAccounts: []*BankAccount{},
+		}
+
+		for _, a := range s.Accounts {
+			ba := &BankAccount{
+				UUID:               a.UUID,
+				UnsafeCardUUID:     a.CardUUID,
+				UnsafeOwnerUUID:    a.OwnerUUID,
+				UnsafeBalance:      MoneyFromFloat(a.Balance),
+				UnsafeTransactions: []*BankTransaction{},
+			}
+
+			for _, t := range a.Transactions {
+				tx := &BankTransaction{
+					Time:         t.Time,
+					Amount:       MoneyFromFloat(t.Amount),
+					Balance:      MoneyFromFloat(t.Balance),
+					Counterparty: t.Character,
+				}
+
+				switch t.Type {
+				case "deposit":
+					tx.Type = MoneyReasonBankDeposit
+				case "withdrawal":
+					tx.Type = MoneyReasonBankWithdrawal
+					tx.Amount = -tx.Amount
+				case "transfer-in":
+					tx.Type = MoneyReasonBankTransfer
+					tx.Counterparty = fmt.Sprintf("%s's bank account", t.Counterparty)
+				case "transfer-out":
+					tx.Type = MoneyReasonBankTransfer
+					tx.Amount = -tx.Amount
+					tx.Counterparty = fmt.Sprintf("%s's bank account", t.Counterparty)
+				default:
+					tx.Type = t.Type
+				}
+
+				ba.UnsafeTransactions = append(ba.UnsafeTransactions, tx)
+			}
+
+			bm.UnsafeAccounts = append(bm.UnsafeAccounts, ba)
+
+			Armeria.log.Info("bank account migration successful",
+				zap.String("uuid", a.UUID),
+			)
+		}
+
+		bm.SaveAccounts()
+	}
+}
+
+// migrateEconomy handles migrations for the economy ledger. This must run after the characters and bank accounts
+// have been migrated.
+func migrateEconomy(to int) {
+	if to == 8 {
+		// the money that already exists is recorded as created by the migration
+		var created Money
+
+		chars := struct {
+			Characters []*Character `json:"characters"`
+		}{}
+
+		b, err := ioutil.ReadFile(Armeria.dataPath + "/characters.json")
+		if err != nil {
+			Armeria.log.Fatal("error reading characters.json", zap.Error(err))
+		}
+
+		err = json.Unmarshal(b, &chars)
+		if err != nil {
+			Armeria.log.Fatal("error unmarshalling characters.json", zap.Error(err))
+		}
+
+		for _, c := range chars.Characters {
+			if m, err := strconv.ParseInt(c.UnsafeAttributes[AttributeMoney], 10, 64); err == nil {
+				created += Money(m)
+			}
+		}
+
+		accounts := struct {
+			Accounts []*BankAccount `json:"accounts"`
+		}{}
+
+		b, err = ioutil.ReadFile(Armeria.dataPath + "/bank-accounts.json")
+		if err != nil {
+			Armeria.log.Fatal("error reading bank-accounts.json", zap.Error(err))
+		}
+
+		err = json.Unmarshal(b, &accounts)
+		if err != nil {
+			Armeria.log.Fatal("error unmarshalling bank-accounts.json", zap.Error(err))
+		}
+
+		for _, ba := range accounts.Accounts {
+			created += ba.UnsafeBalance
+		}
+
+		em := &EconomyManager{
+			dataFile:      fmt.Sprintf("%s/economy.json", Armeria.dataPath),
+			UnsafeReasons: map[string]*EconomyStats{},
+		}
+		if created > 0 {
+			em.UnsafeReasons[MoneyReasonMigration] = &EconomyStats{Created: created}
+		}
+		em.SaveEconomy()
+		Armeria.log.Info("initial economy created successfully",
+			zap.Int64("created", int64(created)),
+		)
+	}
+}
+
+// migrateAuctions handles migrations for auctions.
+func migrateAuctions(to int) {
+	if to == 9 {
+		am := &AuctionManager{
+			dataFile:       fmt.Sprintf("%s/auctions.json", Armeria.dataPath),
+			UnsafeAuctions: []*Auction{},
+		}
+		am.SaveAuctions()
+		Armeria.log.Info("initial auctions created successfully")
+	}
+}
+
+// migrateRecipes handles migrations for recipes.
+func migrateRecipes(to int) {
+	if to == 10 {
+		rm := &RecipeManager{
+			dataFile:      fmt.Sprintf("%s/recipes.json", Armeria.dataPath),
+			UnsafeRecipes: []*Recipe{},
+		}
+		rm.SaveRecipes()
+		Armeria.log.Info("initial recipes created successfully")
+	}
+}
+
+// migrateAccounts handles migrations for accounts.
+func migrateAccounts(to int) {
+	if to == 11 {
+		// wrap each existing character in its own account, using the character's name and password
+		s := struct {
+			Characters []*Character `json:"characters"`
+		}{}
+
+		b, err := ioutil.ReadFile(Armeria.dataPath + "/characters.json")
+		if err != nil {
+			Armeria.log.Fatal("error reading characters.json", zap.Error(err))
+		}
+
+		err = json.Unmarshal(b, &s)
+		if err != nil {
+			Armeria.log.Fatal("error unmarshalling characters.json", zap.Error(err))
+		}
+
+		am := &AccountManager{
+			dataFile:       fmt.Sprintf("%s/accounts.json", Armeria.dataPath),
+			UnsafeAccounts: []*Account{},
+		}
+
+		for _, c := range s.Characters {
+			am.UnsafeAccounts = append(am.UnsafeAccounts, &Account{
+				UUID:             uuid.New().String(),
+				UnsafeUsername:   c.UnsafeName,
+				UnsafePassword:   c.UnsafePassword,
+				UnsafeCharacters: []string{c.UUID},
+				UnsafeCreated:    time.Now(),
+			})
+
+			Armeria.log.Info("account migration successful",
+				zap.String("name", c.UnsafeName),
+			)
+		}
+
+		am.SaveAccounts()
+	}
+}
+
+// migrateModeration handles migrations for moderation.
+func migrateModeration(to int) {
+	if to == 12 {
+		mm := &ModerationManager{
+			dataFile:        fmt.Sprintf("%s/moderation.json", Armeria.dataPath),
+			UnsafeSanctions: []*Sanction{},
+		}
+		mm.SaveSanctions()
+		Armeria.log.Info("initial moderation created successfully")
+	}
+}
+
+// migrateChannels handles migrations for player-created channels.
+func migrateChannels(to int) {
+	if to == 13 {
+		cm := &ChannelManager{
+			dataFile:       fmt.Sprintf("%s/channels.json", Armeria.dataPath),
+			UnsafeChannels: []*Channel{},
+		}
+		cm.SaveChannels()
+		Armeria.log.Info("initial channels created successfully")
+	}
+}
+
+// migrateChatHistory handles migrations for chat history.
+func migrateChatHistory(to int) {
+	if to == 14 {
+		chm := &ChatHistoryManager{
+			dataFile:       fmt.Sprintf("%s/chat-history.json", Armeria.dataPath),
+			UnsafeChannels: map[string][]*ChatMessage{},
+			UnsafeWhispers: map[string][]*ChatMessage{},
+		}
+		chm.SaveHistory()
+		Armeria.log.Info("initial chat history created successfully")
+	}
+}
+
+// migrateGuilds handles migrations for guilds.
+func migrateGuilds(to int) {
+	if to == 15 {
+		gm := &GuildManager{
+			dataFile:     fmt.Sprintf("%s/guilds.json", Armeria.dataPath),
+			UnsafeGuilds: []*Guild{},
+		}
+		gm.SaveGuilds()
+		Armeria.log.Info("initial guilds created successfully")
 	}
 }
 
@@ -220,6 +584,15 @@ func Migrate() {
 		migrateMobs(i)
 		migrateLedgers(i)
 		migrateItems(i)
+		migrateBankAccounts(i)
+		migrateEconomy(i)
+		migrateAuctions(i)
+		migrateRecipes(i)
+		migrateAccounts(i)
+		migrateModeration(i)
+		migrateChannels(i)
+		migrateChatHistory(i)
+		migrateGuilds(i)
 	}
 
 	writeSchemaVersionToDisk(SchemaVersion)
@@ -14,14 +14,18 @@ var _ ContainerObject = (*MobInstance)(nil)
 
 type MobInstance struct {
 	sync.RWMutex
-	UUID                 string            `json:"uuid"`
-	UnsafeAttributes     map[string]string `json:"attributes"`
-	UnsafeInventory      *ObjectContainer  `json:"inventory"`
-	UnsafeItemLedgers    []*Ledger         `json:"-"`
-	Parent               *Mob              `json:"-"`
-	UnsafeMobSpawnerUUID string            `json:"spawnerUUID"`
-	UnsafeMoveTicks      int               `json:"moveTicks"`
-	UnsafeConvoText      map[string]string `json:"-"`
+	UUID                 string                    `json:"uuid"`
+	UnsafeAttributes     map[string]string         `json:"attributes"`
+	UnsafeInventory      *ObjectContainer          `json:"inventory"`
+	UnsafeItemLedgers    []*Ledger                 `json:"-"`
+	UnsafeBanker         bool                      `json:"-"`
+	UnsafeAuctioneer     bool                      `json:"-"`
+	UnsafeRepairer       bool                      `json:"-"`
+	UnsafeShopState      map[string]*ShopItemState `json:"shopState,omitempty"`
+	Parent               *Mob                      `json:"-"`
+	UnsafeMobSpawnerUUID string                    `json:"spawnerUUID"`
+	UnsafeMoveTicks      int                       `json:"moveTicks"`
+	UnsafeConvoText      map[string]string         `json:"-"`
 }
 
 // Init is called when the MobInstance is created or loaded from disk.
@@ -205,6 +209,38 @@ func (mi *MobInstance) ItemLedgers() []*Ledger {
 	return mi.UnsafeItemLedgers
 }
 
+// SetBanker sets whether the mob can handle banking for characters in the same room.
+func (mi *MobInstance) SetBanker(banker bool) {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.UnsafeBanker = banker
+}
+
+// Banker returns true if the mob can handle banking for characters in the same room.
+func (mi *MobInstance) Banker() bool {
+	mi.RLock()
+	defer mi.RUnlock()
+
+	return mi.UnsafeBanker
+}
+
+// SetAuctioneer sets whether the mob can handle the auction house for characters in the same room.
+func (mi *MobInstance) SetAuctioneer(auctioneer bool) {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.UnsafeAuctioneer = auctioneer
+}
+
+// Auctioneer returns true if the mob can handle the auction house for characters in the same room.
+func (mi *MobInstance) Auctioneer() bool {
+	mi.RLock()
+	defer mi.RUnlock()
+
+	return mi.UnsafeAuctioneer
+}
+
 // MobInstance returns the MobInstance's Room based on the object container it is within.
 func (mi *MobInstance) Room() *Room {
 	oc := Armeria.registry.GetObjectContainer(mi.ID())
@@ -106,6 +106,17 @@ func (m *Mob) CreateInstance() *MobInstance {
 	return mi
 }
 
+// AddInstance adds an existing MobInstance to the Mob and initializes it.
+func (m *Mob) AddInstance(mi *MobInstance) {
+	m.Lock()
+	defer m.Unlock()
+
+	mi.Parent = m
+	m.UnsafeInstances = append(m.UnsafeInstances, mi)
+
+	mi.Init()
+}
+
 // DeleteInstance removes the MobInstance from memory.
 func (m *Mob) DeleteInstance(mi *MobInstance) bool {
 	m.Lock()
@@ -0,0 +1,300 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RoomTypeJail is the room type that jailed characters are sent to. Characters can't walk into or out of it.
+const RoomTypeJail string = "jail"
+
+// ErrNoJailRoom is an error for when there isn't a room with the jail type to send a character to.
+var ErrNoJailRoom = errors.New("there is no jail room; set a room's type to jail first")
+
+// ModerationManager keeps track of the sanctions placed on characters and addresses.
+type ModerationManager struct {
+	sync.RWMutex
+	dataFile        string
+	UnsafeSanctions []*Sanction `json:"sanctions"`
+}
+
+// NewModerationManager creates a new ModerationManager.
+func NewModerationManager() *ModerationManager {
+	m := &ModerationManager{
+		dataFile: fmt.Sprintf("%s/moderation.json", Armeria.dataPath),
+	}
+
+	m.LoadSanctions()
+
+	return m
+}
+
+// LoadSanctions loads the sanctions from disk into memory.
+func (m *ModerationManager) LoadSanctions() {
+	m.Lock()
+	defer m.Unlock()
+
+	moderationFile, err := os.Open(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer moderationFile.Close()
+
+	jsonParser := json.NewDecoder(moderationFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("sanctions loaded",
+		zap.Int("count", len(m.UnsafeSanctions)),
+	)
+}
+
+// SaveSanctions writes the in-memory sanctions to disk.
+func (m *ModerationManager) SaveSanctions() {
+	m.RLock()
+	defer m.RUnlock()
+
+	moderationFile, err := os.Create(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to create data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer moderationFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := moderationFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = moderationFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Sanctions returns every sanction, including those that have been lifted.
+func (m *ModerationManager) Sanctions() []*Sanction {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeSanctions
+}
+
+// Active returns the active sanction of a type against a character uuid, or an address. Either can be empty.
+func (m *ModerationManager) Active(st SanctionType, characterID, ip string) *Sanction {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, s := range m.UnsafeSanctions {
+		if s.Type != st || !s.Active() {
+			continue
+		}
+
+		if (len(characterID) > 0 && s.Character == characterID) || (len(ip) > 0 && s.IP == ip) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// AddSanction records a new sanction. A zero duration makes the sanction last until it is lifted.
+func (m *ModerationManager) AddSanction(s *Sanction, d time.Duration) {
+	s.ID = randomHex(4)
+	s.Created = time.Now()
+	if s.Type == SanctionKick {
+		s.Expires = s.Created
+	} else if d > 0 {
+		s.Expires = s.Created.Add(d)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeSanctions = append(m.UnsafeSanctions, s)
+
+	Armeria.log.Info("sanction added",
+		zap.String("type", string(s.Type)),
+		zap.String("target", s.TargetName()),
+		zap.String("by", s.By),
+		zap.Duration("duration", d),
+	)
+}
+
+// Lift ends a sanction early.
+func (m *ModerationManager) Lift(s *Sanction) {
+	m.Lock()
+	defer m.Unlock()
+
+	s.Lifted = time.Now()
+}
+
+// Expired marks the sanctions that have run out on their own as lifted, and returns them.
+func (m *ModerationManager) Expired() []*Sanction {
+	m.Lock()
+	defer m.Unlock()
+
+	var expired []*Sanction
+	for _, s := range m.UnsafeSanctions {
+		if s.Expired() {
+			s.Lifted = s.Expires
+			expired = append(expired, s)
+		}
+	}
+
+	return expired
+}
+
+// JailRoom returns the first room with the jail type.
+func (m *ModerationManager) JailRoom() (*Room, error) {
+	for _, a := range Armeria.worldManager.Areas() {
+		if rooms := a.RoomsWithAttribute(AttributeType, RoomTypeJail); len(rooms) > 0 {
+			return rooms[0], nil
+		}
+	}
+
+	return nil, ErrNoJailRoom
+}
+
+// Jail sends a character to the jail room, remembering where they were so they can be returned once they are
+// released.
+func (m *ModerationManager) Jail(c *Character, reason string, by *Character, d time.Duration) (*Sanction, error) {
+	jail, err := m.JailRoom()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Sanction{
+		Type:      SanctionJail,
+		Character: c.ID(),
+		Reason:    reason,
+		By:        by.Name(),
+	}
+	if r := c.Room(); r != nil {
+		s.ReturnRoom = r.ID()
+	}
+
+	m.AddSanction(s, d)
+
+	c.Move(
+		jail,
+		TextStyle(s.Describe("You have been sent to jail"), WithUserColor(c, ColorError)),
+		TextStyle(fmt.Sprintf("%s is dragged off to jail!", c.FormattedName()), WithUserColor(c, ColorMovement)),
+		TextStyle(fmt.Sprintf("%s is thrown into the cell.", c.FormattedName()), WithUserColor(c, ColorMovement)),
+		"",
+	)
+
+	if c.Online() {
+		Armeria.commandManager.ProcessCommand(c.Player(), "look", false)
+	}
+
+	return s, nil
+}
+
+// Release moves a jailed character back to the room they were jailed from, or the starting room if it no longer
+// exists.
+func (m *ModerationManager) Release(s *Sanction) {
+	c := Armeria.characterManager.CharacterById(s.Character)
+	if c == nil {
+		return
+	}
+
+	var to *Room
+	if o, rt := Armeria.registry.Get(s.ReturnRoom); rt == RegistryTypeRoom {
+		to = o.(*Room)
+	} else if r, err := Armeria.creationManager.StartingRoom(); err == nil {
+		to = r
+	}
+
+	if to == nil {
+		Armeria.log.Error("no room to release character to",
+			zap.String("character", c.Name()),
+		)
+		return
+	}
+
+	c.Move(
+		to,
+		TextStyle("You have been released from jail.", WithUserColor(c, ColorMovement)),
+		TextStyle(fmt.Sprintf("%s has been released.", c.FormattedName()), WithUserColor(c, ColorMovement)),
+		TextStyle(fmt.Sprintf("%s appears in a puff of smoke.", c.FormattedName()), WithUserColor(c, ColorMovement)),
+		"",
+	)
+
+	if c.Online() {
+		Armeria.commandManager.ProcessCommand(c.Player(), "look", false)
+	}
+}
+
+// CommandBlocked returns the reason a character can't use a command because of a sanction, or an empty string if
+// they can.
+func (m *ModerationManager) CommandBlocked(c *Character, cmd *Command) string {
+	if cmd.Speech {
+		if s := c.Muted(); s != nil {
+			return s.Describe("You have been muted")
+		}
+	}
+
+	if cmd.Movement {
+		if s := c.Jailed(); s != nil {
+			return s.Describe("You are in jail")
+		}
+	}
+
+	return ""
+}
+
+// Banned returns the active ban against a character or an address. The character can be nil.
+func (m *ModerationManager) Banned(c *Character, ip string) *Sanction {
+	if c != nil {
+		if s := m.Active(SanctionBan, c.ID(), ""); s != nil {
+			return s
+		}
+	}
+
+	return m.Active(SanctionBan, "", ip)
+}
+
+// SanctionExpiry lifts the sanctions that have run out, letting the characters know and releasing anyone whose
+// jail sentence is over.
+func SanctionExpiry() {
+	for _, s := range Armeria.moderationManager.Expired() {
+		switch s.Type {
+		case SanctionJail:
+			Armeria.moderationManager.Release(s)
+		case SanctionMute:
+			if c := Armeria.characterManager.CharacterById(s.Character); c != nil && c.Online() {
+				c.Player().client.ShowColorizedText("You are no longer muted.", ColorSuccess)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount of money in minor units (cents).
+type Money int64
+
+var (
+	// ErrInvalidMoney is an error for when a string cannot be parsed as an amount of money.
+	ErrInvalidMoney = errors.New("not a valid amount of money")
+)
+
+// MoneyFromFloat converts a decimal amount of money to Money, rounding to the nearest minor unit.
+func MoneyFromFloat(f float64) Money {
+	return Money(math.Round(f * 100))
+}
+
+// ParseMoney parses a decimal amount of money (ie: 12, 12.5 or $12.50) without any loss of precision. Amounts with
+// more than two decimal places are rejected.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "$")
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	sections := strings.Split(s, ".")
+	if len(sections) > 2 || len(sections[0]) == 0 && (len(sections) == 1 || len(sections[1]) == 0) {
+		return 0, ErrInvalidMoney
+	}
+
+	var whole int64
+	if len(sections[0]) > 0 {
+		w, err := strconv.ParseInt(sections[0], 10, 64)
+		if err != nil || w < 0 {
+			return 0, ErrInvalidMoney
+		}
+		whole = w
+	}
+
+	var minor int64
+	if len(sections) == 2 {
+		frac := sections[1]
+		if len(frac) > 2 {
+			return 0, ErrInvalidMoney
+		}
+		frac = frac + strings.Repeat("0", 2-len(frac))
+		m, err := strconv.ParseInt(frac, 10, 64)
+		if err != nil || m < 0 {
+			return 0, ErrInvalidMoney
+		}
+		minor = m
+	}
+
+	if whole > (math.MaxInt64-minor)/100 {
+		return 0, ErrInvalidMoney
+	}
+
+	m := Money(whole*100 + minor)
+	if neg {
+		m = -m
+	}
+
+	return m, nil
+}
+
+// Float returns the amount as a decimal number. This should only be used for display purposes.
+func (m Money) Float() float64 {
+	return float64(m) / 100
+}
+
+// Decimal returns the amount as a plain decimal string (ie: 12.50).
+func (m Money) Decimal() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
+
+// String returns the amount formatted as currency (ie: $12.50).
+func (m Money) String() string {
+	return misc.Money.FormatMoney(m.Float())
+}
@@ -0,0 +1,78 @@
+package armeria
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Money
+		err  bool
+	}{
+		{in: "12", want: 1200},
+		{in: "12.5", want: 1250},
+		{in: "12.50", want: 1250},
+		{in: "$12.05", want: 1205},
+		{in: " 7 ", want: 700},
+		{in: ".5", want: 50},
+		{in: "5.", want: 500},
+		{in: "0", want: 0},
+		{in: "-3.25", want: -325},
+		{in: "$-1", want: -100},
+		{in: "92233720368547758.07", want: 9223372036854775807},
+		{in: "", err: true},
+		{in: ".", err: true},
+		{in: "$", err: true},
+		{in: "abc", err: true},
+		{in: "1.2.3", err: true},
+		{in: "1.234", err: true},
+		{in: "1.-5", err: true},
+		{in: "--1", err: true},
+		{in: "92233720368547758.08", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in)
+		if tt.err {
+			if err == nil {
+				t.Errorf("ParseMoney(%q) = %d, want an error", tt.in, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseMoney(%q) returned an error: %s", tt.in, err)
+		} else if got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	tests := []struct {
+		in   Money
+		want string
+	}{
+		{in: 0, want: "0.00"},
+		{in: 5, want: "0.05"},
+		{in: 50, want: "0.50"},
+		{in: 1250, want: "12.50"},
+		{in: 100000, want: "1000.00"},
+		{in: -5, want: "-0.05"},
+		{in: -1250, want: "-12.50"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.Decimal(); got != tt.want {
+			t.Errorf("Money(%d).Decimal() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyDecimalRoundTrip(t *testing.T) {
+	for _, m := range []Money{0, 1, 99, 100, 123456, -42} {
+		got, err := ParseMoney(m.Decimal())
+		if err != nil || got != m {
+			t.Errorf("ParseMoney(%q) = %d, %v, want %d", m.Decimal(), got, err, m)
+		}
+	}
+}
@@ -56,6 +56,8 @@ var (
 	ErrContainerNoRoom = errors.New("no space in container")
 	// ErrContainerDuplicate is an error for when the container already contains a specific uuid.
 	ErrContainerDuplicate = errors.New("object already in container")
+	// ErrContainerNested is an error for when a container item is put inside another container item.
+	ErrContainerNested = errors.New("containers cannot hold other containers")
 )
 
 // ContainerParentType is an int representing the container's parent type.
@@ -66,6 +68,8 @@ const (
 	ContainerParentTypeRoom ContainerParentType = iota
 	ContainerParentTypeCharacter
 	ContainerParentTypeMobInstance
+	ContainerParentTypeEscrow
+	ContainerParentTypeItemInstance
 )
 
 // NewObjectContainer will return a new object container with the specified max size.
@@ -136,6 +140,36 @@ func (oc *ObjectContainer) ParentMobInstance() *MobInstance {
 	return oc.UnsafeParent.(*MobInstance)
 }
 
+// ParentItemInstance returns the parent ItemInstance if the object has the appropriate parent type.
+func (oc *ObjectContainer) ParentItemInstance() *ItemInstance {
+	oc.RLock()
+	defer oc.RUnlock()
+
+	if oc.UnsafeParentType != ContainerParentTypeItemInstance {
+		return nil
+	}
+
+	return oc.UnsafeParent.(*ItemInstance)
+}
+
+// ParentAuction returns the parent Auction if the container is holding an auction's item in escrow.
+func (oc *ObjectContainer) ParentAuction() *Auction {
+	oc.RLock()
+	defer oc.RUnlock()
+
+	a, _ := oc.UnsafeParent.(*Auction)
+	return a
+}
+
+// ParentMail returns the parent Mail if the container is holding a mail's attachments in escrow.
+func (oc *ObjectContainer) ParentMail() *Mail {
+	oc.RLock()
+	defer oc.RUnlock()
+
+	m, _ := oc.UnsafeParent.(*Mail)
+	return m
+}
+
 // ParentType returns the ContainerParentType that owns this object container.
 func (oc *ObjectContainer) ParentType() ContainerParentType {
 	oc.RLock()
@@ -151,6 +185,14 @@ func (oc *ObjectContainer) MaxSize() int {
 	return oc.UnsafeMaxSize
 }
 
+// SetMaxSize sets the maximum number of objects within the container (0 = unlimited).
+func (oc *ObjectContainer) SetMaxSize(maxSize int) {
+	oc.Lock()
+	defer oc.Unlock()
+
+	oc.UnsafeMaxSize = maxSize
+}
+
 // Contains returns a bool indicating whether the object container contains something with the
 // specified uuid.
 func (oc *ObjectContainer) Contains(uuid string) bool {
@@ -442,11 +484,139 @@ func (oc *ObjectContainer) Add(uuid string) error {
 	return nil
 }
 
-// PopulateFromLedger ensures at least one entry from the ledger, with a buy price, exists within the
-// object container.
+// HasRoomFor returns true if a number of items from a stack can be added to the container, either by merging them
+// into the stacks already within it or by using an empty slot.
+func (oc *ObjectContainer) HasRoomFor(ii *ItemInstance, qty int) bool {
+	free := 0
+	for _, s := range oc.Items() {
+		if s.CanStackWith(ii) {
+			free += s.MaxStack() - s.Quantity()
+		}
+	}
+
+	if qty <= free {
+		return true
+	}
+
+	return oc.MaxSize() == 0 || oc.Count() < oc.MaxSize()
+}
+
+// AddItem adds an item instance to the container, merging it into the stacks already within it where possible. An
+// item instance that is merged entirely is deleted, so the stack the items ended up in is returned. Nothing is
+// changed if there isn't room.
+func (oc *ObjectContainer) AddItem(ii *ItemInstance) (*ItemInstance, error) {
+	if oc.Contains(ii.ID()) {
+		return nil, ErrContainerDuplicate
+	} else if !oc.HasRoomFor(ii, ii.Quantity()) {
+		return nil, ErrContainerNoRoom
+	}
+
+	remaining := ii.Quantity()
+	var stack *ItemInstance
+	for _, s := range oc.Items() {
+		if remaining == 0 {
+			break
+		}
+		if !s.CanStackWith(ii) {
+			continue
+		}
+		space := s.MaxStack() - s.Quantity()
+		if space <= 0 {
+			continue
+		}
+		if space > remaining {
+			space = remaining
+		}
+		s.SetQuantity(s.Quantity() + space)
+		remaining -= space
+		stack = s
+	}
+
+	if remaining == 0 {
+		ii.Delete()
+		return stack, nil
+	}
+
+	ii.SetQuantity(remaining)
+	if err := oc.Add(ii.ID()); err != nil {
+		return nil, err
+	}
+
+	return ii, nil
+}
+
+// MoveItem moves a number of items from a stack within the container into another container, splitting the stack
+// if only some of it is moved. The stack the items ended up in is returned.
+func (oc *ObjectContainer) MoveItem(ii *ItemInstance, qty int, to *ObjectContainer) (*ItemInstance, error) {
+	if qty <= 0 || qty > ii.Quantity() {
+		qty = ii.Quantity()
+	}
+
+	if !to.HasRoomFor(ii, qty) {
+		return nil, ErrContainerNoRoom
+	}
+
+	moved := ii.Split(qty)
+	if moved == ii {
+		oc.Remove(ii.ID())
+	}
+
+	stack, err := to.AddItem(moved)
+	if err != nil {
+		if moved == ii {
+			_ = oc.Add(ii.ID())
+		} else {
+			ii.SetQuantity(ii.Quantity() + qty)
+			moved.Delete()
+		}
+		return nil, err
+	}
+
+	return stack, nil
+}
+
+// ItemQuantity returns the total number of items, with a specific name, across every stack within the container.
+func (oc *ObjectContainer) ItemQuantity(name string) int {
+	count := 0
+	for _, ii := range oc.Items() {
+		if strings.ToLower(ii.Name()) == strings.ToLower(name) {
+			count += ii.Quantity()
+		}
+	}
+
+	return count
+}
+
+// TakeItemQuantity removes a number of items, with a specific name, from the stacks within the container and
+// deletes them. The number of items actually removed is returned.
+func (oc *ObjectContainer) TakeItemQuantity(name string, qty int) int {
+	taken := 0
+	for _, ii := range oc.Items() {
+		if taken == qty {
+			break
+		}
+		if strings.ToLower(ii.Name()) != strings.ToLower(name) {
+			continue
+		}
+
+		if have := ii.Quantity(); have > qty-taken {
+			ii.SetQuantity(have - (qty - taken))
+			taken = qty
+		} else {
+			oc.Remove(ii.ID())
+			ii.Delete()
+			taken += have
+		}
+	}
+
+	return taken
+}
+
+// PopulateFromLedger ensures at least one entry from the ledger, with a buy price and unlimited stock, exists
+// within the object container.
 func (oc *ObjectContainer) PopulateFromLedger(ledger *Ledger) {
 	for _, entry := range ledger.Entries() {
-		if entry.BuyPrice > 0 {
+		if entry.BuyPrice > 0 && entry.Stock == 0 {
 			item := Armeria.itemManager.ItemByName(entry.ItemName)
 			if item != nil {
 				if oc.GetByName(item.Name()).Type == RegistryTypeUnknown {
@@ -0,0 +1,121 @@
+package armeria
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// fillTestContainer creates a container holding a stack of an Item for each quantity.
+func fillTestContainer(i *Item, maxSize int, quantities ...int) *ObjectContainer {
+	oc := NewObjectContainer(maxSize)
+	for _, qty := range quantities {
+		ii := i.CreateInstance()
+		ii.SetQuantity(qty)
+		_ = oc.Add(ii.ID())
+	}
+
+	return oc
+}
+
+// stackQuantities returns the quantity of each stack within a container, smallest first.
+func stackQuantities(oc *ObjectContainer) []int {
+	quantities := []int{}
+	for _, ii := range oc.Items() {
+		quantities = append(quantities, ii.Quantity())
+	}
+	sort.Ints(quantities)
+
+	return quantities
+}
+
+func TestObjectContainerAddItem(t *testing.T) {
+	defer setupTestGameState(t)()
+
+	tests := []struct {
+		name      string
+		stackSize string
+		maxSize   int
+		have      []int
+		add       int
+		err       error
+		merged    bool
+		want      []int
+	}{
+		{name: "empty container", stackSize: "10", have: []int{}, add: 5, want: []int{5}},
+		{name: "merge into a stack", stackSize: "10", have: []int{3}, add: 4, merged: true, want: []int{7}},
+		{name: "overflow into a new stack", stackSize: "10", have: []int{8}, add: 5, want: []int{3, 10}},
+		{name: "spread across stacks", stackSize: "10", have: []int{8, 9}, add: 3, merged: true, want: []int{10, 10}},
+		{name: "fits by merging when full", stackSize: "10", maxSize: 2, have: []int{9, 10}, add: 1, merged: true, want: []int{10, 10}},
+		{name: "no room", stackSize: "10", maxSize: 2, have: []int{10, 10}, add: 1, err: ErrContainerNoRoom, want: []int{10, 10}},
+		{name: "no room after merging", stackSize: "10", maxSize: 1, have: []int{8}, add: 5, err: ErrContainerNoRoom, want: []int{8}},
+		{name: "unstackable", stackSize: "1", have: []int{1}, add: 1, want: []int{1, 1}},
+	}
+
+	for _, tt := range tests {
+		i := newTestItem("Arrow", tt.stackSize)
+		oc := fillTestContainer(i, tt.maxSize, tt.have...)
+
+		ii := i.CreateInstance()
+		ii.SetQuantity(tt.add)
+
+		stack, err := oc.AddItem(ii)
+		if err != tt.err {
+			t.Errorf("%s: AddItem returned %v, want %v", tt.name, err, tt.err)
+		}
+		if got := stackQuantities(oc); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: stacks are %v, want %v", tt.name, got, tt.want)
+		}
+		if err != nil {
+			continue
+		}
+
+		if !oc.Contains(stack.ID()) {
+			t.Errorf("%s: AddItem returned a stack that isn't in the container", tt.name)
+		}
+		if _, rt := Armeria.registry.Get(ii.ID()); (rt == RegistryTypeUnknown) != tt.merged {
+			t.Errorf("%s: added instance deleted: %t, want %t", tt.name, rt == RegistryTypeUnknown, tt.merged)
+		}
+	}
+}
+
+func TestObjectContainerTakeItemQuantity(t *testing.T) {
+	defer setupTestGameState(t)()
+
+	tests := []struct {
+		name      string
+		have      []int
+		take      int
+		wantTaken int
+		want      []int
+	}{
+		{name: "part of a stack", have: []int{10}, take: 4, wantTaken: 4, want: []int{6}},
+		{name: "a whole stack", have: []int{4, 10}, take: 4, wantTaken: 4, want: []int{10}},
+		{name: "across stacks", have: []int{5, 10}, take: 12, wantTaken: 12, want: []int{3}},
+		{name: "everything", have: []int{5, 10}, take: 15, wantTaken: 15, want: []int{}},
+		{name: "more than there is", have: []int{4}, take: 20, wantTaken: 4, want: []int{}},
+		{name: "nothing", have: []int{4}, take: 0, wantTaken: 0, want: []int{4}},
+	}
+
+	for _, tt := range tests {
+		arrows := newTestItem("Arrow", "10")
+		oc := fillTestContainer(arrows, 0, tt.have...)
+
+		bolt := newTestItem("Bolt", "10").CreateInstance()
+		bolt.SetQuantity(7)
+		_ = oc.Add(bolt.ID())
+
+		if got := oc.TakeItemQuantity("arrow", tt.take); got != tt.wantTaken {
+			t.Errorf("%s: TakeItemQuantity took %d, want %d", tt.name, got, tt.wantTaken)
+		}
+
+		want := append(append([]int{}, tt.want...), 7)
+		sort.Ints(want)
+		if got := stackQuantities(oc); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: stacks are %v, want %v", tt.name, got, want)
+		}
+		if oc.ItemQuantity("Bolt") != 7 {
+			t.Errorf("%s: TakeItemQuantity took items with a different name", tt.name)
+		}
+	}
+}
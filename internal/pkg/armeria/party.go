@@ -0,0 +1,269 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// MaxPartySize is how many characters, including the leader, can be in a party at once.
+const MaxPartySize int = 6
+
+// Party is a group of online characters adventuring together. Parties aren't saved, and a character leaves their
+// party when they log out. When the leader leaves, the longest-standing member takes over.
+type Party struct {
+	sync.RWMutex
+	members []*Character
+	invited []*Character
+	channel *Channel
+}
+
+// NewParty creates a new Party led by a Character.
+func NewParty(leader *Character) *Party {
+	p := &Party{
+		members: []*Character{leader},
+		invited: make([]*Character, 0),
+	}
+	p.channel = &Channel{
+		Name:  "Party",
+		Color: ColorChannelParty,
+		party: p,
+	}
+
+	leader.SetParty(p)
+
+	return p
+}
+
+// Channel returns the channel that the Party's members talk on.
+func (p *Party) Channel() *Channel {
+	return p.channel
+}
+
+// Leader returns the Character leading the Party.
+func (p *Party) Leader() *Character {
+	p.RLock()
+	defer p.RUnlock()
+
+	if len(p.members) == 0 {
+		return nil
+	}
+
+	return p.members[0]
+}
+
+// IsLeader returns true if the Character leads the Party.
+func (p *Party) IsLeader(c *Character) bool {
+	return p.Leader() == c
+}
+
+// Members returns the Characters in the Party, starting with the leader.
+func (p *Party) Members() []*Character {
+	p.RLock()
+	defer p.RUnlock()
+
+	return append([]*Character{}, p.members...)
+}
+
+// IsMember returns true if the Character is in the Party.
+func (p *Party) IsMember(c *Character) bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	for _, m := range p.members {
+		if m == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Full returns true if there is no room for anyone else in the Party.
+func (p *Party) Full() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return len(p.members) >= MaxPartySize
+}
+
+// Invite allows a Character to join the Party.
+func (p *Party) Invite(c *Character) {
+	p.Lock()
+	defer p.Unlock()
+
+	for _, i := range p.invited {
+		if i == c {
+			return
+		}
+	}
+
+	p.invited = append(p.invited, c)
+}
+
+// Invited returns true if the Character has been invited to join the Party.
+func (p *Party) Invited(c *Character) bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	for _, i := range p.invited {
+		if i == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Add adds a Character to the Party, and forgets their invitation.
+func (p *Party) Add(c *Character) {
+	p.Lock()
+	for i, invited := range p.invited {
+		if invited == c {
+			p.invited = append(p.invited[:i], p.invited[i+1:]...)
+			break
+		}
+	}
+	p.members = append(p.members, c)
+	p.Unlock()
+
+	c.SetParty(p)
+
+	p.Broadcast(fmt.Sprintf("%s has joined the party.", c.FormattedName()))
+	p.Sync()
+}
+
+// Leave removes a Character from the Party, letting the remaining members know why. The Party is disbanded once
+// there is only one member left.
+func (p *Party) Leave(c *Character, reason string) {
+	p.Lock()
+	wasLeader := len(p.members) > 0 && p.members[0] == c
+	for i, m := range p.members {
+		if m == c {
+			p.members = append(p.members[:i], p.members[i+1:]...)
+			break
+		}
+	}
+	remaining := append([]*Character{}, p.members...)
+	p.Unlock()
+
+	c.SetParty(nil)
+	if c.Online() {
+		c.Player().client.SyncParty()
+	}
+
+	if len(remaining) <= 1 {
+		for _, m := range remaining {
+			m.SetParty(nil)
+			if m.Online() {
+				m.Player().client.ShowColorizedText(
+					fmt.Sprintf("%s The party has been disbanded.", reason),
+					ColorChannelParty,
+				)
+				m.Player().client.SyncParty()
+			}
+		}
+		return
+	}
+
+	p.Broadcast(reason)
+	if wasLeader {
+		p.Broadcast(fmt.Sprintf("%s is now leading the party.", remaining[0].FormattedName()))
+	}
+	p.Sync()
+}
+
+// Broadcast sends a message from the game to every member of the Party.
+func (p *Party) Broadcast(text string) {
+	p.channel.Broadcast(nil, text)
+}
+
+// Sync updates the party members shown on each member's client.
+func (p *Party) Sync() {
+	for _, m := range p.Members() {
+		if m.Online() {
+			m.Player().client.SyncParty()
+		}
+	}
+}
+
+// MembersJSON returns the JSON used for rendering the party members on the client.
+func (p *Party) MembersJSON() string {
+	var members []map[string]interface{}
+
+	for i, m := range p.Members() {
+		members = append(members, map[string]interface{}{
+			"uuid":    m.ID(),
+			"name":    m.Name(),
+			"title":   m.Attribute(AttributeTitle),
+			"picture": m.Attribute(AttributePicture),
+			"leader":  i == 0,
+		})
+	}
+
+	membersJSON, err := json.Marshal(members)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal party data",
+			zap.Error(err),
+		)
+	}
+
+	return string(membersJSON)
+}
+
+// Party returns the party the Character is in.
+func (c *Character) Party() *Party {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeParty
+}
+
+// SetParty sets the party the Character is in.
+func (c *Character) SetParty(p *Party) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeParty = p
+}
+
+// InPartyWith returns true if the Character is in the same party as another Character.
+func (c *Character) InPartyWith(other *Character) bool {
+	p := c.Party()
+	return p != nil && p.IsMember(other)
+}
+
+// Following returns the Character that this Character follows from room to room, if any.
+func (c *Character) Following() *Character {
+	id := c.TempAttribute(TempAttributeFollowing)
+	if len(id) == 0 {
+		return nil
+	}
+
+	return Armeria.characterManager.CharacterById(id)
+}
+
+// SetFollowing sets the Character to follow from room to room. Passing nil stops following.
+func (c *Character) SetFollowing(leader *Character) {
+	if leader == nil {
+		c.SetTempAttribute(TempAttributeFollowing, "")
+		return
+	}
+
+	c.SetTempAttribute(TempAttributeFollowing, leader.ID())
+}
+
+// followersIn returns the Characters in a room that are following the Character and are still in the same party
+// as them.
+func (c *Character) followersIn(r *Room) []*Character {
+	var followers []*Character
+	for _, char := range r.Here().Characters(true, c) {
+		if char.TempAttribute(TempAttributeFollowing) == c.ID() && char.InPartyWith(c) {
+			followers = append(followers, char)
+		}
+	}
+
+	return followers
+}
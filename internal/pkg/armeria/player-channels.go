@@ -0,0 +1,220 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxChannelsPerCharacter is how many channels a character can own at once.
+	MaxChannelsPerCharacter int = 3
+	// MinChannelNameLength is the shortest a player-created channel's name can be.
+	MinChannelNameLength int = 3
+	// MaxChannelNameLength is the longest a player-created channel's name can be.
+	MaxChannelNameLength int = 20
+	// MaxChannelTopicLength is the longest a channel's topic can be.
+	MaxChannelTopicLength int = 120
+)
+
+var (
+	// ErrChannelNameInvalid is an error for when a channel name has the wrong length or characters.
+	ErrChannelNameInvalid = fmt.Errorf(
+		"channel names must be %d to %d letters, numbers or dashes",
+		MinChannelNameLength,
+		MaxChannelNameLength,
+	)
+	// ErrChannelNameTaken is an error for when there is already a channel with the name.
+	ErrChannelNameTaken = errors.New("there is already a channel with that name")
+	// ErrTooManyChannels is an error for when a character already owns the most channels they can.
+	ErrTooManyChannels = fmt.Errorf("you can't own more than %d channels", MaxChannelsPerCharacter)
+
+	channelNameRegex = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+)
+
+// ChannelManager keeps track of the channels created by players.
+type ChannelManager struct {
+	sync.RWMutex
+	dataFile       string
+	UnsafeChannels []*Channel `json:"channels"`
+}
+
+// NewChannelManager creates a new ChannelManager.
+func NewChannelManager() *ChannelManager {
+	m := &ChannelManager{
+		dataFile: fmt.Sprintf("%s/channels.json", Armeria.dataPath),
+	}
+
+	m.LoadChannels()
+
+	return m
+}
+
+// LoadChannels loads the player-created channels from disk into memory.
+func (m *ChannelManager) LoadChannels() {
+	m.Lock()
+	defer m.Unlock()
+
+	channelsFile, err := os.Open(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer channelsFile.Close()
+
+	jsonParser := json.NewDecoder(channelsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	for _, ch := range m.UnsafeChannels {
+		ch.Color = ColorChannelPlayer
+	}
+
+	Armeria.log.Info("channels loaded",
+		zap.Int("count", len(m.UnsafeChannels)),
+	)
+}
+
+// SaveChannels writes the in-memory player-created channels to disk.
+func (m *ChannelManager) SaveChannels() {
+	m.RLock()
+	defer m.RUnlock()
+
+	channelsFile, err := os.Create(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to create data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer channelsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := channelsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = channelsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Channels returns all of the player-created channels.
+func (m *ChannelManager) Channels() []*Channel {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeChannels
+}
+
+// ChannelByName returns the matching player-created Channel.
+func (m *ChannelManager) ChannelByName(name string) *Channel {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, ch := range m.UnsafeChannels {
+		if strings.ToLower(ch.Name) == strings.ToLower(name) {
+			return ch
+		}
+	}
+
+	return nil
+}
+
+// OwnedBy returns the channels created by a Character.
+func (m *ChannelManager) OwnedBy(c *Character) []*Channel {
+	var owned []*Channel
+	for _, ch := range m.Channels() {
+		if ch.IsOwner(c) {
+			owned = append(owned, ch)
+		}
+	}
+
+	return owned
+}
+
+// CreateChannel creates a new Channel owned by a Character, who joins it straight away.
+func (m *ChannelManager) CreateChannel(name string, owner *Character) (*Channel, error) {
+	if len(name) < MinChannelNameLength || len(name) > MaxChannelNameLength || !channelNameRegex.MatchString(name) {
+		return nil, ErrChannelNameInvalid
+	} else if ChannelByName(name) != nil || Armeria.guildManager.GuildByName(name) != nil {
+		return nil, ErrChannelNameTaken
+	} else if len(m.OwnedBy(owner)) >= MaxChannelsPerCharacter {
+		return nil, ErrTooManyChannels
+	}
+
+	ch := &Channel{
+		Name:             name,
+		Color:            ColorChannelPlayer,
+		UnsafeOwner:      owner.ID(),
+		UnsafeModerators: []string{},
+		UnsafeBanned:     []string{},
+		UnsafeInvited:    []string{},
+		Created:          time.Now(),
+	}
+
+	m.Lock()
+	m.UnsafeChannels = append(m.UnsafeChannels, ch)
+	m.Unlock()
+
+	owner.JoinChannel(ch)
+
+	Armeria.log.Info("channel created",
+		zap.String("channel", name),
+		zap.String("owner", owner.Name()),
+	)
+
+	return ch, nil
+}
+
+// RemoveChannel deletes a player-created Channel, removing it from every Character that joined it and forgetting
+// its history.
+func (m *ChannelManager) RemoveChannel(ch *Channel) {
+	for _, c := range ch.Members() {
+		c.LeaveChannel(ch)
+	}
+
+	Armeria.chatHistoryManager.ClearChannel(ch)
+
+	m.Lock()
+	defer m.Unlock()
+
+	for i, existing := range m.UnsafeChannels {
+		if existing == ch {
+			m.UnsafeChannels = append(m.UnsafeChannels[:i], m.UnsafeChannels[i+1:]...)
+			break
+		}
+	}
+
+	Armeria.log.Info("channel removed",
+		zap.String("channel", ch.Name),
+	)
+}
@@ -14,9 +14,13 @@ type Player struct {
 	sync.RWMutex
 	client           ClientActions
 	socket           *websocket.Conn
+	ip               string
 	pumpsInitialized bool
 	sendData         chan *OutgoingDataStructure
 	character        *Character
+	account          *Account
+	creation         *CharacterCreation
+	sessionID        string
 }
 
 type IncomingDataStructure struct {
@@ -151,6 +155,59 @@ func (p *Player) Character() *Character {
 	return p.character
 }
 
+// AttachAccount sets the Account the player has logged in with.
+func (p *Player) AttachAccount(a *Account) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.account = a
+}
+
+// Account returns the Account the player has logged in with, or nil if they haven't logged in.
+func (p *Player) Account() *Account {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.account
+}
+
+// IP returns the address the player connected from.
+func (p *Player) IP() string {
+	return p.ip
+}
+
+// SessionID returns the id of the character's session that this player is using for auto-login, if any.
+func (p *Player) SessionID() string {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.sessionID
+}
+
+// SetSessionID sets the id of the character's session that this player is using for auto-login.
+func (p *Player) SetSessionID(id string) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.sessionID = id
+}
+
+// Creation returns the character the player is in the middle of creating, or nil if they aren't creating one.
+func (p *Player) Creation() *CharacterCreation {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.creation
+}
+
+// SetCreation sets the character the player is in the middle of creating.
+func (p *Player) SetCreation(cc *CharacterCreation) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.creation = cc
+}
+
 func (p *Player) PlayerInfoJSON() string {
 	pi := map[string]string{
 		"uuid": p.Character().ID(),
@@ -21,12 +21,13 @@ func NewPlayerManager() *PlayerManager {
 }
 
 // NewPlayer creates a new Player instance, adds it to memory, and returns Player.
-func (m *PlayerManager) NewPlayer(conn *websocket.Conn) *Player {
+func (m *PlayerManager) NewPlayer(conn *websocket.Conn, ip string) *Player {
 	m.Lock()
 	defer m.Unlock()
 
 	p := &Player{
 		socket:           conn,
+		ip:               ip,
 		pumpsInitialized: false,
 		sendData:         make(chan *OutgoingDataStructure, 256),
 	}
@@ -36,7 +37,7 @@ func (m *PlayerManager) NewPlayer(conn *websocket.Conn) *Player {
 	m.players[p] = true
 
 	Armeria.log.Info("player connected",
-		zap.String("ip", conn.RemoteAddr().String()),
+		zap.String("ip", ip),
 		zap.Int("players", len(m.players)),
 	)
 
@@ -59,8 +60,14 @@ func (m *PlayerManager) DisconnectPlayer(p *Player) {
 		p.character.SetPlayer(nil)
 		// Unset unsafeCharacter from parent
 		p.AttachCharacter(nil)
+		p.SetSessionID("")
+		p.AttachAccount(nil)
 	}
 
+	// Release any name reserved during character creation
+	Armeria.creationManager.Release(p)
+	p.SetCreation(nil)
+
 	// Fatal if data should of been sent but wasn't
 	if len(p.sendData) > 0 {
 		Armeria.log.Error("player disconnected with unsent data",
@@ -86,3 +93,34 @@ func (m *PlayerManager) DisconnectPlayer(p *Player) {
 		zap.Int("players", len(m.players)),
 	)
 }
+
+// PlayersFromIP returns the connected players from an address.
+func (m *PlayerManager) PlayersFromIP(ip string) []*Player {
+	m.RLock()
+	defer m.RUnlock()
+
+	var players []*Player
+	for p := range m.players {
+		if p.IP() == ip {
+			players = append(players, p)
+		}
+	}
+
+	return players
+}
+
+// PlayersFromAccount returns the connected players that are logged into an account, including those that are
+// still choosing a character.
+func (m *PlayerManager) PlayersFromAccount(a *Account) []*Player {
+	m.RLock()
+	defer m.RUnlock()
+
+	var players []*Player
+	for p := range m.players {
+		if p.Account() == a {
+			players = append(players, p)
+		}
+	}
+
+	return players
+}
@@ -0,0 +1,229 @@
+package armeria
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecipeInput is an item, and how many of it, that is used up when crafting a recipe.
+type RecipeInput struct {
+	ItemName string `json:"name"`
+	Quantity int    `json:"quantity"`
+}
+
+// Recipe describes how an item is crafted: the inputs used up, the tools needed in the inventory, the kind of
+// workstation needed in the room and how long it takes.
+type Recipe struct {
+	sync.RWMutex
+	UnsafeName        string         `json:"name"`
+	UnsafeOutput      string         `json:"output"`
+	UnsafeOutputCount int            `json:"outputCount"`
+	UnsafeInputs      []*RecipeInput `json:"inputs"`
+	UnsafeTools       []string       `json:"tools"`
+	UnsafeWorkstation string         `json:"workstation"`
+	UnsafeSeconds     int            `json:"seconds"`
+}
+
+// Name returns the name of the recipe.
+func (r *Recipe) Name() string {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.UnsafeName
+}
+
+// Output returns the name of the item the recipe creates.
+func (r *Recipe) Output() string {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.UnsafeOutput
+}
+
+// SetOutput sets the name of the item the recipe creates.
+func (r *Recipe) SetOutput(item string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.UnsafeOutput = item
+}
+
+// OutputCount returns how many items the recipe creates.
+func (r *Recipe) OutputCount() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	if r.UnsafeOutputCount < 1 {
+		return 1
+	}
+
+	return r.UnsafeOutputCount
+}
+
+// SetOutputCount sets how many items the recipe creates.
+func (r *Recipe) SetOutputCount(count int) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.UnsafeOutputCount = count
+}
+
+// Inputs returns the items used up when crafting the recipe.
+func (r *Recipe) Inputs() []*RecipeInput {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.UnsafeInputs
+}
+
+// SetInput sets how many of an item are used up when crafting the recipe. A quantity of 0 removes the input.
+func (r *Recipe) SetInput(item string, quantity int) {
+	r.Lock()
+	defer r.Unlock()
+
+	for i, in := range r.UnsafeInputs {
+		if strings.ToLower(in.ItemName) == strings.ToLower(item) {
+			if quantity > 0 {
+				in.Quantity = quantity
+			} else {
+				r.UnsafeInputs = append(r.UnsafeInputs[:i], r.UnsafeInputs[i+1:]...)
+			}
+			return
+		}
+	}
+
+	if quantity > 0 {
+		r.UnsafeInputs = append(r.UnsafeInputs, &RecipeInput{ItemName: item, Quantity: quantity})
+	}
+}
+
+// Tools returns the names of the items that must be in the inventory, but aren't used up, to craft the recipe.
+func (r *Recipe) Tools() []string {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.UnsafeTools
+}
+
+// ToggleTool adds a tool to the recipe, or removes it if it is already needed. Returns true if the tool was added.
+func (r *Recipe) ToggleTool(item string) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	for i, t := range r.UnsafeTools {
+		if strings.ToLower(t) == strings.ToLower(item) {
+			r.UnsafeTools = append(r.UnsafeTools[:i], r.UnsafeTools[i+1:]...)
+			return false
+		}
+	}
+
+	r.UnsafeTools = append(r.UnsafeTools, item)
+	return true
+}
+
+// Workstation returns the kind of workstation that must be in the room to craft the recipe, if any.
+func (r *Recipe) Workstation() string {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.UnsafeWorkstation
+}
+
+// SetWorkstation sets the kind of workstation that must be in the room to craft the recipe.
+func (r *Recipe) SetWorkstation(kind string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.UnsafeWorkstation = kind
+}
+
+// Duration returns how long it takes to craft the recipe.
+func (r *Recipe) Duration() time.Duration {
+	r.RLock()
+	defer r.RUnlock()
+
+	return time.Duration(r.UnsafeSeconds) * time.Second
+}
+
+// SetDuration sets how long it takes to craft the recipe.
+func (r *Recipe) SetDuration(d time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.UnsafeSeconds = int(d.Seconds())
+}
+
+// Unlocked returns true if the recipe can be crafted in the room, based on the workstations within it.
+func (r *Recipe) Unlocked(room *Room) bool {
+	kind := r.Workstation()
+	if len(kind) == 0 {
+		return true
+	}
+
+	for _, ii := range room.Here().Items() {
+		if ii.Attribute(AttributeType) == ItemTypeWorkstation &&
+			strings.ToLower(ii.Attribute(AttributeWorkstation)) == strings.ToLower(kind) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Missing returns a description of everything the Character is missing to craft the recipe, or an empty slice if
+// they have everything they need.
+func (r *Recipe) Missing(c *Character) []string {
+	var missing []string
+
+	if !r.Unlocked(c.Room()) {
+		missing = append(missing, fmt.Sprintf("a %s nearby", r.Workstation()))
+	}
+
+	for _, t := range r.Tools() {
+		if r.Tool(c, t) == nil {
+			missing = append(missing, fmt.Sprintf("a %s that isn't broken", t))
+		}
+	}
+
+	for _, in := range r.Inputs() {
+		if have := c.Inventory().ItemQuantity(in.ItemName); have < in.Quantity {
+			missing = append(missing, fmt.Sprintf("%dx %s", in.Quantity-have, in.ItemName))
+		}
+	}
+
+	return missing
+}
+
+// Tool returns an item, in the Character's inventory, that can be used as one of the recipe's tools. Broken items
+// cannot be used.
+func (r *Recipe) Tool(c *Character, name string) *ItemInstance {
+	for _, ii := range c.Inventory().Items() {
+		if strings.ToLower(ii.Name()) == strings.ToLower(name) && !ii.Broken() {
+			return ii
+		}
+	}
+
+	return nil
+}
+
+// Requirements returns a short description of the inputs, tools and workstation needed to craft the recipe.
+func (r *Recipe) Requirements() string {
+	var reqs []string
+	for _, in := range r.Inputs() {
+		reqs = append(reqs, fmt.Sprintf("%dx %s", in.Quantity, in.ItemName))
+	}
+	for _, t := range r.Tools() {
+		reqs = append(reqs, fmt.Sprintf("%s (tool)", t))
+	}
+	if len(r.Workstation()) > 0 {
+		reqs = append(reqs, fmt.Sprintf("%s (workstation)", r.Workstation()))
+	}
+
+	if len(reqs) == 0 {
+		return "nothing"
+	}
+
+	return strings.Join(reqs, ", ")
+}
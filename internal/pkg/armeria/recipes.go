@@ -0,0 +1,148 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+type RecipeManager struct {
+	sync.RWMutex
+	dataFile      string
+	UnsafeRecipes []*Recipe `json:"recipes"`
+}
+
+// NewRecipeManager creates a new RecipeManager.
+func NewRecipeManager() *RecipeManager {
+	m := &RecipeManager{
+		dataFile: fmt.Sprintf("%s/recipes.json", Armeria.dataPath),
+	}
+
+	m.LoadRecipes()
+
+	return m
+}
+
+// LoadRecipes loads the recipes from disk into memory.
+func (m *RecipeManager) LoadRecipes() {
+	m.Lock()
+	defer m.Unlock()
+
+	recipesFile, err := os.Open(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer recipesFile.Close()
+
+	jsonParser := json.NewDecoder(recipesFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("recipes loaded",
+		zap.Int("count", len(m.UnsafeRecipes)),
+	)
+}
+
+// SaveRecipes writes the in-memory recipes to disk.
+func (m *RecipeManager) SaveRecipes() {
+	m.RLock()
+	defer m.RUnlock()
+
+	recipesFile, err := os.Create(m.dataFile)
+	if err != nil {
+		Armeria.log.Fatal("failed to create data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+	defer recipesFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := recipesFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = recipesFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Recipes returns all of the in-memory Recipes.
+func (m *RecipeManager) Recipes() []*Recipe {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeRecipes
+}
+
+// RecipeByName returns the matching Recipe, by name.
+func (m *RecipeManager) RecipeByName(name string) *Recipe {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, r := range m.UnsafeRecipes {
+		if strings.ToLower(r.Name()) == strings.ToLower(name) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// CreateRecipe creates a new Recipe instance, but doesn't add it to memory.
+func (m *RecipeManager) CreateRecipe(name string, output string) *Recipe {
+	return &Recipe{
+		UnsafeName:        name,
+		UnsafeOutput:      output,
+		UnsafeOutputCount: 1,
+		UnsafeInputs:      []*RecipeInput{},
+		UnsafeTools:       []string{},
+	}
+}
+
+// AddRecipe adds a new Recipe reference to memory.
+func (m *RecipeManager) AddRecipe(r *Recipe) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeRecipes = append(m.UnsafeRecipes, r)
+}
+
+// RemoveRecipe removes a Recipe from memory.
+func (m *RecipeManager) RemoveRecipe(r *Recipe) {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, rr := range m.UnsafeRecipes {
+		if rr == r {
+			m.UnsafeRecipes = append(m.UnsafeRecipes[:i], m.UnsafeRecipes[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RoomSelection is a set of rooms, within a single area, that a builder has selected for bulk editing.
+type RoomSelection struct {
+	Area    *Area
+	Rooms   []*Room
+	Pending *BulkRoomOperation
+}
+
+// BulkRoomOperation is a change to every room in a RoomSelection that has been previewed, but not yet confirmed.
+// Rooms can be destroyed between the preview and the confirmation, so Apply works on the rooms that still exist and
+// returns how many were changed.
+type BulkRoomOperation struct {
+	Description string
+	Apply       func() (int, error)
+}
+
+var (
+	// ErrShiftOverlap is an error for when shifting rooms would place them on top of rooms that were not selected.
+	ErrShiftOverlap = errors.New("shifted rooms would overlap existing rooms")
+)
+
+// NewRoomSelection creates and returns a new RoomSelection.
+func NewRoomSelection(a *Area, rooms []*Room) *RoomSelection {
+	return &RoomSelection{
+		Area:  a,
+		Rooms: rooms,
+	}
+}
+
+// ValidRooms returns the selected rooms that still exist within the game world.
+func (rs *RoomSelection) ValidRooms() []*Room {
+	var rooms []*Room
+	for _, r := range rs.Rooms {
+		if o, rt := Armeria.registry.Get(r.ID()); rt == RegistryTypeRoom && o == r {
+			rooms = append(rooms, r)
+		}
+	}
+
+	return rooms
+}
+
+// PreviewTable returns a table of the selected rooms, showing no more than max rooms.
+func (rs *RoomSelection) PreviewTable(max int) string {
+	rows := []string{TableRow(
+		TableCell{content: "Location", header: true},
+		TableCell{content: "Title", header: true},
+	)}
+
+	rooms := rs.ValidRooms()
+	for i, r := range rooms {
+		if i == max {
+			rows = append(rows, TableRow(
+				TableCell{content: TextStyle(fmt.Sprintf("...and %d more", len(rooms)-max), WithItalics())},
+				TableCell{content: ""},
+			))
+			break
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: r.LocationString()},
+			TableCell{content: r.Attribute(AttributeTitle)},
+		))
+	}
+
+	return TextTable(rows...)
+}
+
+// ShiftRooms moves the rooms within an area by an offset. Explicit exits that point to a shifted room, from anywhere
+// in the game world, are updated to point to the room's new location.
+func ShiftRooms(a *Area, rooms []*Room, offset *Coords) error {
+	selected := make(map[*Room]bool)
+	for _, r := range rooms {
+		selected[r] = true
+	}
+
+	newCoords := make(map[*Room]*Coords)
+	for _, r := range rooms {
+		c := NewCoords(r.Coords.X()+offset.X(), r.Coords.Y()+offset.Y(), r.Coords.Z()+offset.Z(), r.Coords.I())
+		if existing := a.RoomAt(c); existing != nil && !selected[existing] {
+			return ErrShiftOverlap
+		}
+		newCoords[r] = c
+	}
+
+	// Keep track of where each room used to be so explicit exits can be re-pointed.
+	moved := make(map[string]string)
+	for r, c := range newCoords {
+		moved[r.Coords.String()] = c.String()
+	}
+
+	for _, area := range Armeria.worldManager.Areas() {
+		for _, r := range area.Rooms() {
+			for _, dir := range []string{NorthDirection, SouthDirection, EastDirection, WestDirection, UpDirection, DownDirection} {
+				exit := r.Attribute(dir)
+				sections := strings.Split(exit, ",")
+				if len(sections) == 3 && area == a {
+					if c, err := ParseCoords(exit); err == nil && len(moved[c.String()]) > 0 {
+						r.SetAttribute(dir, moved[c.String()])
+					}
+				} else if len(sections) == 4 && strings.ToLower(sections[0]) == strings.ToLower(a.Name()) {
+					if c, err := ParseCoords(strings.Join(sections[1:], ",")); err == nil && len(moved[c.String()]) > 0 {
+						r.SetAttribute(dir, sections[0]+","+moved[c.String()])
+					}
+				}
+			}
+		}
+	}
+
+	for r, c := range newCoords {
+		r.Coords.SetFrom(c)
+	}
+
+	return nil
+}
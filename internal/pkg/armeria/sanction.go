@@ -0,0 +1,86 @@
+package armeria
+
+import (
+	"fmt"
+	"time"
+)
+
+// SanctionType is the kind of action a moderator has taken against a character or address.
+type SanctionType string
+
+const (
+	// SanctionMute stops a character from talking to other characters.
+	SanctionMute SanctionType = "mute"
+	// SanctionKick disconnects a character. It is only kept as a record, and is never active.
+	SanctionKick SanctionType = "kick"
+	// SanctionBan stops a character, or anyone from an address, from logging in.
+	SanctionBan SanctionType = "ban"
+	// SanctionJail moves a character to the jail room and stops them from leaving it.
+	SanctionJail SanctionType = "jail"
+)
+
+// Sanction is an action a moderator has taken against a character, or an address. A Sanction with a zero expiry
+// lasts until it is lifted.
+type Sanction struct {
+	ID         string       `json:"id"`
+	Type       SanctionType `json:"type"`
+	Character  string       `json:"character,omitempty"`
+	IP         string       `json:"ip,omitempty"`
+	Reason     string       `json:"reason"`
+	By         string       `json:"by"`
+	Created    time.Time    `json:"created"`
+	Expires    time.Time    `json:"expires"`
+	Lifted     time.Time    `json:"lifted"`
+	ReturnRoom string       `json:"returnRoom,omitempty"`
+}
+
+// Active returns true if the Sanction is still in effect.
+func (s *Sanction) Active() bool {
+	if s.Type == SanctionKick || !s.Lifted.IsZero() {
+		return false
+	}
+
+	return s.Expires.IsZero() || time.Now().Before(s.Expires)
+}
+
+// Expired returns true if the Sanction ran out on its own, but hasn't been marked as lifted yet.
+func (s *Sanction) Expired() bool {
+	return s.Type != SanctionKick && s.Lifted.IsZero() && !s.Expires.IsZero() && time.Now().After(s.Expires)
+}
+
+// TargetName returns the name of the character the Sanction is against, or the address.
+func (s *Sanction) TargetName() string {
+	if len(s.Character) > 0 {
+		if c := Armeria.characterManager.CharacterById(s.Character); c != nil {
+			return c.Name()
+		}
+	}
+
+	return s.IP
+}
+
+// Describe returns a sentence describing the Sanction, to show to the character it is against.
+func (s *Sanction) Describe(prefix string) string {
+	msg := prefix
+	if s.Expires.IsZero() {
+		msg = fmt.Sprintf("%s permanently", msg)
+	} else {
+		msg = fmt.Sprintf("%s until %s", msg, s.Expires.Format("Mon Jan 2 2006 15:04 MST"))
+	}
+
+	if len(s.Reason) > 0 {
+		msg = fmt.Sprintf("%s: %s", msg, s.Reason)
+	}
+
+	return msg + "."
+}
+
+// Muted returns the mute on the Character, or nil if they can talk.
+func (c *Character) Muted() *Sanction {
+	return Armeria.moderationManager.Active(SanctionMute, c.ID(), "")
+}
+
+// Jailed returns the jail sentence the Character is serving, or nil if they are free to move.
+func (c *Character) Jailed() *Sanction {
+	return Armeria.moderationManager.Active(SanctionJail, c.ID(), "")
+}
@@ -0,0 +1,96 @@
+package armeria
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSanctionActive(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		sanction    Sanction
+		wantActive  bool
+		wantExpired bool
+	}{
+		{name: "kick", sanction: Sanction{Type: SanctionKick}},
+		{name: "kick with expiry", sanction: Sanction{Type: SanctionKick, Expires: past}},
+		{name: "permanent", sanction: Sanction{Type: SanctionBan}, wantActive: true},
+		{name: "not expired yet", sanction: Sanction{Type: SanctionMute, Expires: future}, wantActive: true},
+		{name: "expired", sanction: Sanction{Type: SanctionJail, Expires: past}, wantExpired: true},
+		{name: "lifted", sanction: Sanction{Type: SanctionBan, Lifted: past}},
+		{name: "lifted before expiry", sanction: Sanction{Type: SanctionMute, Expires: future, Lifted: past}},
+		{name: "lifted after expiry", sanction: Sanction{Type: SanctionJail, Expires: past, Lifted: past}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.sanction.Active(); got != tt.wantActive {
+			t.Errorf("%s: Active() = %t, want %t", tt.name, got, tt.wantActive)
+		}
+		if got := tt.sanction.Expired(); got != tt.wantExpired {
+			t.Errorf("%s: Expired() = %t, want %t", tt.name, got, tt.wantExpired)
+		}
+	}
+}
+
+// findTestCommand returns a registered command, or subcommand, by name.
+func findTestCommand(names ...string) *Command {
+	var found *Command
+	commands := Armeria.commandManager.Commands()
+	for _, name := range names {
+		found = nil
+		for _, cmd := range commands {
+			if cmd.Name == name {
+				found = cmd
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		commands = found.Subcommands
+	}
+
+	return found
+}
+
+func TestModerationManagerCommandBlocked(t *testing.T) {
+	defer setupTestGameState(t)()
+	Armeria.moderationManager = &ModerationManager{}
+	Armeria.commandManager = NewCommandManager()
+	RegisterGameCommands()
+
+	muted := &Character{UUID: "muted"}
+	jailed := &Character{UUID: "jailed"}
+	Armeria.moderationManager.AddSanction(&Sanction{Type: SanctionMute, Character: muted.ID()}, 0)
+	Armeria.moderationManager.AddSanction(&Sanction{Type: SanctionJail, Character: jailed.ID()}, 0)
+
+	tests := []struct {
+		character   *Character
+		command     []string
+		wantBlocked bool
+	}{
+		{character: muted, command: []string{"say"}, wantBlocked: true},
+		{character: muted, command: []string{"whisper"}, wantBlocked: true},
+		{character: muted, command: []string{"mail", "send"}, wantBlocked: true},
+		{character: muted, command: []string{"mail", "read"}},
+		{character: muted, command: []string{"move"}},
+		{character: jailed, command: []string{"mail", "send"}},
+		{character: jailed, command: []string{"move"}, wantBlocked: true},
+		{character: &Character{UUID: "free"}, command: []string{"mail", "send"}},
+	}
+
+	for _, tt := range tests {
+		cmd := findTestCommand(tt.command...)
+		if cmd == nil {
+			t.Fatalf("command %v is not registered", tt.command)
+		}
+
+		msg := Armeria.moderationManager.CommandBlocked(tt.character, cmd)
+		if blocked := len(msg) > 0; blocked != tt.wantBlocked {
+			t.Errorf("%s using %v: blocked %t, want %t", tt.character.ID(), tt.command, blocked, tt.wantBlocked)
+		}
+	}
+}
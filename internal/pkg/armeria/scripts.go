@@ -1,10 +1,10 @@
 package armeria
 
 import (
-	"armeria/internal/pkg/misc"
 	"context"
 	"fmt"
 	"io/ioutil"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap"
@@ -121,7 +121,16 @@ func LuaSetCharacterAttribute(L *lua.LState) int {
 		return 1
 	}
 
-	if !tmp {
+	if !tmp && attr == AttributeMoney {
+		amount, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || amount < 0 || SetMoney(c, Money(amount), MoneyReasonScript) != nil {
+			L.Push(lua.LNumber(-2))
+			return 1
+		}
+		if c.Player() != nil {
+			c.Player().client.SyncMoney()
+		}
+	} else if !tmp {
 		err := c.SetAttribute(attr, val)
 		if err != nil {
 			L.Push(lua.LNumber(-2))
@@ -135,6 +144,48 @@ func LuaSetCharacterAttribute(L *lua.LState) int {
 	return 1
 }
 
+// LuaCharacterParty (c_party) returns the uuids of the members of a Character's party, starting with the leader.
+func LuaCharacterParty(L *lua.LState) int {
+	uuid := L.ToString(1)
+
+	c := Armeria.characterManager.CharacterById(uuid)
+	if c == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	members := L.NewTable()
+	if p := c.Party(); p != nil {
+		for _, m := range p.Members() {
+			members.Append(lua.LString(m.ID()))
+		}
+	}
+
+	L.Push(members)
+	return 1
+}
+
+// LuaCharacterPartyLeader (c_party_leader) returns the uuid of the leader of a Character's party.
+func LuaCharacterPartyLeader(L *lua.LState) int {
+	uuid := L.ToString(1)
+
+	c := Armeria.characterManager.CharacterById(uuid)
+	if c == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	var leader string
+	if p := c.Party(); p != nil {
+		if l := p.Leader(); l != nil {
+			leader = l.ID()
+		}
+	}
+
+	L.Push(lua.LString(leader))
+	return 1
+}
+
 // LuaItemName (i_name) returns the formatted item name from an item uuid.
 func LuaItemName(L *lua.LState) int {
 	uuid := L.ToString(1)
@@ -309,8 +360,10 @@ func LuaShop(L *lua.LState) int {
 		return 0
 	}
 
-	// Ensure mob's inventory has at least one item instance from everything on the ledger.
+	// Ensure mob's inventory has at least one item instance from everything on the ledger, and that any
+	// limited items have been stocked.
 	mi.Inventory().PopulateFromLedger(ledger)
+	mi.RestockFromLedger(ledger)
 
 	// Ensure mob knows about this ledger
 	mi.AddItemLedger(ledger)
@@ -321,18 +374,34 @@ func LuaShop(L *lua.LState) int {
 		TableCell{content: "Description", header: true},
 		TableCell{content: "Buy", header: true},
 	)}
-	for _, ii := range mi.Inventory().Items() {
-		ledgerEntry := ledger.Contains(ii.Name())
-		if ledgerEntry != nil && ledgerEntry.BuyPrice > 0 {
-			buyTable = append(buyTable, TableRow(
-				TableCell{content: ii.FormattedName()},
-				TableCell{content: ii.Attribute(AttributeDescription)},
-				TableCell{content: TextStyle(
-					fmt.Sprintf("Buy %s <%s>", ii.Name(), misc.Money.FormatMoney(ledgerEntry.BuyPrice)),
-					WithLinkCmd(fmt.Sprintf("/buy \"%s\" \"%s\"", mi.Name(), ii.Name())),
-				)},
-			))
+	for _, ledgerEntry := range ledger.Entries() {
+		if ledgerEntry.BuyPrice <= 0 {
+			continue
+		}
+		result := mi.Inventory().GetByName(ledgerEntry.ItemName)
+		if result.Type != RegistryTypeItemInstance {
+			if ledgerEntry.Stock > 0 {
+				buyTable = append(buyTable, TableRow(
+					TableCell{content: TextStyle(ledgerEntry.ItemName, WithBold())},
+					TableCell{content: ""},
+					TableCell{content: TextStyle("Out of stock", WithItalics())},
+				))
+			}
+			continue
 		}
+		ii := result.Object.(*ItemInstance)
+		buy := TextStyle(
+			fmt.Sprintf("Buy %s <%s>", ii.Name(), mi.ShopBuyPrice(ledgerEntry).String()),
+			WithLinkCmd(fmt.Sprintf("/buy \"%s\" \"%s\"", mi.Name(), ii.Name())),
+		)
+		if ledgerEntry.Stock > 0 {
+			buy = fmt.Sprintf("%s (%d left)", buy, mi.ShopStock(ii.Name()))
+		}
+		buyTable = append(buyTable, TableRow(
+			TableCell{content: ii.FormattedName()},
+			TableCell{content: ii.Attribute(AttributeDescription)},
+			TableCell{content: buy},
+		))
 	}
 	c.Player().client.ShowText(TextTable(buyTable...))
 
@@ -347,7 +416,7 @@ func LuaShop(L *lua.LState) int {
 			sellTable = append(sellTable, TableRow(
 				TableCell{content: ii.FormattedName()},
 				TableCell{content: TextStyle(
-					fmt.Sprintf("Sell %s <%s>", ii.Name(), misc.Money.FormatMoney(ledgerEntry.SellPrice)),
+					fmt.Sprintf("Sell %s <%s>", ii.Name(), mi.ShopSellPrice(ledgerEntry).String()),
 					WithLinkCmd(fmt.Sprintf("/sell \"%s\" \"%s\"", mi.Name(), ii.ID())),
 				)},
 			))
@@ -360,6 +429,132 @@ func LuaShop(L *lua.LState) int {
 	return 0
 }
 
+// LuaBank (bank) marks the mob as a banker and displays the bank account to the character that invoked the command.
+func LuaBank(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		return 0
+	}
+
+	mi := LuaMobInstance(L)
+	if mi == nil {
+		return 0
+	}
+
+	// Ensure mob knows it can handle banking
+	mi.SetBanker(true)
+
+	card := c.BankCard()
+	if card == nil {
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s can't open an account for you without a bank card equipped.", mi.FormattedName()),
+		)
+		return 0
+	}
+
+	ba := Armeria.bankManager.AccountForCard(card, c)
+
+	c.Player().client.ShowText(TextTable(
+		TableRow(
+			TableCell{content: "Bank Card", header: true},
+			TableCell{content: "Balance", header: true},
+			TableCell{content: "History", header: true},
+		),
+		TableRow(
+			TableCell{content: card.FormattedName()},
+			TableCell{content: c.Colorize(ba.Money().String(), ColorMoney)},
+			TableCell{content: TextStyle("View Transactions", WithLinkCmd("/balance"))},
+		),
+	))
+	c.Player().client.ShowText(
+		TextStyle("Use /deposit or /withdraw to move money in or out of your account.", WithItalics()),
+	)
+
+	return 0
+}
+
+// LuaAuctioneer (auctioneer) marks the mob as an auctioneer and introduces the auction house to the character that
+// invoked the command.
+func LuaAuctioneer(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		return 0
+	}
+
+	mi := LuaMobInstance(L)
+	if mi == nil {
+		return 0
+	}
+
+	// Ensure mob knows it can handle the auction house
+	mi.SetAuctioneer(true)
+
+	c.Player().client.ShowText(
+		fmt.Sprintf(
+			"%s can help you buy and sell items at the auction house. There are %s up for auction.",
+			mi.FormattedName(),
+			TextStyle(
+				fmt.Sprintf("%d items", len(Armeria.auctionManager.Auctions())),
+				WithBold(),
+				WithLinkCmd("/auction list"),
+			),
+		),
+	)
+	c.Player().client.ShowText(
+		TextStyle("Use /auction list to browse the auctions, or /auction sell to list an item.", WithItalics()),
+	)
+
+	return 0
+}
+
+// LuaRepairer (repairer) marks the mob as a repairer and tells the character that invoked the command which of
+// their items need repairing.
+func LuaRepairer(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		return 0
+	}
+
+	mi := LuaMobInstance(L)
+	if mi == nil {
+		return 0
+	}
+
+	// Ensure mob knows it can handle repairs
+	mi.SetRepairer(true)
+
+	c.Player().client.ShowText(
+		fmt.Sprintf("%s can repair your damaged equipment.", mi.FormattedName()),
+	)
+	c.Player().client.ShowText(
+		TextStyle(fmt.Sprintf("Use /repair %s to see what needs repairing.", mi.Name()), WithItalics()),
+	)
+
+	return 0
+}
+
+// LuaItemWear (i_wear) wears an item that a character has, lowering its condition.
+func LuaItemWear(L *lua.LState) int {
+	uuid := L.ToString(1)
+	amount := L.ToInt(2)
+
+	o, rt := Armeria.registry.Get(uuid)
+	if rt != RegistryTypeItemInstance {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	ii := o.(*ItemInstance)
+	if c := ii.Character(); c != nil {
+		c.WearItem(ii, amount)
+	} else {
+		ii.Wear(amount)
+	}
+
+	L.Push(lua.LNumber(ii.Condition()))
+	return 1
+}
+
 // LuaRoomText (room_text) sends arbitrary text to the room.
 func LuaRoomText(L *lua.LState) int {
 	text := L.ToString(1)
@@ -403,10 +598,16 @@ func CallMobFunc(invoker *Character, mi *MobInstance, funcName string, args ...l
 	L.SetGlobal("convo_select", L.NewFunction(LuaConvoSelect))
 	L.SetGlobal("c_attr", L.NewFunction(LuaCharacterAttribute))
 	L.SetGlobal("c_set_attr", L.NewFunction(LuaSetCharacterAttribute))
+	L.SetGlobal("c_party", L.NewFunction(LuaCharacterParty))
+	L.SetGlobal("c_party_leader", L.NewFunction(LuaCharacterPartyLeader))
 	L.SetGlobal("i_name", L.NewFunction(LuaItemName))
 	L.SetGlobal("give", L.NewFunction(LuaInventoryGive))
 	L.SetGlobal("room_text", L.NewFunction(LuaRoomText))
 	L.SetGlobal("shop", L.NewFunction(LuaShop))
+	L.SetGlobal("bank", L.NewFunction(LuaBank))
+	L.SetGlobal("auctioneer", L.NewFunction(LuaAuctioneer))
+	L.SetGlobal("repairer", L.NewFunction(LuaRepairer))
+	L.SetGlobal("i_wear", L.NewFunction(LuaItemWear))
 
 	// Set "room" module.
 	L.PreloadModule("room", func(state *lua.LState) int {
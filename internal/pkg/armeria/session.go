@@ -0,0 +1,169 @@
+package armeria
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SessionLifetime is how long a session token stays valid without being used to log in.
+	SessionLifetime = 30 * 24 * time.Hour
+	// SessionTokenBytes is how many random bytes make up a session token.
+	SessionTokenBytes int = 32
+	// MaxSessionLabelLength is the longest a session's device label can be.
+	MaxSessionLabelLength int = 40
+	// DefaultSessionLabel is the device label used when the character doesn't provide one.
+	DefaultSessionLabel string = "Unnamed device"
+)
+
+var (
+	// ErrSessionInvalid is an error for when a session token doesn't match any of the character's sessions.
+	ErrSessionInvalid = errors.New("invalid session token")
+	// ErrSessionExpired is an error for when a session token hasn't been used for longer than SessionLifetime.
+	ErrSessionExpired = errors.New("session token expired")
+)
+
+// Session is a login session issued to a character, allowing a device to log in without a password. Only a hash
+// of the token is stored, so the data file can't be used to log in.
+type Session struct {
+	ID        string    `json:"id"`
+	TokenHash string    `json:"tokenHash"`
+	Label     string    `json:"label"`
+	Created   time.Time `json:"created"`
+	LastUsed  time.Time `json:"lastUsed"`
+	Expires   time.Time `json:"expires"`
+}
+
+// Expired returns true if the Session can no longer be used to log in.
+func (s *Session) Expired() bool {
+	return time.Now().After(s.Expires)
+}
+
+// hashSessionToken returns the hash of a session token, as it is stored with the Session.
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex returns n random bytes, hex encoded.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		Armeria.log.Fatal("error generating random bytes",
+			zap.Error(err),
+		)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// Sessions returns the Character's login sessions that haven't expired.
+func (c *Character) Sessions() []*Session {
+	c.Lock()
+	defer c.Unlock()
+
+	c.pruneSessions()
+
+	return c.UnsafeSessions
+}
+
+// pruneSessions removes the Character's expired sessions. The caller must hold the lock.
+func (c *Character) pruneSessions() {
+	var sessions []*Session
+	for _, s := range c.UnsafeSessions {
+		if !s.Expired() {
+			sessions = append(sessions, s)
+		}
+	}
+
+	c.UnsafeSessions = sessions
+}
+
+// CreateSession issues a new login session for the Character and returns it, along with the token the device
+// must present to log in. The token is never stored, so it can't be retrieved again.
+func (c *Character) CreateSession(label string) (*Session, string) {
+	label = strings.TrimSpace(label)
+	if len(label) == 0 {
+		label = DefaultSessionLabel
+	} else if len(label) > MaxSessionLabelLength {
+		label = label[:MaxSessionLabelLength]
+	}
+
+	token := randomHex(SessionTokenBytes)
+	now := time.Now()
+	s := &Session{
+		ID:        randomHex(4),
+		TokenHash: hashSessionToken(token),
+		Label:     label,
+		Created:   now,
+		LastUsed:  now,
+		Expires:   now.Add(SessionLifetime),
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.pruneSessions()
+	c.UnsafeSessions = append(c.UnsafeSessions, s)
+
+	return s, token
+}
+
+// UseSession finds the Session matching a token and extends its expiry. Returns an error if the token doesn't
+// match a Session, or the Session has expired.
+func (c *Character) UseSession(token string) (*Session, error) {
+	hash := hashSessionToken(token)
+
+	c.Lock()
+	defer c.Unlock()
+
+	for _, s := range c.UnsafeSessions {
+		if s.TokenHash != hash {
+			continue
+		}
+
+		if s.Expired() {
+			c.pruneSessions()
+			return nil, ErrSessionExpired
+		}
+
+		s.LastUsed = time.Now()
+		s.Expires = s.LastUsed.Add(SessionLifetime)
+		return s, nil
+	}
+
+	return nil, ErrSessionInvalid
+}
+
+// RevokeSession removes one of the Character's sessions, by id. Returns false if there is no such session.
+func (c *Character) RevokeSession(id string) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	for i, s := range c.UnsafeSessions {
+		if strings.ToLower(s.ID) == strings.ToLower(id) {
+			c.UnsafeSessions = append(c.UnsafeSessions[:i], c.UnsafeSessions[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// RevokeAllSessions removes all of the Character's sessions and returns how many there were.
+func (c *Character) RevokeAllSessions() int {
+	c.Lock()
+	defer c.Unlock()
+
+	c.pruneSessions()
+	count := len(c.UnsafeSessions)
+	c.UnsafeSessions = nil
+
+	return count
+}
@@ -0,0 +1,144 @@
+package armeria
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRestockMinutes is how often a shopkeeper restocks, and recovers from supply and demand, when the
+	// ledger entry doesn't specify an interval.
+	DefaultRestockMinutes int = 10
+	// MinPriceMultiplier is the lowest a price can fall to, as a multiple of the ledger price, due to supply.
+	MinPriceMultiplier float64 = 0.1
+	// MaxPriceMultiplier is the highest a price can rise to, as a multiple of the ledger price, due to demand.
+	MaxPriceMultiplier float64 = 3
+)
+
+// ShopItemState tracks how a single ledger item has been traded with a shopkeeper.
+type ShopItemState struct {
+	Supply      int       `json:"supply"`
+	LastRestock time.Time `json:"lastRestock"`
+}
+
+// RestockInterval returns how often shopkeepers restock the item.
+func (le *LedgerEntry) RestockInterval() time.Duration {
+	if le.RestockMinutes > 0 {
+		return time.Duration(le.RestockMinutes) * time.Minute
+	}
+
+	return time.Duration(DefaultRestockMinutes) * time.Minute
+}
+
+// AdjustedPrice returns a ledger price adjusted for supply. Each unit a shopkeeper has been sold lowers the price
+// by the entry's elasticity, and each unit they have sold raises it.
+func (le *LedgerEntry) AdjustedPrice(price Money, supply int) Money {
+	if le.Elasticity <= 0 || supply == 0 {
+		return price
+	}
+
+	var m float64
+	if supply > 0 {
+		m = math.Pow(1-le.Elasticity, float64(supply))
+	} else {
+		m = math.Pow(1+le.Elasticity, float64(-supply))
+	}
+
+	m = math.Max(MinPriceMultiplier, math.Min(MaxPriceMultiplier, m))
+
+	return Money(math.Round(float64(price) * m))
+}
+
+// shopItemState returns the shop state for an item, creating it if needed. The caller must hold the lock.
+func (mi *MobInstance) shopItemState(name string) *ShopItemState {
+	if mi.UnsafeShopState == nil {
+		mi.UnsafeShopState = make(map[string]*ShopItemState)
+	}
+
+	key := strings.ToLower(name)
+	if mi.UnsafeShopState[key] == nil {
+		mi.UnsafeShopState[key] = &ShopItemState{}
+	}
+
+	return mi.UnsafeShopState[key]
+}
+
+// ShopSupply returns the net number of units of an item that characters have sold to the mob.
+func (mi *MobInstance) ShopSupply(name string) int {
+	mi.Lock()
+	defer mi.Unlock()
+
+	return mi.shopItemState(name).Supply
+}
+
+// AdjustShopSupply changes the net number of units of an item that characters have sold to the mob.
+func (mi *MobInstance) AdjustShopSupply(name string, delta int) {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.shopItemState(name).Supply += delta
+}
+
+// ShopBuyPrice returns what the mob charges for an item on a ledger.
+func (mi *MobInstance) ShopBuyPrice(le *LedgerEntry) Money {
+	return le.AdjustedPrice(le.BuyPrice, mi.ShopSupply(le.ItemName))
+}
+
+// ShopSellPrice returns what the mob pays for an item on a ledger.
+func (mi *MobInstance) ShopSellPrice(le *LedgerEntry) Money {
+	return le.AdjustedPrice(le.SellPrice, mi.ShopSupply(le.ItemName))
+}
+
+// ShopStock returns the number of units of an item that the mob has available to sell.
+func (mi *MobInstance) ShopStock(name string) int {
+	return mi.Inventory().ItemQuantity(name)
+}
+
+// RestockFromLedger restocks the limited items on a ledger, up to their stock level, and moves supply one unit
+// back towards normal, once the restock interval has passed. A shopkeeper that has never been restocked is
+// stocked immediately.
+func (mi *MobInstance) RestockFromLedger(ledger *Ledger) {
+	for _, entry := range ledger.Entries() {
+		mi.Lock()
+		st := mi.shopItemState(entry.ItemName)
+		due := st.LastRestock.IsZero() || time.Since(st.LastRestock) >= entry.RestockInterval()
+		if due {
+			st.LastRestock = time.Now()
+			if st.Supply > 0 {
+				st.Supply--
+			} else if st.Supply < 0 {
+				st.Supply++
+			}
+		}
+		mi.Unlock()
+
+		if !due || entry.Stock <= 0 || entry.BuyPrice <= 0 {
+			continue
+		}
+
+		item := Armeria.itemManager.ItemByName(entry.ItemName)
+		if item == nil {
+			continue
+		}
+
+		for i := mi.ShopStock(entry.ItemName); i < entry.Stock; i++ {
+			ii := item.CreateInstance()
+			if _, err := mi.Inventory().AddItem(ii); err != nil {
+				item.DeleteInstance(ii)
+				break
+			}
+		}
+	}
+}
+
+// ShopRestock restocks every shopkeeper that characters have shopped with.
+func ShopRestock() {
+	for _, m := range Armeria.mobManager.Mobs() {
+		for _, mi := range m.Instances() {
+			for _, ledger := range mi.ItemLedgers() {
+				mi.RestockFromLedger(ledger)
+			}
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package armeria
 
 import (
+	"net"
 	"net/http"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -16,6 +18,21 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// requestIP returns the address of the client making the request. Behind a proxy (ie: Heroku), the original
+// address is the first one in the X-Forwarded-For header.
+func requestIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); len(fwd) > 0 {
+		return strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
 // ServeWs upgrades the connection to a WebSocket
 func ServeWs(w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)
@@ -26,7 +43,7 @@ func ServeWs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	p := Armeria.playerManager.NewPlayer(conn)
+	p := Armeria.playerManager.NewPlayer(conn, requestIP(r))
 	p.SetupPumps()
 	p.Connected()
 }
@@ -14,24 +14,36 @@ import (
 
 // GameState stores the manager singletons and any other global state.
 type GameState struct {
-	log              *zap.Logger
-	production       bool
-	playerManager    *PlayerManager
-	commandManager   *CommandManager
-	characterManager *CharacterManager
-	worldManager     *WorldManager
-	mobManager       *MobManager
-	itemManager      *ItemManager
-	convoManager     *ConversationManager
-	ledgerManager    *LedgerManager
-	tickManager      *TickManager
-	registry         *Registry
-	channels         map[string]*Channel
-	publicPath       string
-	dataPath         string
-	objectImagesPath string
-	startTime        time.Time
-	github           *github.ArmeriaRepo
+	log                *zap.Logger
+	production         bool
+	playerManager      *PlayerManager
+	commandManager     *CommandManager
+	characterManager   *CharacterManager
+	accountManager     *AccountManager
+	worldManager       *WorldManager
+	mobManager         *MobManager
+	itemManager        *ItemManager
+	convoManager       *ConversationManager
+	ledgerManager      *LedgerManager
+	bankManager        *BankManager
+	economyManager     *EconomyManager
+	auctionManager     *AuctionManager
+	recipeManager      *RecipeManager
+	moderationManager  *ModerationManager
+	channelManager     *ChannelManager
+	chatHistoryManager *ChatHistoryManager
+	guildManager       *GuildManager
+	creationManager    *CreationManager
+	loginGuard         *LoginGuard
+	tickManager        *TickManager
+	auditLog           *AuditLog
+	registry           *Registry
+	channels           map[string]*Channel
+	publicPath         string
+	dataPath           string
+	objectImagesPath   string
+	startTime          time.Time
+	github             *github.ArmeriaRepo
 }
 
 var (
@@ -55,6 +67,7 @@ func Init(configFilePath string, serveTraffic bool) {
 		log.Fatalf("error initializing zap logger: %s", err)
 	}
 	Armeria.log = logger
+	Armeria.creationManager = NewCreationManager(c.Creation)
 
 	if !serveTraffic {
 		return
@@ -62,16 +75,14 @@ func Init(configFilePath string, serveTraffic bool) {
 
 	verifySchemaVersion()
 
-	Armeria.registry = NewRegistry()
+	Armeria.loadGameData()
+
+	Armeria.auditLog = NewAuditLog()
 	Armeria.commandManager = NewCommandManager()
 	Armeria.playerManager = NewPlayerManager()
-	Armeria.characterManager = NewCharacterManager()
-	Armeria.worldManager = NewWorldManager()
-	Armeria.mobManager = NewMobManager()
-	Armeria.itemManager = NewItemManager()
+	Armeria.loginGuard = NewLoginGuard()
 	Armeria.channels = NewChannels()
 	Armeria.convoManager = NewConversationManager()
-	Armeria.ledgerManager = NewLedgerManager()
 	Armeria.tickManager = NewTickManager()
 
 	Armeria.github = github.New()
@@ -93,6 +104,35 @@ func Init(configFilePath string, serveTraffic bool) {
 	InitWeb(port)
 }
 
+// InitOffline loads the game data from disk without starting the tickers or serving any traffic. This is used
+// by the command-line tooling that needs to read or modify the game data while the server is stopped.
+func InitOffline(configFilePath string) {
+	Init(configFilePath, false)
+
+	verifySchemaVersion()
+
+	Armeria.loadGameData()
+}
+
+// loadGameData creates the global registry and the managers that are persisted to disk.
+func (gs *GameState) loadGameData() {
+	gs.registry = NewRegistry()
+	gs.characterManager = NewCharacterManager()
+	gs.accountManager = NewAccountManager()
+	gs.worldManager = NewWorldManager()
+	gs.mobManager = NewMobManager()
+	gs.itemManager = NewItemManager()
+	gs.ledgerManager = NewLedgerManager()
+	gs.bankManager = NewBankManager()
+	gs.economyManager = NewEconomyManager()
+	gs.auctionManager = NewAuctionManager()
+	gs.recipeManager = NewRecipeManager()
+	gs.moderationManager = NewModerationManager()
+	gs.channelManager = NewChannelManager()
+	gs.chatHistoryManager = NewChatHistoryManager()
+	gs.guildManager = NewGuildManager()
+}
+
 func (gs *GameState) setupGracefulExit() {
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGTERM)
@@ -106,8 +146,17 @@ func (gs *GameState) setupGracefulExit() {
 // Save writes the in-memory data to disk.
 func (gs *GameState) Save() {
 	gs.characterManager.SaveCharacters()
+	gs.accountManager.SaveAccounts()
 	gs.worldManager.SaveWorld()
 	gs.mobManager.SaveMobs()
 	gs.itemManager.SaveItems()
 	gs.ledgerManager.SaveLedgers()
+	gs.bankManager.SaveAccounts()
+	gs.economyManager.SaveEconomy()
+	gs.auctionManager.SaveAuctions()
+	gs.recipeManager.SaveRecipes()
+	gs.moderationManager.SaveSanctions()
+	gs.channelManager.SaveChannels()
+	gs.chatHistoryManager.SaveHistory()
+	gs.guildManager.SaveGuilds()
 }
@@ -0,0 +1,31 @@
+package armeria
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// setupTestGameState replaces the global game state with an empty one that writes to a temporary directory. The
+// returned function removes the directory once the test is done with it.
+func setupTestGameState(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "armeria-test")
+	if err != nil {
+		t.Fatalf("error creating data directory: %s", err)
+	}
+
+	Armeria = &GameState{
+		log:              zap.NewNop(),
+		dataPath:         dir,
+		objectImagesPath: dir + "/object-images",
+		registry:         NewRegistry(),
+		characterManager: &CharacterManager{},
+	}
+	Armeria.auditLog = NewAuditLog()
+
+	return func() {
+		_ = os.RemoveAll(dir)
+	}
+}
@@ -46,6 +46,26 @@ func NewTickManager() *TickManager {
 				Handler:  MobSpawner,
 				Interval: 1 * time.Minute,
 			},
+			{
+				Name:     "ShopRestock",
+				Handler:  ShopRestock,
+				Interval: 1 * time.Minute,
+			},
+			{
+				Name:     "AuctionExpiry",
+				Handler:  AuctionExpiry,
+				Interval: 1 * time.Minute,
+			},
+			{
+				Name:     "SanctionExpiry",
+				Handler:  SanctionExpiry,
+				Interval: 1 * time.Minute,
+			},
+			{
+				Name:     "CraftingProgress",
+				Handler:  CraftingProgress,
+				Interval: 1 * time.Second,
+			},
 			{
 				Name:     "MobMovement",
 				Handler:  MobMovement,
@@ -123,8 +143,23 @@ func WipeDanglingInstances() {
 
 	for _, o := range mobsAndItems {
 		obj := o.(ContainerObject)
+
+		// Skip anything deleted along with a container item earlier in the loop
+		if _, rt := Armeria.registry.Get(obj.ID()); rt == RegistryTypeUnknown {
+			continue
+		}
+
 		container := Armeria.registry.GetObjectContainer(obj.ID())
-		if container == nil {
+		dangling := container == nil
+		if !dangling {
+			// Items within a container item are dangling when the container item is no longer in the game
+			if parent := container.ParentItemInstance(); parent != nil {
+				_, rt := Armeria.registry.Get(parent.ID())
+				dangling = rt == RegistryTypeUnknown
+			}
+		}
+
+		if dangling {
 			Armeria.log.Info(
 				"found dangling object instance",
 				zap.String("uuid", obj.ID()),
@@ -133,7 +168,10 @@ func WipeDanglingInstances() {
 			if obj.Type() == ContainerObjectTypeMob {
 				obj.(*MobInstance).Parent.DeleteInstance(obj.(*MobInstance))
 			} else if obj.Type() == ContainerObjectTypeItem {
-				obj.(*ItemInstance).Parent.DeleteInstance(obj.(*ItemInstance))
+				if container != nil {
+					container.Remove(obj.ID())
+				}
+				obj.(*ItemInstance).Delete()
 			}
 
 			Armeria.log.Info(
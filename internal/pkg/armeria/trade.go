@@ -0,0 +1,350 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// TradeOffer is what one side of a Trade is putting up in exchange.
+type TradeOffer struct {
+	Items []*ItemInstance
+	Money Money
+}
+
+// Trade is an exchange of items and money between two characters. Both characters must accept the offers
+// before anything changes hands, and any change to an offer withdraws both acceptances.
+type Trade struct {
+	sync.Mutex
+	Initiator *Character
+	Partner   *Character
+	open      bool
+	done      bool
+	offers    map[*Character]*TradeOffer
+	accepted  map[*Character]bool
+}
+
+var (
+	// ErrTradeClosed is an error for when a trade is changed after it was completed or cancelled.
+	ErrTradeClosed = errors.New("the trade is no longer open")
+	// ErrTradeNotOpen is an error for when a trade is changed before the partner has joined.
+	ErrTradeNotOpen = errors.New("the other character hasn't joined the trade yet")
+	// ErrTradeAlreadyOffered is an error for when an item is added to an offer twice.
+	ErrTradeAlreadyOffered = errors.New("that item is already being offered")
+	// ErrTradeNotOffered is an error for when an item that isn't being offered is removed from an offer.
+	ErrTradeNotOffered = errors.New("that item isn't being offered")
+	// ErrTradeItemMissing is an error for when an offered item is no longer in the character's inventory.
+	ErrTradeItemMissing = errors.New("an offered item is no longer in the inventory")
+	// ErrTradeNotEnoughMoney is an error for when a character cannot afford the money they offered.
+	ErrTradeNotEnoughMoney = errors.New("not enough money to cover the offer")
+	// ErrTradeApart is an error for when the characters are no longer in the same room.
+	ErrTradeApart = errors.New("both characters must be in the same room")
+)
+
+// NewTrade creates a new Trade requested by the initiator.
+func NewTrade(initiator *Character, partner *Character) *Trade {
+	return &Trade{
+		Initiator: initiator,
+		Partner:   partner,
+		offers: map[*Character]*TradeOffer{
+			initiator: {Items: make([]*ItemInstance, 0)},
+			partner:   {Items: make([]*ItemInstance, 0)},
+		},
+		accepted: make(map[*Character]bool),
+	}
+}
+
+// Other returns the character on the other side of the trade.
+func (t *Trade) Other(c *Character) *Character {
+	if c == t.Initiator {
+		return t.Partner
+	}
+
+	return t.Initiator
+}
+
+// Open marks the trade as joined by the partner, allowing offers to be made.
+func (t *Trade) Open() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.open = true
+}
+
+// IsOpen returns true if both characters have joined the trade.
+func (t *Trade) IsOpen() bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.open && !t.done
+}
+
+// Close ends the trade without exchanging anything.
+func (t *Trade) Close() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.done = true
+}
+
+// editable returns an error if offers cannot be changed. The caller must hold the lock.
+func (t *Trade) editable() error {
+	if t.done {
+		return ErrTradeClosed
+	} else if !t.open {
+		return ErrTradeNotOpen
+	}
+
+	return nil
+}
+
+// AddItem adds an item to a character's offer.
+func (t *Trade) AddItem(c *Character, ii *ItemInstance) error {
+	t.Lock()
+	defer t.Unlock()
+
+	if err := t.editable(); err != nil {
+		return err
+	}
+
+	for _, o := range t.offers[c].Items {
+		if o == ii {
+			return ErrTradeAlreadyOffered
+		}
+	}
+
+	t.offers[c].Items = append(t.offers[c].Items, ii)
+	t.accepted = make(map[*Character]bool)
+
+	return nil
+}
+
+// RemoveItem removes an item from a character's offer.
+func (t *Trade) RemoveItem(c *Character, ii *ItemInstance) error {
+	t.Lock()
+	defer t.Unlock()
+
+	if err := t.editable(); err != nil {
+		return err
+	}
+
+	items := t.offers[c].Items
+	for i, o := range items {
+		if o == ii {
+			t.offers[c].Items = append(items[:i], items[i+1:]...)
+			t.accepted = make(map[*Character]bool)
+			return nil
+		}
+	}
+
+	return ErrTradeNotOffered
+}
+
+// SetMoney sets the amount of money in a character's offer.
+func (t *Trade) SetMoney(c *Character, amount Money) error {
+	t.Lock()
+	defer t.Unlock()
+
+	if err := t.editable(); err != nil {
+		return err
+	}
+
+	if amount > c.Money() {
+		return ErrTradeNotEnoughMoney
+	}
+
+	t.offers[c].Money = amount
+	t.accepted = make(map[*Character]bool)
+
+	return nil
+}
+
+// Offer returns a character's offer.
+func (t *Trade) Offer(c *Character) *TradeOffer {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.offers[c]
+}
+
+// Accepted returns true if the character has accepted the current offers.
+func (t *Trade) Accepted(c *Character) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.accepted[c]
+}
+
+// Accept marks the current offers as accepted by a character. Once both characters have accepted, the exchange is
+// executed and true is returned.
+func (t *Trade) Accept(c *Character) (bool, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	if err := t.editable(); err != nil {
+		return false, err
+	}
+
+	t.accepted[c] = true
+	if !t.accepted[t.Initiator] || !t.accepted[t.Partner] {
+		return false, nil
+	}
+
+	if err := t.execute(); err != nil {
+		t.accepted = make(map[*Character]bool)
+		return false, err
+	}
+
+	t.done = true
+
+	return true, nil
+}
+
+// execute exchanges the offers between both characters. Either everything changes hands, or nothing does. The
+// caller must hold the lock.
+func (t *Trade) execute() error {
+	a, b := t.Initiator, t.Partner
+
+	if a.Room() != b.Room() {
+		return ErrTradeApart
+	}
+
+	for _, c := range []*Character{a, b} {
+		for _, ii := range t.offers[c].Items {
+			if !c.Inventory().Contains(ii.ID()) {
+				return ErrTradeItemMissing
+			}
+		}
+		if t.offers[c].Money > c.Money() {
+			return ErrTradeNotEnoughMoney
+		}
+	}
+
+	type move struct {
+		item *ItemInstance
+		from *Character
+		to   *Character
+	}
+
+	var moves []*move
+	for _, c := range []*Character{a, b} {
+		for _, ii := range t.offers[c].Items {
+			moves = append(moves, &move{item: ii, from: c, to: t.Other(c)})
+		}
+	}
+
+	// Take every offered item out of the inventories first, so that items going in one direction free up
+	// room for items going in the other.
+	for _, m := range moves {
+		m.from.Inventory().Remove(m.item.ID())
+	}
+
+	for i, m := range moves {
+		if err := m.to.Inventory().Add(m.item.ID()); err != nil {
+			for _, done := range moves[:i] {
+				done.to.Inventory().Remove(done.item.ID())
+			}
+			for _, m := range moves {
+				_ = m.from.Inventory().Add(m.item.ID())
+			}
+			return err
+		}
+	}
+
+	tx := NewMoneyTransaction()
+	for _, c := range []*Character{a, b} {
+		if amount := t.offers[c].Money; amount > 0 {
+			tx.Move(c, t.Other(c), amount, MoneyReasonTrade)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		for _, m := range moves {
+			m.to.Inventory().Remove(m.item.ID())
+			_ = m.from.Inventory().Add(m.item.ID())
+		}
+		if err == ErrInsufficientFunds {
+			return ErrTradeNotEnoughMoney
+		}
+		return err
+	}
+
+	// Stacking can delete the traded items, so it waits until nothing needs to be rolled back.
+	for _, m := range moves {
+		m.to.Inventory().Remove(m.item.ID())
+		if _, err := m.to.Inventory().AddItem(m.item); err != nil {
+			_ = m.to.Inventory().Add(m.item.ID())
+		}
+	}
+
+	Armeria.log.Info("trade completed",
+		zap.String("initiator", a.Name()),
+		zap.Int("initiatorItems", len(t.offers[a].Items)),
+		zap.Int64("initiatorMoney", int64(t.offers[a].Money)),
+		zap.String("partner", b.Name()),
+		zap.Int("partnerItems", len(t.offers[b].Items)),
+		zap.Int64("partnerMoney", int64(t.offers[b].Money)),
+	)
+
+	return nil
+}
+
+// CancelTrade closes a trade and detaches it from both characters, letting whoever is still online know why.
+func CancelTrade(t *Trade, reason string) {
+	t.Close()
+
+	for _, c := range []*Character{t.Initiator, t.Partner} {
+		if c.Trade() != t {
+			continue
+		}
+		c.SetTrade(nil)
+		if c.Online() {
+			c.Player().client.ShowColorizedText(reason, ColorError)
+		}
+	}
+}
+
+// Window returns a table showing both offers from the point of view of a character.
+func (t *Trade) Window(c *Character) string {
+	other := t.Other(c)
+
+	offerCell := func(o *TradeOffer) string {
+		var lines []string
+		for _, ii := range o.Items {
+			lines = append(lines, ii.FormattedName())
+		}
+		if o.Money > 0 {
+			lines = append(lines, c.Colorize(o.Money.String(), ColorMoney))
+		}
+		if len(lines) == 0 {
+			return TextStyle("Nothing", WithItalics())
+		}
+
+		return strings.Join(lines, ", ")
+	}
+
+	status := func(ch *Character) string {
+		if t.Accepted(ch) {
+			return "Accepted"
+		}
+		return "Not accepted"
+	}
+
+	return TextTable(
+		TableRow(
+			TableCell{content: "You Offer", header: true},
+			TableCell{content: fmt.Sprintf("%s Offers", other.FormattedName()), header: true},
+		),
+		TableRow(
+			TableCell{content: offerCell(t.Offer(c))},
+			TableCell{content: offerCell(t.Offer(other))},
+		),
+		TableRow(
+			TableCell{content: status(c)},
+			TableCell{content: status(other)},
+		),
+	)
+}
@@ -126,6 +126,32 @@ func (m *WorldManager) CreateArea(name string) *Area {
 	return a
 }
 
+// AddArea adds an already-initialized Area to memory.
+func (m *WorldManager) AddArea(a *Area) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeWorld = append(m.UnsafeWorld, a)
+}
+
+// RemoveArea removes an Area, and the rooms within it, from memory.
+func (m *WorldManager) RemoveArea(a *Area) {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, existing := range m.UnsafeWorld {
+		if existing == a {
+			m.UnsafeWorld = append(m.UnsafeWorld[:i], m.UnsafeWorld[i+1:]...)
+			break
+		}
+	}
+
+	for _, r := range a.Rooms() {
+		r.Deinit()
+	}
+	a.Deinit()
+}
+
 func (m *WorldManager) AreaByName(name string) *Area {
 	m.RLock()
 	defer m.RUnlock()
@@ -2,6 +2,7 @@ package misc
 
 import (
 	"math/rand"
+	"strconv"
 	"strings"
 	"time"
 
@@ -179,4 +180,50 @@ func IsUUID(in string) bool {
 	}
 
 	return true
-}
\ No newline at end of file
+}
+
+// MinMax returns the two ints ordered from smallest to largest.
+func MinMax(a, b int) (int, int) {
+	if a > b {
+		return b, a
+	}
+
+	return a, b
+}
+
+// ParseDuration parses a duration string (ie: 30m, 12h or 7d). In addition to the units supported by
+// time.ParseDuration, a number of days can be specified with the "d" suffix.
+func ParseDuration(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(s)
+}
+
+// ParseSince returns the time that is a duration (ie: 30m, 12h or 7d) before now, or the start of a date in the
+// format YYYY-MM-DD.
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.AddDate(0, 0, -days), nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return now.Add(-d), nil
+}
@@ -31,6 +31,7 @@ export default new Vuex.Store({
     itemTooltipCache: [],
     itemTooltipMouseCoords: { x: 0, y: 0 },
     money: '0',
+    party: [],
     commandDictionary: [],
     sentKeepAlive: 0,
     pingTime: 0,
@@ -205,6 +206,10 @@ export default new Vuex.Store({
       state.money = money;
     },
 
+    SET_PARTY: (state, party) => {
+      state.party = party;
+    },
+
     SET_COMMAND_DICTIONARY: (state, dictionary) => {
       state.commandDictionary = dictionary;
     },
@@ -247,6 +252,9 @@ export default new Vuex.Store({
       if (payload.command.indexOf('logintoken') === 1) {
         // Hide the actual token from the command echo'd to the main text area.
         echoCmd = `${payload.command.split(':')[0]}:&lt;redacted&gt;`
+      } else if (payload.command.toLowerCase().indexOf('/create ') === 0) {
+        // Character creation answers may include a password.
+        echoCmd = '/create &lt;redacted&gt;';
       }
       if (typeof payload.hidden !== 'boolean' || !payload.hidden) {
         commit('ADD_GAME_TEXT', `<div class="inline-loopback">${echoCmd}</div>`);
@@ -358,12 +366,8 @@ export default new Vuex.Store({
       commit('KEEP_ALIVE_RESPONSE');
     },
 
-    toggleAutoLogin: ({ state, commit }, payload) => {
-      if (state.autoLoginToken !== '') {
-        commit('SET_AUTOLOGIN_TOKEN', '');
-      } else {
-        commit('SET_AUTOLOGIN_TOKEN', payload.data);
-      }
+    toggleAutoLogin: ({ commit }, payload) => {
+      commit('SET_AUTOLOGIN_TOKEN', payload.data || '');
     },
 
     setInventory: ({ commit }, payload) => {
@@ -390,6 +394,10 @@ export default new Vuex.Store({
       commit('SET_MONEY', payload.data);
     },
 
+    setParty: ({ commit }, payload) => {
+      commit('SET_PARTY', JSON.parse(payload.data) || []);
+    },
+
     playSFX: (_, payload) => {
       const sfx = JSON.parse(payload.data);
       Vue.prototype.$soundEvent(sfx.id, sfx.volume);